@@ -23,7 +23,7 @@ func main() {
 	}
 	defer ctn.Cleanup()
 
-	csm := consumer.NewConsumer(ctn.Log, ctn.MQPro, ctn.SMTPPro)
+	csm := consumer.NewConsumer(ctn.Log, ctn.MQPro, ctn.SMTPPro, ctn.SMSPro, ctn.StorPro, ctn.IDGen, ctn.FileRepo, ctn.FileVariantRepo, ctn.EmailLogRepo, ctn.SuppressionRepo)
 	csm.Start()
 
 	log.Println("Consumer is running")
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/oidc/oidctest"
+)
+
+// A local OpenID Connect provider for trying SSO without a real IdP. Point
+// OIDC_ISSUER_URL at it and every authorization request signs in the user
+// described by the MOCK_IDP_* variables.
+func main() {
+	port := getEnv("MOCK_IDP_PORT", "9000")
+	issuer := getEnv("MOCK_IDP_ISSUER", "http://localhost:"+port)
+
+	idp, err := oidctest.New(issuer, getEnv("OIDC_CLIENT_ID", "instay"), getEnv("OIDC_CLIENT_SECRET", "secret"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	idp.SetUser(oidctest.User{
+		Subject:           getEnv("MOCK_IDP_SUBJECT", "mock-user-1"),
+		Email:             getEnv("MOCK_IDP_EMAIL", "staff@instay.local"),
+		EmailVerified:     true,
+		GivenName:         getEnv("MOCK_IDP_GIVEN_NAME", "Mock"),
+		FamilyName:        getEnv("MOCK_IDP_FAMILY_NAME", "Staff"),
+		PreferredUsername: getEnv("MOCK_IDP_USERNAME", "mockstaff"),
+		PhoneNumber:       getEnv("MOCK_IDP_PHONE", "+84900000000"),
+		Groups:            strings.Fields(strings.ReplaceAll(getEnv("MOCK_IDP_GROUPS", "staff"), ",", " ")),
+	})
+
+	log.Printf("Mock IdP is running at %s", issuer)
+	if err = http.ListenAndServe(":"+port, idp); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return fallback
+}
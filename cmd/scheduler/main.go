@@ -26,9 +26,37 @@ func main() {
 
 	sched := scheduler.NewScheduler(ctn.Log)
 
-	job := job.NewCleanTokenJob(ctn.Log, ctn.TokenRepo)
+	cleanTokenJob := job.NewCleanTokenJob(ctn.Log, ctn.TokenRepo)
 
-	if err := sched.AddJob("12 22 * * *", job); err != nil {
+	if err := sched.AddJob("12 22 * * *", cleanTokenJob); err != nil {
+		log.Println(err)
+		return
+	}
+
+	cleanFileJob, err := job.NewCleanOrphanedFileJob(cfg.FileCleanup, ctn.Log, ctn.StorPro, ctn.FileRepo)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	schedule := cfg.FileCleanup.Schedule
+	if schedule == "" {
+		schedule = "30 3 * * *"
+	}
+
+	if err := sched.AddJob(schedule, cleanFileJob); err != nil {
+		log.Println(err)
+		return
+	}
+
+	abortUploadJob := job.NewAbortStaleMultipartUploadJob(cfg.MultipartCleanup, ctn.Log, ctn.StorPro, ctn.FileRepo, ctn.MultipartUploadRepo, ctn.UploadQuota)
+
+	schedule = cfg.MultipartCleanup.Schedule
+	if schedule == "" {
+		schedule = "0 * * * *"
+	}
+
+	if err := sched.AddJob(schedule, abortUploadJob); err != nil {
 		log.Println(err)
 		return
 	}
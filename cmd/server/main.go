@@ -6,6 +6,8 @@ import (
 	"github.com/InstaySystem/is_v2-be/internal/container"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/pkg/password"
+	"github.com/InstaySystem/is_v2-be/pkg/validator"
 )
 
 func main() {
@@ -14,6 +16,10 @@ func main() {
 		log.Fatalln(err)
 	}
 
+	if err := validator.Register(password.NewPolicy(password.Settings(cfg.Password))); err != nil {
+		log.Fatalln(err)
+	}
+
 	ctn := container.NewContainer(cfg)
 	if err := ctn.InitServer(); err != nil {
 		log.Fatalln(err)
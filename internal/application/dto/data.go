@@ -7,7 +7,69 @@ type ForgotPasswordData struct {
 }
 
 type AuthEmailMessage struct {
-	To      string `json:"to"`
-	Subject string `json:"subject"`
-	Otp     string `json:"otp"`
+	MessageID  string `json:"message_id"`
+	PropertyID *int64 `json:"property_id,omitempty"`
+	To         string `json:"to"`
+	Subject    string `json:"subject"`
+	Otp        string `json:"otp"`
+	Locale     string `json:"locale"`
+}
+
+type SMSOTPMessage struct {
+	MessageID string `json:"message_id"`
+	Phone     string `json:"phone"`
+	Otp       string `json:"otp"`
+	Locale    string `json:"locale"`
+}
+
+type ImageProcessMessage struct {
+	FileID      int64  `json:"file_id"`
+	Key         string `json:"key"`
+	ContentType string `json:"content_type"`
+}
+
+// OIDCStateData is kept in Redis between the authorization redirect and the
+// callback.
+type OIDCStateData struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	Redirect     string `json:"redirect"`
+}
+
+type NotificationData struct {
+	Type  string  `json:"type"`
+	Title string  `json:"title"`
+	Body  string  `json:"body"`
+	Link  *string `json:"link"`
+}
+
+type NotificationEmailMessage struct {
+	MessageID  string  `json:"message_id"`
+	PropertyID *int64  `json:"property_id,omitempty"`
+	To         string  `json:"to"`
+	Title      string  `json:"title"`
+	Body       string  `json:"body"`
+	Link       *string `json:"link"`
+	Locale     string  `json:"locale"`
+}
+
+type EmailAttachment struct {
+	Key      string `json:"key"`
+	FileName string `json:"file_name"`
+}
+
+// EmailMessage is the envelope for every templated email. Template must be
+// registered with the SMTP provider; attachments are loaded from storage by
+// object key when the message is consumed.
+type EmailMessage struct {
+	MessageID string `json:"message_id"`
+	// PropertyID scopes the email log; Send fills it from ctx when unset.
+	PropertyID  *int64            `json:"property_id,omitempty"`
+	Template    string            `json:"template"`
+	Locale      string            `json:"locale"`
+	To          []string          `json:"to"`
+	Cc          []string          `json:"cc,omitempty"`
+	Bcc         []string          `json:"bcc,omitempty"`
+	Data        map[string]any    `json:"data,omitempty"`
+	Attachments []EmailAttachment `json:"attachments,omitempty"`
 }
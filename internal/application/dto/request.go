@@ -1,18 +1,50 @@
 package dto
 
-import "github.com/InstaySystem/is_v2-be/internal/domain/model"
+import (
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
 
 type UploadPresignedURLRequest struct {
-	FileName    string `json:"file_name" binding:"required"`
-	ContentType string `json:"content_type" binding:"required"`
+	FileName    string            `json:"file_name" binding:"required,max=200"`
+	ContentType string            `json:"content_type" binding:"required"`
+	Size        int64             `json:"size" binding:"required,min=1"`
+	Purpose     model.FilePurpose `json:"purpose" binding:"required,oneof=avatar image document video"`
 }
 
 type UploadPresignedURLsRequest struct {
-	Files []UploadPresignedURLRequest `json:"files" binding:"required,min=1,dive"`
+	Files []UploadPresignedURLRequest `json:"files" binding:"required,min=1,max=10,dive"`
+}
+
+type CreateMultipartUploadRequest struct {
+	FileName    string            `json:"file_name" binding:"required,max=200"`
+	ContentType string            `json:"content_type" binding:"required"`
+	Size        int64             `json:"size" binding:"required,min=1"`
+	Purpose     model.FilePurpose `json:"purpose" binding:"required,oneof=avatar image document video"`
+}
+
+type UploadPartURLsRequest struct {
+	PartNumbers []int32 `json:"part_numbers" binding:"required,min=1,max=100,dive,min=1,max=10000"`
+}
+
+type CompletedPartRequest struct {
+	PartNumber int32  `json:"part_number" binding:"required,min=1,max=10000"`
+	ETag       string `json:"etag" binding:"required"`
+}
+
+type CompleteMultipartUploadRequest struct {
+	Parts []CompletedPartRequest `json:"parts" binding:"required,min=1,max=10000,dive"`
 }
 
 type ViewPresignedURLsRequest struct {
-	Keys []string `json:"keys" binding:"required,min=1,dive"`
+	Keys   []string              `json:"keys" binding:"required,min=1,dive"`
+	Size   model.FileVariantSize `json:"size" binding:"omitempty,oneof=thumbnail medium large"`
+	Format string                `json:"format" binding:"omitempty,oneof=webp"`
+}
+
+type ConfirmFilesRequest struct {
+	Keys []string `json:"keys" binding:"required,min=1,dive,required"`
 }
 
 type LoginRequest struct {
@@ -22,11 +54,38 @@ type LoginRequest struct {
 
 type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required,min=6"`
-	NewPassword string `json:"new_password" binding:"required,min=6"`
+	NewPassword string `json:"new_password" binding:"required,password"`
 }
 
 type ForgotPasswordRequest struct {
-	Email string `json:"email" binding:"required,email"`
+	Email string `json:"email" binding:"required_without=Phone,excluded_with=Phone,omitempty,email"`
+	Phone string `json:"phone" binding:"required_without=Email,omitempty,vnphone"`
+}
+
+type MagicLinkRequest struct {
+	Identifier string `json:"identifier" binding:"required,min=5"`
+}
+
+type ConsumeMagicLinkRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type OIDCLoginQuery struct {
+	Redirect string `form:"redirect"`
+}
+
+type OIDCCallbackQuery struct {
+	Code  string `form:"code"`
+	State string `form:"state" binding:"required"`
+	Error string `form:"error"`
+}
+
+type UpdateRoleSettingRequest struct {
+	MagicLinkEnabled *bool `json:"magic_link_enabled" binding:"required"`
+}
+
+type StartImpersonationRequest struct {
+	Reason string `json:"reason" binding:"required,min=5,max=500"`
 }
 
 type VerifyForgotPasswordRequest struct {
@@ -36,26 +95,30 @@ type VerifyForgotPasswordRequest struct {
 
 type ResetPasswordRequest struct {
 	ResetPasswordToken string `json:"reset_password_token" binding:"required,uuid4"`
-	NewPassword        string `json:"new_password" binding:"required,min=6"`
+	NewPassword        string `json:"new_password" binding:"required,password"`
 }
 
 type UpdateInfoRequest struct {
-	Email     string `json:"email" binding:"required,email"`
-	Phone     string `json:"phone" binding:"required,len=10"`
-	FirstName string `json:"first_name" binding:"required"`
-	LastName  string `json:"last_name" binding:"required"`
+	Email              string  `json:"email" binding:"required,email"`
+	Phone              string  `json:"phone" binding:"required,vnphone"`
+	FirstName          string  `json:"first_name" binding:"required"`
+	LastName           string  `json:"last_name" binding:"required"`
+	Locale             string  `json:"locale" binding:"omitempty,oneof=vi en"`
+	AvatarKey          *string `json:"avatar_key" binding:"omitempty,max=255"`
+	EmailNotifications *bool   `json:"email_notifications" binding:"omitempty"`
 }
 
 type CreateUserRequest struct {
 	Username     string         `json:"username" binding:"required,min=5"`
 	Email        string         `json:"email" binding:"required,email"`
-	Phone        string         `json:"phone" binding:"required,len=10"`
-	Password     string         `json:"password" binding:"required,min=6"`
-	Role         model.UserRole `json:"role" binding:"required,oneof=staff admin"`
+	Phone        string         `json:"phone" binding:"required,vnphone"`
+	Password     string         `json:"password" binding:"required,password"`
+	Role         model.UserRole `json:"role" binding:"required,oneof=staff admin chain_admin"`
 	IsActive     *bool          `json:"is_active" binding:"required"`
 	FirstName    string         `json:"first_name" binding:"required"`
 	LastName     string         `json:"last_name" binding:"required"`
 	DepartmentID *int64         `json:"department_id" binding:"omitempty"`
+	PropertyID   *int64         `json:"property_id" binding:"omitempty,min=1"`
 }
 
 type CreateDepartmentRequest struct {
@@ -63,6 +126,7 @@ type CreateDepartmentRequest struct {
 	Phone       string `json:"phone" binding:"required,max=20"`
 	Description string `json:"description" binding:"required,min=1"`
 	IsActive    bool   `json:"is_active" binding:"required"`
+	PropertyID  *int64 `json:"property_id" binding:"omitempty,min=1"`
 }
 
 type UserPaginationQuery struct {
@@ -76,21 +140,113 @@ type UserPaginationQuery struct {
 	Search       string `form:"search" json:"search"`
 }
 
+type NotificationPaginationQuery struct {
+	Page   uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit  uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Unread *bool  `form:"unread" binding:"omitempty" json:"unread"`
+}
+
+type NotifyOnDutyRequest struct {
+	DepartmentID         int64   `json:"department_id" binding:"required,min=1"`
+	Title                string  `json:"title" binding:"required,max=255"`
+	Body                 string  `json:"body" binding:"required"`
+	Link                 *string `json:"link" binding:"omitempty,url,max=500"`
+	FallbackToDepartment bool    `json:"fallback_to_department"`
+}
+
+type EmailLogPaginationQuery struct {
+	Page      uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit     uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Recipient string `form:"recipient" binding:"omitempty,max=150" json:"recipient"`
+	Status    string `form:"status" binding:"omitempty,oneof=sent retrying failed bounced suppressed" json:"status"`
+	Template  string `form:"template" binding:"omitempty,max=50" json:"template"`
+}
+
+type EmailSuppressionPaginationQuery struct {
+	Page   uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit  uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Search string `form:"search" binding:"omitempty,max=150" json:"search"`
+}
+
 type UpdateUserRequest struct {
 	Username     string         `json:"username" binding:"required,min=5"`
 	Email        string         `json:"email" binding:"required,email"`
-	Phone        string         `json:"phone" binding:"required,len=10"`
+	Phone        string         `json:"phone" binding:"required,vnphone"`
 	FirstName    string         `json:"first_name" binding:"required"`
 	LastName     string         `json:"last_name" binding:"required"`
-	Role         model.UserRole `json:"role" binding:"required,oneof=staff admin"`
+	Role         model.UserRole `json:"role" binding:"required,oneof=staff admin chain_admin"`
 	IsActive     *bool          `json:"is_active" binding:"required"`
 	DepartmentID *int64         `json:"department_id" binding:"omitempty"`
+	PropertyID   *int64         `json:"property_id" binding:"omitempty,min=1"`
+	AvatarKey    *string        `json:"avatar_key" binding:"omitempty,max=255"`
 }
 
 type UpdateUserPasswordRequest struct {
-	NewPassword string `json:"new_password" binding:"required,min=6"`
+	NewPassword string `json:"new_password" binding:"required,password"`
 }
 
 type DeleteManyRequest struct {
 	IDs []int64 `json:"ids" binding:"required,min=1,dive,required"`
 }
+
+type ImpersonationSessionPaginationQuery struct {
+	Page    uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit   uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	AdminID int64  `form:"admin_id" binding:"omitempty,min=1" json:"admin_id"`
+	UserID  int64  `form:"user_id" binding:"omitempty,min=1" json:"user_id"`
+}
+
+type CreatePropertyRequest struct {
+	Code     string `json:"code" binding:"required,subdomain"`
+	Name     string `json:"name" binding:"required,min=2,max=150"`
+	Address  string `json:"address" binding:"required,min=1"`
+	IsActive *bool  `json:"is_active" binding:"required"`
+}
+
+type UpdatePropertyRequest struct {
+	Code     string `json:"code" binding:"required,subdomain"`
+	Name     string `json:"name" binding:"required,min=2,max=150"`
+	Address  string `json:"address" binding:"required,min=1"`
+	IsActive *bool  `json:"is_active" binding:"required"`
+}
+
+type CreateShiftRequest struct {
+	DepartmentID int64     `json:"department_id" binding:"required,min=1"`
+	Name         string    `json:"name" binding:"required,min=1,max=100"`
+	StartsAt     time.Time `json:"starts_at" binding:"required"`
+	EndsAt       time.Time `json:"ends_at" binding:"required"`
+	Note         string    `json:"note" binding:"omitempty,max=500"`
+}
+
+type UpdateShiftRequest struct {
+	Name     string    `json:"name" binding:"required,min=1,max=100"`
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Note     string    `json:"note" binding:"omitempty,max=500"`
+}
+
+type ShiftPaginationQuery struct {
+	Page         uint32    `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit        uint32    `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	DepartmentID int64     `form:"department_id" binding:"omitempty,min=1" json:"department_id"`
+	UserID       int64     `form:"user_id" binding:"omitempty,min=1" json:"user_id"`
+	From         time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00" json:"from"`
+	To           time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00" json:"to"`
+}
+
+// ShiftRosterQuery selects the week starting at WeekStart, whose offset also
+// decides which day a shift falls on. It defaults to this week's Monday.
+type ShiftRosterQuery struct {
+	DepartmentID int64     `form:"department_id" binding:"omitempty,min=1" json:"department_id"`
+	WeekStart    time.Time `form:"week_start" time_format:"2006-01-02T15:04:05Z07:00" json:"week_start"`
+}
+
+type OnDutyQuery struct {
+	DepartmentID int64     `form:"department_id" binding:"required,min=1" json:"department_id"`
+	At           time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00" json:"at"`
+}
+
+type AssignShiftRequest struct {
+	UserID int64           `json:"user_id" binding:"required,min=1"`
+	Role   model.ShiftRole `json:"role" binding:"required,oneof=lead member"`
+}
@@ -22,25 +22,64 @@ type MetaResponse struct {
 }
 
 type UploadPresignedURLResponse struct {
-	Url string `json:"url"`
-	Key string `json:"key"`
+	Url    string            `json:"url"`
+	Key    string            `json:"key"`
+	Fields map[string]string `json:"fields"`
+}
+
+type MultipartUploadResponse struct {
+	Key           string                  `json:"key"`
+	OriginalName  string                  `json:"original_name"`
+	ContentType   string                  `json:"content_type"`
+	Size          int64                   `json:"size"`
+	PartSize      int64                   `json:"part_size"`
+	PartCount     int32                   `json:"part_count"`
+	ExpiresAt     time.Time               `json:"expires_at"`
+	UploadedParts []*UploadedPartResponse `json:"uploaded_parts,omitempty"`
+}
+
+type UploadedPartResponse struct {
+	PartNumber int32  `json:"part_number"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+}
+
+type UploadPartURLResponse struct {
+	PartNumber int32  `json:"part_number"`
+	Url        string `json:"url"`
 }
 
 type ViewPresignedURLResponse struct {
 	Url string `json:"url"`
 }
 
+type FileResponse struct {
+	ID           int64             `json:"id"`
+	Key          string            `json:"key"`
+	OriginalName string            `json:"original_name"`
+	ContentType  string            `json:"content_type"`
+	Purpose      model.FilePurpose `json:"purpose"`
+	Size         int64             `json:"size"`
+	Checksum     string            `json:"checksum"`
+	Status       model.FileStatus  `json:"status"`
+	ConfirmedAt  *time.Time        `json:"confirmed_at"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
 type UserResponse struct {
-	ID         int64                    `json:"id"`
-	Username   string                   `json:"username"`
-	Email      string                   `json:"email"`
-	Phone      string                   `json:"phone"`
-	Role       model.UserRole           `json:"role"`
-	IsActive   bool                     `json:"is_active"`
-	FirstName  string                   `json:"first_name"`
-	LastName   string                   `json:"last_name"`
-	CreatedAt  time.Time                `json:"created_at"`
-	Department *BasicDepartmentResponse `json:"department"`
+	ID                 int64                    `json:"id"`
+	Username           string                   `json:"username"`
+	Email              string                   `json:"email"`
+	Phone              string                   `json:"phone"`
+	Role               model.UserRole           `json:"role"`
+	IsActive           bool                     `json:"is_active"`
+	FirstName          string                   `json:"first_name"`
+	LastName           string                   `json:"last_name"`
+	Locale             string                   `json:"locale"`
+	AvatarURL          *string                  `json:"avatar_url"`
+	EmailNotifications bool                     `json:"email_notifications"`
+	CreatedAt          time.Time                `json:"created_at"`
+	Department         *BasicDepartmentResponse `json:"department"`
 }
 
 type SimpleUserResponse struct {
@@ -49,6 +88,7 @@ type SimpleUserResponse struct {
 	LastName   string                   `json:"last_name"`
 	Role       model.UserRole           `json:"role"`
 	IsActive   bool                     `json:"is_active"`
+	AvatarURL  *string                  `json:"avatar_url"`
 	CreatedAt  time.Time                `json:"created_at"`
 	Department *BasicDepartmentResponse `json:"department"`
 }
@@ -62,6 +102,7 @@ type UserDetailsResponse struct {
 	IsActive   bool                     `json:"is_active"`
 	FirstName  string                   `json:"first_name"`
 	LastName   string                   `json:"last_name"`
+	AvatarURL  *string                  `json:"avatar_url"`
 	CreatedAt  time.Time                `json:"created_at"`
 	UpdatedAt  time.Time                `json:"updated_at"`
 	Department *BasicDepartmentResponse `json:"department"`
@@ -70,13 +111,140 @@ type UserDetailsResponse struct {
 }
 
 type BasicUserResponse struct {
-	ID        int64  `json:"id"`
-	Username  string `json:"username"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
+	ID        int64   `json:"id"`
+	Username  string  `json:"username"`
+	FirstName string  `json:"first_name"`
+	LastName  string  `json:"last_name"`
+	AvatarURL *string `json:"avatar_url"`
 }
 
 type BasicDepartmentResponse struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
 }
+
+type PasswordViolationResponse struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type NotificationResponse struct {
+	ID        int64      `json:"id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Link      *string    `json:"link"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type NotifyOnDutyResponse struct {
+	Audience   string `json:"audience"`
+	Recipients int    `json:"recipients"`
+}
+
+type EmailLogResponse struct {
+	ID        int64             `json:"id"`
+	MessageID string            `json:"message_id"`
+	Recipient string            `json:"recipient"`
+	Template  string            `json:"template"`
+	Status    model.EmailStatus `json:"status"`
+	Attempts  int               `json:"attempts"`
+	Response  string            `json:"response"`
+	SentAt    *time.Time        `json:"sent_at"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type RoleSettingResponse struct {
+	Role             string    `json:"role"`
+	MagicLinkEnabled bool      `json:"magic_link_enabled"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type EmailSuppressionResponse struct {
+	ID        int64     `json:"id"`
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ImpersonationSessionResponse struct {
+	ID        int64              `json:"id"`
+	Admin     *BasicUserResponse `json:"admin"`
+	User      *BasicUserResponse `json:"user"`
+	Reason    string             `json:"reason"`
+	IPAddress string             `json:"ip_address"`
+	UserAgent string             `json:"user_agent"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	EndedAt   *time.Time         `json:"ended_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// ImpersonationResponse marks a GetMe response made with an impersonation
+// token so the frontend can show who is really signed in.
+type ImpersonationResponse struct {
+	SessionID      int64     `json:"session_id"`
+	ImpersonatorID int64     `json:"impersonator_id"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+type PropertyResponse struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ShiftResponse struct {
+	ID          int64                      `json:"id"`
+	Name        string                     `json:"name"`
+	StartsAt    time.Time                  `json:"starts_at"`
+	EndsAt      time.Time                  `json:"ends_at"`
+	Note        string                     `json:"note"`
+	Department  *BasicDepartmentResponse   `json:"department"`
+	Assignments []*ShiftAssignmentResponse `json:"assignments,omitempty"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+}
+
+type ShiftAssignmentResponse struct {
+	ID        int64              `json:"id"`
+	Role      model.ShiftRole    `json:"role"`
+	User      *BasicUserResponse `json:"user"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type BasicShiftResponse struct {
+	ID       int64     `json:"id"`
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// ShiftConflictResponse names the shift that keeps a user from being
+// scheduled.
+type ShiftConflictResponse struct {
+	UserID int64               `json:"user_id"`
+	Shift  *BasicShiftResponse `json:"shift"`
+}
+
+type OnDutyResponse struct {
+	User  *BasicUserResponse  `json:"user"`
+	Role  model.ShiftRole     `json:"role"`
+	Shift *BasicShiftResponse `json:"shift"`
+}
+
+type RosterDayResponse struct {
+	Date   string           `json:"date"`
+	Shifts []*ShiftResponse `json:"shifts"`
+}
+
+type ShiftRosterResponse struct {
+	WeekStart time.Time            `json:"week_start"`
+	WeekEnd   time.Time            `json:"week_end"`
+	Days      []*RosterDayResponse `json:"days"`
+}
@@ -16,7 +16,11 @@ type CacheProvider interface {
 
 	GetString(ctx context.Context, key string) (string, error)
 
+	GetDelString(ctx context.Context, key string) (string, error)
+
 	GetInt(ctx context.Context, key string) (int, error)
 
 	Increment(ctx context.Context, key string) error
+
+	IncrementBy(ctx context.Context, key string, value int64, ttl time.Duration) (int64, error)
 }
@@ -6,8 +6,28 @@ import (
 	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 )
 
+// TokenActor identifies the admin acting behind an impersonation token.
+type TokenActor struct {
+	UserID    int64
+	SessionID int64
+}
+
+// TokenClaims is what an access token asserts about its holder. PropertyID
+// is 0 for users not assigned to a property, such as chain admins.
+type TokenClaims struct {
+	UserID       int64
+	PropertyID   int64
+	Role         model.UserRole
+	TokenVersion int
+	TTL          time.Duration
+	// Actor is set only on impersonation tokens.
+	Actor *TokenActor
+}
+
 type JWTProvider interface {
-	GenerateToken(userID int64, role model.UserRole, tokenVersion int, ttl time.Duration) (string, error)
+	GenerateToken(userID, propertyID int64, role model.UserRole, tokenVersion int, ttl time.Duration) (string, error)
+
+	GenerateImpersonationToken(userID, propertyID int64, role model.UserRole, tokenVersion int, actor TokenActor, ttl time.Duration) (string, error)
 
-	ParseToken(tokenStr string) (int64, model.UserRole, int, time.Duration, error)
+	ParseToken(tokenStr string) (*TokenClaims, error)
 }
@@ -1,7 +1,33 @@
 package port
 
+import "context"
+
 type MessageQueueProvider interface {
-	PublishMessage(exchange, routingKey string, body []byte) error
-	
-	ConsumeMessage(queueName, exchange, routingKey string, handler func([]byte) error) error
+	PublishMessage(ctx context.Context, exchange, routingKey string, body []byte) error
+
+	ConsumeMessage(queueName, exchange, routingKey string, handler func(context.Context, []byte) error) error
+
+	ConsumeMessages(queueName, exchange string, routingKeys []string, handler func(context.Context, []byte) error) error
+}
+
+type deliveryAttemptKey struct{}
+
+type deliveryAttempt struct {
+	attempt     int
+	maxAttempts int
+}
+
+// WithDeliveryAttempt lets a consumer handler tell its final retry apart from
+// earlier ones.
+func WithDeliveryAttempt(ctx context.Context, attempt, maxAttempts int) context.Context {
+	return context.WithValue(ctx, deliveryAttemptKey{}, deliveryAttempt{attempt, maxAttempts})
+}
+
+// DeliveryAttempt returns the current attempt and the attempt limit, or 1 and
+// 1 when the handler runs outside a retry loop.
+func DeliveryAttempt(ctx context.Context) (int, int) {
+	if da, ok := ctx.Value(deliveryAttemptKey{}).(deliveryAttempt); ok {
+		return da.attempt, da.maxAttempts
+	}
+	return 1, 1
 }
@@ -0,0 +1,28 @@
+package port
+
+import "context"
+
+// OIDCIdentity is the verified subset of ID token claims used to sign a user
+// in.
+type OIDCIdentity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	GivenName         string
+	FamilyName        string
+	PreferredUsername string
+	PhoneNumber       string
+	Groups            []string
+}
+
+type OIDCProvider interface {
+	// AuthCodeURL builds the authorization endpoint URL for an
+	// authorization code request protected by PKCE (S256).
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+
+	// Exchange redeems the code and returns the claims of the verified ID
+	// token. The token's nonce must match.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OIDCIdentity, error)
+}
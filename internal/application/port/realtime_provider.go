@@ -0,0 +1,14 @@
+package port
+
+import "context"
+
+type RealtimeEvent struct {
+	Type string `json:"type"`
+	Data any    `json:"data,omitempty"`
+}
+
+type RealtimeProvider interface {
+	PublishToUser(ctx context.Context, userID int64, event RealtimeEvent) error
+
+	PublishToDepartment(ctx context.Context, departmentID int64, event RealtimeEvent) error
+}
@@ -0,0 +1,9 @@
+package port
+
+import "context"
+
+// SMSProvider delivers one-time passwords to a phone number, either as a
+// plain text message or through a messaging app template such as Zalo ZNS.
+type SMSProvider interface {
+	SendOTP(ctx context.Context, phone, otp, locale string) error
+}
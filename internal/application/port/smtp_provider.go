@@ -1,7 +1,56 @@
 package port
 
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type MailAttachment struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// TemplateMail is rendered through the provider's template registry into a
+// multipart HTML and plain-text message. Subject, when set, overrides the
+// template's localized subject.
+type TemplateMail struct {
+	MessageID   string
+	Template    string
+	Locale      string
+	Subject     string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Data        map[string]any
+	Attachments []*MailAttachment
+}
+
+// RecipientsRejectedError lists the recipients the mail server permanently
+// refused. Delivered reports whether the message still went out to the
+// remaining recipients.
+type RecipientsRejectedError struct {
+	Rejected  map[string]error
+	Delivered bool
+}
+
+func (e *RecipientsRejectedError) Error() string {
+	parts := make([]string, 0, len(e.Rejected))
+	for _, rcpt := range slices.Sorted(maps.Keys(e.Rejected)) {
+		parts = append(parts, fmt.Sprintf("%s: %v", rcpt, e.Rejected[rcpt]))
+	}
+	return "recipients rejected: " + strings.Join(parts, "; ")
+}
+
+func (e *RecipientsRejectedError) Unwrap() []error {
+	return slices.Collect(maps.Values(e.Rejected))
+}
+
 type SMTPProvider interface {
-	Send(to, subject, body string) error
-	
-	AuthEmail(to, subject, otp string) error
-}
\ No newline at end of file
+	SendTemplate(ctx context.Context, mail *TemplateMail) error
+
+	Close() error
+}
@@ -0,0 +1,60 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrMultipartUploadNotFound = errors.New("multipart upload not found")
+	ErrInvalidUploadPart       = errors.New("invalid upload part")
+)
+
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	Checksum     string
+	LastModified time.Time
+}
+
+type PresignedUpload struct {
+	URL    string
+	Fields map[string]string
+}
+
+type UploadedPart struct {
+	PartNumber int32
+	ETag       string
+	Size       int64
+}
+
+type StorageProvider interface {
+	PresignUpload(ctx context.Context, key, contentType string, maxSize int64, ttl time.Duration) (*PresignedUpload, error)
+
+	PresignDownload(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	PublicURL(key string) (string, bool)
+
+	Head(ctx context.Context, key string) (*ObjectInfo, error)
+
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+
+	Delete(ctx context.Context, keys []string) ([]string, error)
+
+	List(ctx context.Context, prefix, startAfter string, limit int) ([]*ObjectInfo, error)
+
+	CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error)
+
+	PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, size int64, ttl time.Duration) (string, error)
+
+	ListParts(ctx context.Context, key, uploadID string) ([]*UploadedPart, error)
+
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []*UploadedPart) error
+
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+}
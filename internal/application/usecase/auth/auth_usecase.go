@@ -19,11 +19,29 @@ type AuthUseCase interface {
 
 	ChangePassword(ctx context.Context, userID int64, req dto.ChangePasswordRequest) error
 
-	ForgotPassword(ctx context.Context, email string) (string, error)
+	ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) (string, error)
 
 	VerifyForgotPassword(ctx context.Context, req dto.VerifyForgotPasswordRequest) (string, error)
 
 	ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
 
 	UpdateInfo(ctx context.Context, userID int64, req dto.UpdateInfoRequest) (*model.User, error)
+
+	RequestMagicLink(ctx context.Context, req dto.MagicLinkRequest) error
+
+	ConsumeMagicLink(ctx context.Context, ua string, req dto.ConsumeMagicLinkRequest) (*model.User, string, string, error)
+
+	StartOIDCLogin(ctx context.Context, redirect string) (string, string, error)
+
+	CompleteOIDCLogin(ctx context.Context, ua, stateCookie string, req dto.OIDCCallbackQuery) (*model.User, string, string, string, error)
+
+	StartImpersonation(ctx context.Context, adminID, userID int64, ip, ua string, req dto.StartImpersonationRequest) (*model.User, string, time.Duration, error)
+
+	StopImpersonation(ctx context.Context, ua string, adminID, sessionID int64, accessToken, refreshToken string, accessTTL time.Duration) (*model.User, string, string, error)
+
+	GetImpersonationSessions(ctx context.Context, query dto.ImpersonationSessionPaginationQuery) ([]*model.ImpersonationSession, *dto.MetaResponse, error)
+
+	GetRoleSettings(ctx context.Context) ([]*model.RoleSetting, error)
+
+	UpdateRoleSetting(ctx context.Context, userID int64, role model.UserRole, req dto.UpdateRoleSettingRequest) (*model.RoleSetting, error)
 }
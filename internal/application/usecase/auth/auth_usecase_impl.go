@@ -7,15 +7,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
 	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	emailUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/email"
+	fileUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/file"
+	passwordUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/password"
 	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
 	"github.com/InstaySystem/is_v2-be/pkg/constants"
 	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/tenant"
 	"github.com/InstaySystem/is_v2-be/pkg/utils"
 	"github.com/google/uuid"
 	"github.com/sony/sonyflake/v2"
@@ -23,46 +31,89 @@ import (
 	"gorm.io/gorm"
 )
 
+const otpRequestWindow = time.Hour
+
+// otpRequestLimits caps password reset codes per recipient within
+// otpRequestWindow. Phone is tighter because every SMS is billed.
+var otpRequestLimits = map[string]int64{
+	constants.OTPChannelEmail:     5,
+	constants.OTPChannelPhone:     3,
+	constants.OTPChannelMagicLink: 5,
+}
+
 type authUseCaseImpl struct {
-	cfg       config.JWTConfig
-	db        *gorm.DB
-	log       *zap.Logger
-	idGen     *sonyflake.Sonyflake
-	jwtPro    port.JWTProvider
-	cachePro  port.CacheProvider
-	mqPro     port.MessageQueueProvider
-	userRepo  repository.UserRepository
-	tokenRepo repository.TokenRepository
+	cfg               config.JWTConfig
+	mlCfg             config.MagicLinkConfig
+	oidcCfg           config.OIDCConfig
+	db                *gorm.DB
+	log               *zap.Logger
+	idGen             *sonyflake.Sonyflake
+	jwtPro            port.JWTProvider
+	cachePro          port.CacheProvider
+	mqPro             port.MessageQueueProvider
+	oidcPro           port.OIDCProvider
+	userRepo          repository.UserRepository
+	deptRepo          repository.DepartmentRepository
+	tokenRepo         repository.TokenRepository
+	roleSetRepo       repository.RoleSettingRepository
+	identityRepo      repository.UserIdentityRepository
+	impersonationRepo repository.ImpersonationSessionRepository
+	passwordUC        passwordUC.PasswordUseCase
+	fileUC            fileUC.FileUseCase
+	emailUC           emailUC.EmailUseCase
+	realtimePro       port.RealtimeProvider
 }
 
 func NewAuthUseCase(
 	cfg config.JWTConfig,
+	mlCfg config.MagicLinkConfig,
+	oidcCfg config.OIDCConfig,
 	db *gorm.DB,
 	log *zap.Logger,
 	idGen *sonyflake.Sonyflake,
 	jwtPro port.JWTProvider,
 	cachePro port.CacheProvider,
 	mqPro port.MessageQueueProvider,
+	oidcPro port.OIDCProvider,
 	userRepo repository.UserRepository,
+	deptRepo repository.DepartmentRepository,
 	tokenRepo repository.TokenRepository,
+	roleSetRepo repository.RoleSettingRepository,
+	identityRepo repository.UserIdentityRepository,
+	impersonationRepo repository.ImpersonationSessionRepository,
+	passwordUC passwordUC.PasswordUseCase,
+	fileUC fileUC.FileUseCase,
+	emailUC emailUC.EmailUseCase,
+	realtimePro port.RealtimeProvider,
 ) AuthUseCase {
 	return &authUseCaseImpl{
 		cfg,
+		mlCfg,
+		oidcCfg,
 		db,
 		log,
 		idGen,
 		jwtPro,
 		cachePro,
 		mqPro,
+		oidcPro,
 		userRepo,
+		deptRepo,
 		tokenRepo,
+		roleSetRepo,
+		identityRepo,
+		impersonationRepo,
+		passwordUC,
+		fileUC,
+		emailUC,
+		realtimePro,
 	}
 }
 
 func (u *authUseCaseImpl) Login(ctx context.Context, ua string, req dto.LoginRequest) (*model.User, string, string, error) {
 	user, err := u.userRepo.FindByUsernameWithDepartment(ctx, req.Username)
 	if err != nil {
-		u.log.Error("find user by username failed", zap.String("username", req.Username), zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("find user by username failed", zap.String("username", req.Username), zap.Error(err))
 		return nil, "", "", err
 	}
 	if user == nil || !user.IsActive {
@@ -73,69 +124,107 @@ func (u *authUseCaseImpl) Login(ctx context.Context, ua string, req dto.LoginReq
 		return nil, "", "", customErr.ErrLoginFailed
 	}
 
+	if u.passwordUC.IsExpired(user) {
+		resetPasswordToken := uuid.NewString()
+		redisKey := fmt.Sprintf("reset_password:%s", resetPasswordToken)
+		if err = u.cachePro.SetString(ctx, redisKey, user.Email, 3*time.Minute); err != nil {
+			logger.FromContext(ctx, u.log).Error("save email reset password failed", zap.Error(err))
+			return nil, "", "", err
+		}
+
+		return nil, "", "", customErr.ErrPasswordExpired.WithData(map[string]any{
+			"reset_password_token": resetPasswordToken,
+		})
+	}
+
+	accessToken, refreshToken, err := u.createSession(ctx, ua, user)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	u.fileUC.ResolveAvatarURLs(ctx, user)
+
+	return user, accessToken, refreshToken, nil
+}
+
+// createSession issues the access/refresh token pair for an authenticated
+// user, records the refresh token and warns about sign-ins from new devices.
+// Every login method goes through it so sessions behave the same.
+func (u *authUseCaseImpl) createSession(ctx context.Context, ua string, user *model.User) (string, string, error) {
 	redisKey := fmt.Sprintf("user_version:%d", user.ID)
 	tokenVersion, err := u.cachePro.GetInt(ctx, redisKey)
 	if err != nil {
-		u.log.Error("get token version failed", zap.Error(err))
-		return nil, "", "", err
+		logger.FromContext(ctx, u.log).Error("get token version failed", zap.Error(err))
+		return "", "", err
 	}
 	if tokenVersion == 0 {
 		if err = u.cachePro.SetString(ctx, redisKey, "1", 0); err != nil {
-			u.log.Error("save token version failed", zap.Error(err))
-			return nil, "", "", err
+			logger.FromContext(ctx, u.log).Error("save token version failed", zap.Error(err))
+			return "", "", err
 		}
 		tokenVersion = 1
 	}
 
-	accessToken, err := u.jwtPro.GenerateToken(user.ID, user.Role, tokenVersion, u.cfg.AccessExpiresIn)
+	accessToken, err := u.jwtPro.GenerateToken(user.ID, user.AssignedPropertyID(), user.Role, tokenVersion, u.cfg.AccessExpiresIn)
 	if err != nil {
-		u.log.Error("generate access token failed", zap.Error(err))
-		return nil, "", "", err
+		logger.FromContext(ctx, u.log).Error("generate access token failed", zap.Error(err))
+		return "", "", err
 	}
 
 	refreshToken, err := generateRefreshToken()
 	if err != nil {
-		u.log.Error("generate refresh token failed", zap.Error(err))
-		return nil, "", "", err
+		logger.FromContext(ctx, u.log).Error("generate refresh token failed", zap.Error(err))
+		return "", "", err
 	}
 
 	id, err := u.idGen.NextID()
 	if err != nil {
-		u.log.Error("generate token id failed", zap.Error(err))
-		return nil, "", "", err
+		logger.FromContext(ctx, u.log).Error("generate token id failed", zap.Error(err))
+		return "", "", err
 	}
 
+	device := utils.ConvertUserAgent(ua)
+	newDevice := u.isNewDevice(ctx, user.ID, device)
+
 	token := &model.Token{
-		ID:        id,
-		UserID:    user.ID,
-		Token:     utils.SHA256Hash(refreshToken),
-		UserAgent: utils.ConvertUserAgent(ua),
-		RevokedAt: nil,
-		ExpiresAt: time.Now().Add(u.cfg.RefreshExpiresIn),
+		ID:         id,
+		UserID:     user.ID,
+		PropertyID: user.PropertyID,
+		Token:      utils.SHA256Hash(refreshToken),
+		UserAgent:  device,
+		RevokedAt:  nil,
+		ExpiresAt:  time.Now().Add(u.cfg.RefreshExpiresIn),
 	}
 
-	if err := u.tokenRepo.Create(ctx, token); err != nil {
-		u.log.Error("create token failed", zap.Error(err))
-		return nil, "", "", err
+	if err = u.tokenRepo.Create(ctx, token); err != nil {
+		logger.FromContext(ctx, u.log).Error("create token failed", zap.Error(err))
+		return "", "", err
 	}
 
-	return user, accessToken, refreshToken, nil
+	if newDevice {
+		u.emailUC.SendNewLogin(ctx, user, device, time.Now())
+	}
+
+	return accessToken, refreshToken, nil
 }
 
+// Logout is unscoped for the same reason as GetMe: a chain admin's tokens
+// belong to no property.
 func (u *authUseCaseImpl) Logout(ctx context.Context, accessToken, refreshToken string, accessTTL time.Duration) error {
+	ctx = tenant.Unscoped(ctx)
 	hashedToken := utils.SHA256Hash(refreshToken)
 
 	if err := u.tokenRepo.UpdateByToken(ctx, hashedToken, map[string]any{"revoked_at": time.Now()}); err != nil {
 		if errors.Is(err, customErr.ErrInvalidUser) {
 			return err
 		}
-		u.log.Error("update all token by token failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("update all token by token failed", zap.Error(err))
 		return err
 	}
 
 	redisKey := fmt.Sprintf("black_list:%s", accessToken)
 	if err := u.cachePro.SetString(ctx, redisKey, "1", accessTTL); err != nil {
-		u.log.Error("save black list failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("save black list failed", zap.Error(err))
 	}
 
 	return nil
@@ -146,7 +235,7 @@ func (u *authUseCaseImpl) RefreshToken(ctx context.Context, ua, refreshToken str
 
 	token, err := u.tokenRepo.FindByToken(ctx, hashedToken)
 	if err != nil {
-		u.log.Error("find token by token failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("find token by token failed", zap.Error(err))
 		return "", "", nil
 	}
 	if token == nil || token.RevokedAt != nil || token.ExpiresAt.Before(time.Now()) {
@@ -155,7 +244,7 @@ func (u *authUseCaseImpl) RefreshToken(ctx context.Context, ua, refreshToken str
 
 	user, err := u.userRepo.FindByID(ctx, token.UserID)
 	if err != nil {
-		u.log.Error("find user by id failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Error(err))
 		return "", "", nil
 	}
 	if user == nil || !user.IsActive {
@@ -165,65 +254,74 @@ func (u *authUseCaseImpl) RefreshToken(ctx context.Context, ua, refreshToken str
 	redisKey := fmt.Sprintf("user_version:%d", user.ID)
 	tokenVersion, err := u.cachePro.GetInt(ctx, redisKey)
 	if err != nil {
-		u.log.Error("get token version failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("get token version failed", zap.Error(err))
 		return "", "", err
 	}
 	if tokenVersion == 0 {
 		return "", "", customErr.ErrInvalidUser
 	}
 
-	newAccessToken, err := u.jwtPro.GenerateToken(user.ID, user.Role, tokenVersion, u.cfg.AccessExpiresIn)
+	newAccessToken, err := u.jwtPro.GenerateToken(user.ID, user.AssignedPropertyID(), user.Role, tokenVersion, u.cfg.AccessExpiresIn)
 	if err != nil {
-		u.log.Error("generate access token failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("generate access token failed", zap.Error(err))
 		return "", "", err
 	}
 
 	newRefreshToken, err := generateRefreshToken()
 	if err != nil {
-		u.log.Error("generate refresh token failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("generate refresh token failed", zap.Error(err))
 		return "", "", err
 	}
 
 	id, err := u.idGen.NextID()
 	if err != nil {
-		u.log.Error("generate token id failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("generate token id failed", zap.Error(err))
 		return "", "", err
 	}
 
 	newToken := &model.Token{
-		ID:        id,
-		UserID:    user.ID,
-		Token:     utils.SHA256Hash(newRefreshToken),
-		UserAgent: utils.ConvertUserAgent(ua),
-		RevokedAt: nil,
-		ExpiresAt: time.Now().Add(u.cfg.RefreshExpiresIn),
+		ID:         id,
+		UserID:     user.ID,
+		PropertyID: user.PropertyID,
+		Token:      utils.SHA256Hash(newRefreshToken),
+		UserAgent:  utils.ConvertUserAgent(ua),
+		RevokedAt:  nil,
+		ExpiresAt:  time.Now().Add(u.cfg.RefreshExpiresIn),
 	}
 
 	if err := u.tokenRepo.Create(ctx, newToken); err != nil {
-		u.log.Error("create token failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("create token failed", zap.Error(err))
 		return "", "", err
 	}
 
 	return newAccessToken, newRefreshToken, nil
 }
 
+// GetMe, ChangePassword and UpdateInfo act on the caller's own account,
+// which a chain admin working in a property is not part of.
 func (u *authUseCaseImpl) GetMe(ctx context.Context, userID int64) (*model.User, error) {
+	ctx = tenant.Unscoped(ctx)
+
 	user, err := u.userRepo.FindByIDWithDepartment(ctx, userID)
 	if err != nil {
-		u.log.Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
 		return nil, err
 	}
 	if user == nil {
 		return nil, customErr.ErrInvalidUser
 	}
 
+	u.fileUC.ResolveAvatarURLs(ctx, user)
+
 	return user, nil
 }
 
 func (u *authUseCaseImpl) ChangePassword(ctx context.Context, userID int64, req dto.ChangePasswordRequest) error {
+	ctx = tenant.Unscoped(ctx)
+
 	user, err := u.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		u.log.Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
 		return err
 	}
 	if user == nil {
@@ -234,28 +332,37 @@ func (u *authUseCaseImpl) ChangePassword(ctx context.Context, userID int64, req
 		return customErr.ErrInvalidPassword
 	}
 
+	if err = u.passwordUC.Validate(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
-		u.log.Error("hash password failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("hash password failed", zap.Error(err))
 		return err
 	}
 
 	if err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		updateData := map[string]any{
-			"password":      hashedPassword,
-			"updated_by_id": user.ID,
+			"password":            hashedPassword,
+			"password_changed_at": time.Now(),
+			"updated_by_id":       user.ID,
 		}
 
 		if err = u.userRepo.UpdateTx(tx, userID, updateData); err != nil {
 			if errors.Is(err, customErr.ErrUserNotFound) {
 				return customErr.ErrInvalidUser
 			}
-			u.log.Error("update password failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("update password failed", zap.Error(err))
+			return err
+		}
+
+		if err = u.passwordUC.SaveHistoryTx(tx, userID, hashedPassword); err != nil {
 			return err
 		}
 
 		if err := u.tokenRepo.UpdateAllByUserIDTx(tx, userID, map[string]any{"revoked_at": time.Now()}); err != nil {
-			u.log.Error("update all token by user id failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("update all token by user id failed", zap.Error(err))
 			return err
 		}
 		return nil
@@ -265,68 +372,153 @@ func (u *authUseCaseImpl) ChangePassword(ctx context.Context, userID int64, req
 
 	redisKey := fmt.Sprintf("user_version:%d", user.ID)
 	if err = u.cachePro.Increment(ctx, redisKey); err != nil {
-		u.log.Error("increase token version failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("increase token version failed", zap.Error(err))
 	}
 
+	u.publishSessionRevoked(ctx, user.ID)
+
+	u.emailUC.SendPasswordChanged(ctx, user, time.Now())
+
 	return nil
 }
 
-func (u *authUseCaseImpl) ForgotPassword(ctx context.Context, email string) (string, error) {
-	exists, err := u.userRepo.ExistsByEmail(ctx, email)
-	if err != nil {
-		u.log.Error("check user by email failed", zap.String("email", email), zap.Error(err))
+func (u *authUseCaseImpl) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) (string, error) {
+	channel, recipient := constants.OTPChannelEmail, req.Email
+	if req.Phone != "" {
+		channel, recipient = constants.OTPChannelPhone, req.Phone
+	}
+
+	if err := u.reserveOTPRequest(ctx, channel, recipient); err != nil {
 		return "", err
 	}
-	if !exists {
-		return "", customErr.ErrEmailDoesNotExist
+
+	var user *model.User
+	var err error
+	if channel == constants.OTPChannelPhone {
+		user, err = u.userRepo.FindByPhone(ctx, recipient)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find user by phone failed", zap.String("phone", recipient), zap.Error(err))
+			return "", err
+		}
+		if user == nil {
+			return "", customErr.ErrPhoneDoesNotExist
+		}
+	} else {
+		user, err = u.userRepo.FindByEmail(ctx, recipient)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find user by email failed", zap.String("email", recipient), zap.Error(err))
+			return "", err
+		}
+		if user == nil {
+			return "", customErr.ErrEmailDoesNotExist
+		}
+
+		suppressed, err := u.emailUC.IsSuppressed(ctx, user.Email)
+		if err != nil {
+			return "", err
+		}
+		if suppressed {
+			return "", customErr.ErrEmailSuppressed
+		}
 	}
 
 	otp := utils.GenerateOTP(6)
 	forgotPasswordToken := uuid.NewString()
 
 	forgData := dto.ForgotPasswordData{
-		Email:    email,
+		Email:    user.Email,
 		Otp:      otp,
 		Attempts: 0,
 	}
 
 	bytes, err := json.Marshal(forgData)
 	if err != nil {
-		u.log.Error("json marshal forgot password data failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("json marshal forgot password data failed", zap.Error(err))
 		return "", err
 	}
 
 	redisKey := fmt.Sprintf("forgot_password:%s", forgotPasswordToken)
 	if err = u.cachePro.SetObject(ctx, redisKey, bytes, 3*time.Minute); err != nil {
-		u.log.Error("save forgot password data failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("save forgot password data failed", zap.Error(err))
 		return "", err
 	}
 
-	emailMsg := dto.AuthEmailMessage{
-		To:      email,
-		Subject: "Xác thực quên mật khẩu tại Instay",
-		Otp:     otp,
+	locale := user.Locale
+	if !i18n.IsSupported(locale) {
+		locale = i18n.FromContext(ctx)
+	}
+
+	if channel == constants.OTPChannelPhone {
+		u.publishSMSOTP(ctx, dto.SMSOTPMessage{
+			MessageID: uuid.NewString(),
+			Phone:     user.Phone,
+			Otp:       otp,
+			Locale:    locale,
+		})
+	} else {
+		u.publishEmailOTP(ctx, dto.AuthEmailMessage{
+			MessageID:  uuid.NewString(),
+			PropertyID: user.PropertyID,
+			To:         user.Email,
+			Subject:    i18n.T(locale, "email.forgot_password.subject"),
+			Otp:        otp,
+			Locale:     locale,
+		})
 	}
 
-	go func(msg dto.AuthEmailMessage) {
+	return forgotPasswordToken, nil
+}
+
+// reserveOTPRequest counts a reset code request against the recipient's
+// hourly limit. It runs before the user lookup so unknown addresses are
+// throttled too and cannot be probed freely.
+func (u *authUseCaseImpl) reserveOTPRequest(ctx context.Context, channel, recipient string) error {
+	redisKey := fmt.Sprintf("otp_requests:%s:%s", channel, strings.ToLower(recipient))
+	total, err := u.cachePro.IncrementBy(ctx, redisKey, 1, otpRequestWindow)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("increase otp request count failed", zap.Error(err))
+		return err
+	}
+	if total > otpRequestLimits[channel] {
+		return customErr.ErrTooManyOTPRequests
+	}
+
+	return nil
+}
+
+func (u *authUseCaseImpl) publishEmailOTP(ctx context.Context, emailMsg dto.AuthEmailMessage) {
+	go func(ctx context.Context, msg dto.AuthEmailMessage) {
 		body, err := json.Marshal(msg)
 		if err != nil {
-			u.log.Error("json marshal failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("json marshal failed", zap.Error(err))
+			return
 		}
 
-		if u.mqPro.PublishMessage(constants.ExchangeEmail, constants.RoutingKeyAuthEmail, body); err != nil {
-			u.log.Error("publish auth email message failed", zap.String("email", email), zap.Error(err))
+		if err = u.mqPro.PublishMessage(ctx, constants.ExchangeEmail, constants.RoutingKeyAuthEmail, body); err != nil {
+			logger.FromContext(ctx, u.log).Error("publish auth email message failed", zap.String("email", msg.To), zap.Error(err))
 		}
-	}(emailMsg)
+	}(context.WithoutCancel(ctx), emailMsg)
+}
 
-	return forgotPasswordToken, nil
+func (u *authUseCaseImpl) publishSMSOTP(ctx context.Context, smsMsg dto.SMSOTPMessage) {
+	go func(ctx context.Context, msg dto.SMSOTPMessage) {
+		body, err := json.Marshal(msg)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("json marshal failed", zap.Error(err))
+			return
+		}
+
+		if err = u.mqPro.PublishMessage(ctx, constants.ExchangeSMS, constants.RoutingKeyOTPSMS, body); err != nil {
+			logger.FromContext(ctx, u.log).Error("publish sms otp message failed", zap.String("phone", msg.Phone), zap.Error(err))
+		}
+	}(context.WithoutCancel(ctx), smsMsg)
 }
 
 func (u *authUseCaseImpl) VerifyForgotPassword(ctx context.Context, req dto.VerifyForgotPasswordRequest) (string, error) {
 	redisKey := fmt.Sprintf("forgot_password:%s", req.ForgotPasswordToken)
 	bytes, err := u.cachePro.GetObject(ctx, redisKey)
 	if err != nil {
-		u.log.Error("get forgot password data failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("get forgot password data failed", zap.Error(err))
 		return "", err
 	}
 	if bytes == nil {
@@ -335,13 +527,13 @@ func (u *authUseCaseImpl) VerifyForgotPassword(ctx context.Context, req dto.Veri
 
 	var forgData dto.ForgotPasswordData
 	if err = json.Unmarshal(bytes, &forgData); err != nil {
-		u.log.Error("json unmarshal forgot password data failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("json unmarshal forgot password data failed", zap.Error(err))
 		return "", nil
 	}
 
 	if forgData.Attempts >= 3 {
 		if err = u.cachePro.Del(ctx, redisKey); err != nil {
-			u.log.Error("delete forgot password data failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("delete forgot password data failed", zap.Error(err))
 			return "", err
 		}
 		return "", customErr.ErrTooManyAttempts
@@ -354,12 +546,12 @@ func (u *authUseCaseImpl) VerifyForgotPassword(ctx context.Context, req dto.Veri
 	key := fmt.Sprintf("reset_password:%s", resetPasswordToken)
 
 	if err = u.cachePro.SetString(ctx, key, forgData.Email, 3*time.Minute); err != nil {
-		u.log.Error("save email reset password failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("save email reset password failed", zap.Error(err))
 		return "", err
 	}
 
 	if err = u.cachePro.Del(ctx, redisKey); err != nil {
-		u.log.Error("delete forgot password data failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("delete forgot password data failed", zap.Error(err))
 	}
 
 	return resetPasswordToken, nil
@@ -369,7 +561,7 @@ func (u *authUseCaseImpl) ResetPassword(ctx context.Context, req dto.ResetPasswo
 	redisKey := fmt.Sprintf("reset_password:%s", req.ResetPasswordToken)
 	email, err := u.cachePro.GetString(ctx, redisKey)
 	if err != nil {
-		u.log.Error("get email reset password failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("get email reset password failed", zap.Error(err))
 		return err
 	}
 	if email == "" {
@@ -378,35 +570,44 @@ func (u *authUseCaseImpl) ResetPassword(ctx context.Context, req dto.ResetPasswo
 
 	user, err := u.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		u.log.Error("find user by email failed", zap.String("email", email), zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("find user by email failed", zap.String("email", email), zap.Error(err))
 		return err
 	}
 	if user == nil {
 		return customErr.ErrUnAuth
 	}
 
+	if err = u.passwordUC.Validate(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
-		u.log.Error("hash password failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("hash password failed", zap.Error(err))
 		return err
 	}
 
 	if err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		updateData := map[string]any{
-			"password":      hashedPassword,
-			"updated_by_id": user.ID,
+			"password":            hashedPassword,
+			"password_changed_at": time.Now(),
+			"updated_by_id":       user.ID,
 		}
 
 		if err = u.userRepo.UpdateTx(tx, user.ID, updateData); err != nil {
 			if errors.Is(err, customErr.ErrUserNotFound) {
 				return customErr.ErrUnAuth
 			}
-			u.log.Error("update password failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("update password failed", zap.Error(err))
+			return err
+		}
+
+		if err = u.passwordUC.SaveHistoryTx(tx, user.ID, hashedPassword); err != nil {
 			return err
 		}
 
 		if err := u.tokenRepo.UpdateAllByUserIDTx(tx, user.ID, map[string]any{"revoked_at": time.Now()}); err != nil {
-			u.log.Error("update all token by user id failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("update all token by user id failed", zap.Error(err))
 			return err
 		}
 		return nil
@@ -415,18 +616,42 @@ func (u *authUseCaseImpl) ResetPassword(ctx context.Context, req dto.ResetPasswo
 	}
 
 	if err = u.cachePro.Del(ctx, redisKey); err != nil {
-		u.log.Error("delete reset password failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("delete reset password failed", zap.Error(err))
 	}
 
 	redisKey = fmt.Sprintf("user_version:%d", user.ID)
 	if err = u.cachePro.Increment(ctx, redisKey); err != nil {
-		u.log.Error("increase token version failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("increase token version failed", zap.Error(err))
 	}
 
+	u.publishSessionRevoked(ctx, user.ID)
+
+	u.emailUC.SendPasswordChanged(ctx, user, time.Now())
+
 	return nil
 }
 
 func (u *authUseCaseImpl) UpdateInfo(ctx context.Context, userID int64, req dto.UpdateInfoRequest) (*model.User, error) {
+	ctx = tenant.Unscoped(ctx)
+
+	current, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+		return nil, err
+	}
+	if current == nil {
+		return nil, customErr.ErrInvalidUser
+	}
+
+	var avatar *model.File
+	if req.AvatarKey != nil && *req.AvatarKey != "" {
+		file, err := u.fileUC.ValidateAvatar(ctx, userID, userID, *req.AvatarKey)
+		if err != nil {
+			return nil, err
+		}
+		avatar = file
+	}
+
 	updateData := map[string]any{
 		"email":         req.Email,
 		"phone":         req.Phone,
@@ -434,33 +659,64 @@ func (u *authUseCaseImpl) UpdateInfo(ctx context.Context, userID int64, req dto.
 		"last_name":     req.LastName,
 		"updated_by_id": userID,
 	}
+	if req.Locale != "" {
+		updateData["locale"] = req.Locale
+	}
+	if req.EmailNotifications != nil {
+		updateData["email_notifications"] = *req.EmailNotifications
+	}
+	if req.AvatarKey != nil {
+		updateData["avatar_key"] = nil
+		if avatar != nil {
+			updateData["avatar_key"] = avatar.Key
+		}
+	}
 
-	if err := u.userRepo.Update(ctx, userID, updateData); err != nil {
-		if errors.Is(err, customErr.ErrUserNotFound) {
-			return nil, customErr.ErrInvalidUser
+	if err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := u.userRepo.UpdateTx(tx, userID, updateData); err != nil {
+			if errors.Is(err, customErr.ErrUserNotFound) {
+				return customErr.ErrInvalidUser
+			}
+			ok, constraint := utils.IsUniqueViolation(err)
+			if ok {
+				switch constraint {
+				case "users_email_key":
+					return customErr.ErrEmailAlreadyExists
+				case "users_phone_key":
+					return customErr.ErrPhoneAlreadyExists
+				}
+			}
+			logger.FromContext(ctx, u.log).Error("update user failed", zap.Int64("id", userID), zap.Error(err))
+			return err
 		}
-		ok, constraint := utils.IsUniqueViolation(err)
-		if ok {
-			switch constraint {
-			case "users_email_key":
-				return nil, customErr.ErrEmailAlreadyExists
-			case "users_phone_key":
-				return nil, customErr.ErrPhoneAlreadyExists
+
+		if req.AvatarKey != nil {
+			if err := u.fileUC.LinkAvatarTx(tx, userID, avatar); err != nil {
+				logger.FromContext(ctx, u.log).Error("link avatar failed", zap.Int64("id", userID), zap.Error(err))
+				return err
 			}
 		}
-		u.log.Error("update user failed", zap.Int64("id", userID), zap.Error(err))
+
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
 	user, err := u.userRepo.FindByIDWithDepartment(ctx, userID)
 	if err != nil {
-		u.log.Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
 		return nil, err
 	}
 	if user == nil {
 		return nil, customErr.ErrInvalidUser
 	}
 
+	if !strings.EqualFold(current.Email, user.Email) {
+		u.emailUC.SendEmailChanged(ctx, user, current.Email, user.UpdatedAt)
+	}
+
+	u.fileUC.ResolveAvatarURLs(ctx, user)
+
 	return user, nil
 }
 
@@ -474,3 +730,21 @@ func generateRefreshToken() (string, error) {
 
 	return rawToken, nil
 }
+
+func (u *authUseCaseImpl) publishSessionRevoked(ctx context.Context, userID int64) {
+	if err := u.realtimePro.PublishToUser(ctx, userID, port.RealtimeEvent{Type: constants.EventSessionRevoked}); err != nil {
+		logger.FromContext(ctx, u.log).Error("publish session revoked failed", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
+
+// isNewDevice reports whether the user has signed in before but never from
+// this device; a first-ever login is not treated as suspicious.
+func (u *authUseCaseImpl) isNewDevice(ctx context.Context, userID int64, device string) bool {
+	userAgents, err := u.tokenRepo.FindAllUserAgentsByUserID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find user agents by user id failed", zap.Int64("user_id", userID), zap.Error(err))
+		return false
+	}
+
+	return len(userAgents) > 0 && !slices.Contains(userAgents, device)
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/tenant"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const defaultImpersonationTTL = 15 * time.Minute
+
+// StartImpersonation issues a short-lived access token for the target user
+// carrying the admin as actor. No refresh token is issued: the admin's own
+// refresh cookie is left in place so StopImpersonation can restore it.
+func (u *authUseCaseImpl) StartImpersonation(ctx context.Context, adminID, userID int64, ip, ua string, req dto.StartImpersonationRequest) (*model.User, string, time.Duration, error) {
+	if adminID == userID {
+		return nil, "", 0, customErr.ErrCannotImpersonateUser
+	}
+
+	user, err := u.userRepo.FindByIDWithDepartment(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+		return nil, "", 0, err
+	}
+	if user == nil {
+		return nil, "", 0, customErr.ErrUserNotFound
+	}
+	if user.Role.IsAdmin() || !user.IsActive {
+		return nil, "", 0, customErr.ErrCannotImpersonateUser
+	}
+
+	redisKey := fmt.Sprintf("user_version:%d", user.ID)
+	tokenVersion, err := u.cachePro.GetInt(ctx, redisKey)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("get token version failed", zap.Error(err))
+		return nil, "", 0, err
+	}
+	if tokenVersion == 0 {
+		if err = u.cachePro.SetString(ctx, redisKey, "1", 0); err != nil {
+			logger.FromContext(ctx, u.log).Error("save token version failed", zap.Error(err))
+			return nil, "", 0, err
+		}
+		tokenVersion = 1
+	}
+
+	ttl := u.cfg.ImpersonationExpiresIn
+	if ttl <= 0 {
+		ttl = defaultImpersonationTTL
+	}
+
+	id, err := u.idGen.NextID()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate impersonation session id failed", zap.Error(err))
+		return nil, "", 0, err
+	}
+
+	session := &model.ImpersonationSession{
+		ID:         id,
+		AdminID:    adminID,
+		UserID:     user.ID,
+		PropertyID: user.PropertyID,
+		Reason:     req.Reason,
+		IPAddress:  ip,
+		UserAgent:  utils.ConvertUserAgent(ua),
+		ExpiresAt:  time.Now().Add(ttl),
+	}
+
+	if err = u.impersonationRepo.Create(ctx, session); err != nil {
+		logger.FromContext(ctx, u.log).Error("create impersonation session failed", zap.Error(err))
+		return nil, "", 0, err
+	}
+
+	accessToken, err := u.jwtPro.GenerateImpersonationToken(user.ID, user.AssignedPropertyID(), user.Role, tokenVersion, port.TokenActor{
+		UserID:    adminID,
+		SessionID: session.ID,
+	}, ttl)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate impersonation token failed", zap.Error(err))
+		return nil, "", 0, err
+	}
+
+	logger.FromContext(ctx, u.log).Info("impersonation started",
+		zap.Int64("session_id", session.ID),
+		zap.Int64("admin_id", adminID),
+		zap.Int64("target_user_id", user.ID),
+		zap.String("reason", req.Reason),
+		zap.String("ip", ip),
+	)
+
+	u.fileUC.ResolveAvatarURLs(ctx, user)
+
+	return user, accessToken, ttl, nil
+}
+
+// StopImpersonation revokes the impersonation token and exchanges the admin's
+// refresh token for a fresh session of their own.
+func (u *authUseCaseImpl) StopImpersonation(ctx context.Context, ua string, adminID, sessionID int64, accessToken, refreshToken string, accessTTL time.Duration) (*model.User, string, string, error) {
+	// The request is scoped to the impersonated user's property, which a
+	// chain admin is not part of.
+	ctx = tenant.Unscoped(ctx)
+
+	session, err := u.impersonationRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find impersonation session failed", zap.Int64("id", sessionID), zap.Error(err))
+		return nil, "", "", err
+	}
+	if session == nil || session.AdminID != adminID {
+		return nil, "", "", customErr.ErrNotImpersonating
+	}
+
+	redisKey := fmt.Sprintf("black_list:%s", accessToken)
+	if err = u.cachePro.SetString(ctx, redisKey, "1", accessTTL); err != nil {
+		logger.FromContext(ctx, u.log).Error("save black list failed", zap.Error(err))
+		return nil, "", "", err
+	}
+
+	if session.EndedAt == nil {
+		if err = u.impersonationRepo.Update(ctx, session.ID, map[string]any{"ended_at": time.Now()}); err != nil {
+			logger.FromContext(ctx, u.log).Error("update impersonation session failed", zap.Int64("id", session.ID), zap.Error(err))
+		}
+	}
+
+	logger.FromContext(ctx, u.log).Info("impersonation stopped",
+		zap.Int64("session_id", session.ID),
+		zap.Int64("admin_id", session.AdminID),
+		zap.Int64("target_user_id", session.UserID),
+	)
+
+	// The refresh cookie must still be the admin's own; anything else means
+	// the admin has to sign in again.
+	token, err := u.tokenRepo.FindByToken(ctx, utils.SHA256Hash(refreshToken))
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find token by token failed", zap.Error(err))
+		return nil, "", "", err
+	}
+	if token == nil || token.UserID != adminID {
+		return nil, "", "", customErr.ErrInvalidUser
+	}
+
+	newAccessToken, newRefreshToken, err := u.RefreshToken(ctx, ua, refreshToken)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	admin, err := u.GetMe(ctx, adminID)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return admin, newAccessToken, newRefreshToken, nil
+}
+
+func (u *authUseCaseImpl) GetImpersonationSessions(ctx context.Context, query dto.ImpersonationSessionPaginationQuery) ([]*model.ImpersonationSession, *dto.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	sessions, total, err := u.impersonationRepo.FindAllPaginated(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find all impersonation sessions paginated failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	users := make([]*model.User, 0, len(sessions)*2)
+	for _, session := range sessions {
+		users = append(users, session.Admin, session.User)
+	}
+	u.fileUC.ResolveAvatarURLs(ctx, users...)
+
+	meta := utils.CalculateMeta(total, query.Page, query.Limit)
+
+	return sessions, meta, nil
+}
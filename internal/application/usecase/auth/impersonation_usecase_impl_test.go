@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	fileUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/file"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+type fakeImpersonationRepo struct {
+	repository.ImpersonationSessionRepository
+	sessions []*model.ImpersonationSession
+}
+
+func (r *fakeImpersonationRepo) FindAllPaginated(context.Context, dto.ImpersonationSessionPaginationQuery) ([]*model.ImpersonationSession, int64, error) {
+	return r.sessions, int64(len(r.sessions)), nil
+}
+
+type avatarResolver struct {
+	fileUC.FileUseCase
+}
+
+func (avatarResolver) ResolveAvatarURLs(_ context.Context, users ...*model.User) {
+	for _, user := range users {
+		if user != nil {
+			user.AvatarURL = "avatar/" + user.Username
+		}
+	}
+}
+
+func TestGetImpersonationSessionsResolvesAvatars(t *testing.T) {
+	admin := &model.User{ID: 1, Username: "admin"}
+	sessions := []*model.ImpersonationSession{
+		{ID: 1, Admin: admin, User: &model.User{ID: 2, Username: "alice"}},
+		{ID: 2, Admin: admin, User: &model.User{ID: 3, Username: "bob"}},
+	}
+
+	u := &authUseCaseImpl{
+		log:               zap.NewNop(),
+		impersonationRepo: &fakeImpersonationRepo{sessions: sessions},
+		fileUC:            avatarResolver{},
+	}
+
+	got, _, err := u.GetImpersonationSessions(context.Background(), dto.ImpersonationSessionPaginationQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, session := range got {
+		for _, user := range []*model.User{session.Admin, session.User} {
+			if want := "avatar/" + user.Username; user.AvatarURL != want {
+				t.Fatalf("session %d: %s AvatarURL = %q, want %q", session.ID, user.Username, user.AvatarURL, want)
+			}
+		}
+	}
+}
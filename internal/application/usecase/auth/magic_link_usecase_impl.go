@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const defaultMagicLinkTTL = 15 * time.Minute
+
+// RequestMagicLink emails a single-use sign-in link. Unknown or inactive
+// accounts and roles without magic links are answered the same way as a
+// successful request, so the endpoint cannot be used to probe accounts.
+func (u *authUseCaseImpl) RequestMagicLink(ctx context.Context, req dto.MagicLinkRequest) error {
+	if u.mlCfg.URL == "" {
+		return errors.New("magic link url is not configured")
+	}
+
+	identifier := strings.TrimSpace(req.Identifier)
+	if err := u.reserveOTPRequest(ctx, constants.OTPChannelMagicLink, identifier); err != nil {
+		return err
+	}
+
+	var user *model.User
+	var err error
+	if strings.Contains(identifier, "@") {
+		user, err = u.userRepo.FindByEmail(ctx, identifier)
+	} else {
+		user, err = u.userRepo.FindByUsernameWithDepartment(ctx, identifier)
+	}
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find user by identifier failed", zap.String("identifier", identifier), zap.Error(err))
+		return err
+	}
+	if user == nil || !user.IsActive {
+		return nil
+	}
+
+	enabled, err := u.isMagicLinkEnabled(ctx, user.Role)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	randomPart, err := generateRefreshToken()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate magic link token failed", zap.Error(err))
+		return err
+	}
+	token := u.signMagicLinkToken(randomPart)
+
+	ttl := u.mlCfg.TTL
+	if ttl <= 0 {
+		ttl = defaultMagicLinkTTL
+	}
+
+	redisKey := fmt.Sprintf("magic_link:%s", utils.SHA256Hash(token))
+	if err = u.cachePro.SetString(ctx, redisKey, strconv.FormatInt(user.ID, 10), ttl); err != nil {
+		logger.FromContext(ctx, u.log).Error("save magic link failed", zap.Error(err))
+		return err
+	}
+
+	link, err := url.Parse(u.mlCfg.URL)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("parse magic link url failed", zap.Error(err))
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	u.emailUC.SendMagicLink(ctx, user, link.String(), ttl)
+
+	return nil
+}
+
+func (u *authUseCaseImpl) ConsumeMagicLink(ctx context.Context, ua string, req dto.ConsumeMagicLinkRequest) (*model.User, string, string, error) {
+	if !u.verifyMagicLinkToken(req.Token) {
+		return nil, "", "", customErr.ErrInvalidToken
+	}
+
+	redisKey := fmt.Sprintf("magic_link:%s", utils.SHA256Hash(req.Token))
+	userIDStr, err := u.cachePro.GetDelString(ctx, redisKey)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("get magic link failed", zap.Error(err))
+		return nil, "", "", err
+	}
+	if userIDStr == "" {
+		return nil, "", "", customErr.ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		return nil, "", "", customErr.ErrInvalidToken
+	}
+
+	user, err := u.userRepo.FindByIDWithDepartment(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+		return nil, "", "", err
+	}
+	if user == nil || !user.IsActive {
+		return nil, "", "", customErr.ErrLoginFailed
+	}
+
+	// The role may have been switched off after the link was sent.
+	enabled, err := u.isMagicLinkEnabled(ctx, user.Role)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if !enabled {
+		return nil, "", "", customErr.ErrLoginFailed
+	}
+
+	accessToken, refreshToken, err := u.createSession(ctx, ua, user)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	u.fileUC.ResolveAvatarURLs(ctx, user)
+
+	return user, accessToken, refreshToken, nil
+}
+
+func (u *authUseCaseImpl) GetRoleSettings(ctx context.Context) ([]*model.RoleSetting, error) {
+	settings, err := u.roleSetRepo.FindAll(ctx)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find all role settings failed", zap.Error(err))
+		return nil, err
+	}
+
+	byRole := make(map[model.UserRole]*model.RoleSetting, len(settings))
+	for _, setting := range settings {
+		byRole[setting.Role] = setting
+	}
+
+	result := make([]*model.RoleSetting, 0, 3)
+	for _, role := range []model.UserRole{model.RoleChainAdmin, model.RoleAdmin, model.RoleStaff} {
+		if setting, ok := byRole[role]; ok {
+			result = append(result, setting)
+			continue
+		}
+		result = append(result, &model.RoleSetting{Role: role})
+	}
+
+	return result, nil
+}
+
+func (u *authUseCaseImpl) UpdateRoleSetting(ctx context.Context, userID int64, role model.UserRole, req dto.UpdateRoleSettingRequest) (*model.RoleSetting, error) {
+	if !model.IsValidRole(role) {
+		return nil, customErr.ErrInvalidRole
+	}
+
+	setting := &model.RoleSetting{
+		Role:             role,
+		MagicLinkEnabled: *req.MagicLinkEnabled,
+		UpdatedByID:      &userID,
+	}
+
+	if err := u.roleSetRepo.Upsert(ctx, setting); err != nil {
+		logger.FromContext(ctx, u.log).Error("upsert role setting failed", zap.String("role", string(role)), zap.Error(err))
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+func (u *authUseCaseImpl) isMagicLinkEnabled(ctx context.Context, role model.UserRole) (bool, error) {
+	setting, err := u.roleSetRepo.FindByRole(ctx, role)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find role setting failed", zap.String("role", string(role)), zap.Error(err))
+		return false, err
+	}
+
+	return setting != nil && setting.MagicLinkEnabled, nil
+}
+
+// signMagicLinkToken appends an HMAC of the random part so forged tokens are
+// rejected before Redis is consulted.
+func (u *authUseCaseImpl) signMagicLinkToken(randomPart string) string {
+	mac := hmac.New(sha256.New, []byte(u.cfg.SecretKey))
+	mac.Write([]byte("magic_link:" + randomPart))
+
+	return randomPart + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (u *authUseCaseImpl) verifyMagicLinkToken(token string) bool {
+	randomPart, _, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+
+	return hmac.Equal([]byte(token), []byte(u.signMagicLinkToken(randomPart)))
+}
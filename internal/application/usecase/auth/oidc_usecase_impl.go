@@ -0,0 +1,433 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	oidcStateTTL            = 10 * time.Minute
+	maxUsernameAttempts     = 3
+	minUsernameLength       = 5
+	maxUsernameLength       = 50
+	usernameCollisionDigits = 4
+	defaultOIDCRedirectPath = "/"
+)
+
+func (u *authUseCaseImpl) StartOIDCLogin(ctx context.Context, redirect string) (string, string, error) {
+	if !u.oidcCfg.Enabled {
+		return "", "", customErr.ErrOIDCDisabled
+	}
+
+	state, err := generateRefreshToken()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate oidc state failed", zap.Error(err))
+		return "", "", err
+	}
+	nonce, err := generateRefreshToken()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate oidc nonce failed", zap.Error(err))
+		return "", "", err
+	}
+	codeVerifier, err := generateRefreshToken()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate pkce verifier failed", zap.Error(err))
+		return "", "", err
+	}
+
+	bytes, err := json.Marshal(dto.OIDCStateData{
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		Redirect:     safeRedirectPath(redirect),
+	})
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("json marshal oidc state failed", zap.Error(err))
+		return "", "", err
+	}
+
+	redisKey := fmt.Sprintf("oidc_state:%s", state)
+	if err = u.cachePro.SetObject(ctx, redisKey, bytes, oidcStateTTL); err != nil {
+		logger.FromContext(ctx, u.log).Error("save oidc state failed", zap.Error(err))
+		return "", "", err
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	authURL, err := u.oidcPro.AuthCodeURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("build oidc authorization url failed", zap.Error(err))
+		return "", "", err
+	}
+
+	return authURL, state, nil
+}
+
+// CompleteOIDCLogin handles the redirect back from the identity provider and
+// returns the session along with the frontend path the login started from.
+// stateCookie ties the callback to the browser that started the login.
+func (u *authUseCaseImpl) CompleteOIDCLogin(ctx context.Context, ua, stateCookie string, req dto.OIDCCallbackQuery) (*model.User, string, string, string, error) {
+	if !u.oidcCfg.Enabled {
+		return nil, "", "", "", customErr.ErrOIDCDisabled
+	}
+
+	if stateCookie == "" || subtle.ConstantTimeCompare([]byte(stateCookie), []byte(req.State)) != 1 {
+		return nil, "", "", "", customErr.ErrOIDCLoginFailed
+	}
+
+	// The state is consumed on first use so a callback URL cannot be
+	// replayed.
+	redisKey := fmt.Sprintf("oidc_state:%s", req.State)
+	stateStr, err := u.cachePro.GetDelString(ctx, redisKey)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("get oidc state failed", zap.Error(err))
+		return nil, "", "", "", err
+	}
+	if stateStr == "" {
+		return nil, "", "", "", customErr.ErrOIDCLoginFailed
+	}
+
+	var stateData dto.OIDCStateData
+	if err = json.Unmarshal([]byte(stateStr), &stateData); err != nil {
+		logger.FromContext(ctx, u.log).Error("json unmarshal oidc state failed", zap.Error(err))
+		return nil, "", "", "", err
+	}
+
+	if req.Error != "" || req.Code == "" {
+		logger.FromContext(ctx, u.log).Warn("oidc authorization denied", zap.String("error", req.Error))
+		return nil, "", "", "", customErr.ErrOIDCLoginFailed
+	}
+
+	identity, err := u.oidcPro.Exchange(ctx, req.Code, stateData.CodeVerifier, stateData.Nonce)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Warn("oidc code exchange failed", zap.Error(err))
+		return nil, "", "", "", customErr.ErrOIDCLoginFailed
+	}
+
+	user, err := u.resolveOIDCUser(ctx, identity)
+	if err != nil {
+		return nil, "", "", "", err
+	}
+	if !user.IsActive {
+		return nil, "", "", "", customErr.ErrLoginFailed
+	}
+
+	accessToken, refreshToken, err := u.createSession(ctx, ua, user)
+	if err != nil {
+		return nil, "", "", "", err
+	}
+
+	u.fileUC.ResolveAvatarURLs(ctx, user)
+
+	return user, accessToken, refreshToken, stateData.Redirect, nil
+}
+
+// resolveOIDCUser finds the local user for an external identity: first by an
+// existing link, then by verified email (creating the link), and finally by
+// provisioning a new account when just-in-time provisioning is enabled.
+func (u *authUseCaseImpl) resolveOIDCUser(ctx context.Context, identity *port.OIDCIdentity) (*model.User, error) {
+	link, err := u.identityRepo.FindByIssuerAndSubject(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find user identity failed", zap.Error(err))
+		return nil, err
+	}
+
+	var userID int64
+	switch {
+	case link != nil:
+		userID = link.UserID
+		if err = u.identityRepo.Update(ctx, link.ID, map[string]any{"last_login_at": time.Now()}); err != nil {
+			logger.FromContext(ctx, u.log).Error("update user identity failed", zap.Int64("id", link.ID), zap.Error(err))
+		}
+
+	// An unverified email could belong to anyone, so it never links.
+	case identity.Email == "" || !identity.EmailVerified:
+		return nil, customErr.ErrOIDCAccountNotFound
+
+	default:
+		user, err := u.userRepo.FindByEmail(ctx, identity.Email)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find user by email failed", zap.String("email", identity.Email), zap.Error(err))
+			return nil, err
+		}
+
+		if user == nil {
+			if !u.oidcCfg.JITEnabled {
+				return nil, customErr.ErrOIDCAccountNotFound
+			}
+			if userID, err = u.provisionOIDCUser(ctx, identity); err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		if user.Role.IsAdmin() && !u.oidcCfg.LinkAdmins {
+			logger.FromContext(ctx, u.log).Warn("refused oidc email link to admin account", zap.Int64("user_id", user.ID), zap.String("issuer", identity.Issuer))
+			return nil, customErr.ErrOIDCAccountNotFound
+		}
+
+		userID = user.ID
+		if err = u.linkOIDCIdentity(ctx, u.db.WithContext(ctx), userID, identity); err != nil {
+			if ok, _ := utils.IsUniqueViolation(err); !ok {
+				logger.FromContext(ctx, u.log).Error("create user identity failed", zap.Int64("user_id", userID), zap.Error(err))
+				return nil, err
+			}
+		}
+	}
+
+	user, err := u.userRepo.FindByIDWithDepartment(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, customErr.ErrOIDCAccountNotFound
+	}
+
+	return user, nil
+}
+
+func (u *authUseCaseImpl) provisionOIDCUser(ctx context.Context, identity *port.OIDCIdentity) (int64, error) {
+	mapping := matchGroupMapping(u.oidcCfg.GroupMappings, identity.Groups)
+	if mapping == nil {
+		return 0, customErr.ErrOIDCAccountNotFound
+	}
+
+	role := model.UserRole(mapping.Role)
+	if !model.IsValidRole(role) {
+		logger.FromContext(ctx, u.log).Error("oidc group mapping has invalid role", zap.String("group", mapping.Group), zap.String("role", mapping.Role))
+		return 0, customErr.ErrOIDCProvisioningFailed
+	}
+
+	phone := normalizeVNPhone(identity.PhoneNumber)
+	if phone == "" {
+		return 0, customErr.ErrOIDCProvisioningFailed
+	}
+
+	propertyID, departmentID, err := u.resolveMappingPlacement(ctx, mapping, role)
+	if err != nil {
+		return 0, err
+	}
+
+	firstName, lastName := identity.GivenName, identity.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(strings.TrimSpace(identity.Name), " ")
+	}
+	if firstName == "" {
+		firstName, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	// The account can only be entered through the identity provider until
+	// an admin sets a password.
+	randomPassword, err := generateRefreshToken()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate password failed", zap.Error(err))
+		return 0, err
+	}
+	hashedPassword, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("hash password failed", zap.Error(err))
+		return 0, err
+	}
+
+	baseUsername := oidcUsername(identity)
+	username := baseUsername
+	for attempt := 1; ; attempt++ {
+		id, err := u.idGen.NextID()
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("generate user id failed", zap.Error(err))
+			return 0, err
+		}
+
+		now := time.Now()
+		user := &model.User{
+			ID:                id,
+			Username:          username,
+			Email:             identity.Email,
+			Password:          hashedPassword,
+			PasswordChangedAt: &now,
+			FirstName:         firstName,
+			LastName:          lastName,
+			Phone:             phone,
+			Role:              role,
+			IsActive:          true,
+			DepartmentID:      departmentID,
+			PropertyID:        propertyID,
+		}
+
+		err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := u.userRepo.CreateTx(tx, user); err != nil {
+				return err
+			}
+			if err := u.passwordUC.SaveHistoryTx(tx, id, hashedPassword); err != nil {
+				return err
+			}
+			return u.linkOIDCIdentity(ctx, tx, id, identity)
+		})
+		if err == nil {
+			logger.FromContext(ctx, u.log).Info("oidc user provisioned", zap.Int64("id", id), zap.String("username", username), zap.String("group", mapping.Group))
+			return id, nil
+		}
+
+		if ok, constraint := utils.IsUniqueViolation(err); ok {
+			switch constraint {
+			case "users_username_key":
+				if attempt < maxUsernameAttempts {
+					username = truncateUsername(baseUsername, maxUsernameLength-usernameCollisionDigits) + utils.GenerateOTP(usernameCollisionDigits)
+					continue
+				}
+			case "users_email_key":
+				return 0, customErr.ErrEmailAlreadyExists
+			case "users_phone_key":
+				return 0, customErr.ErrPhoneAlreadyExists
+			}
+		}
+		if ok, _ := utils.IsForeignKeyViolation(err); ok {
+			logger.FromContext(ctx, u.log).Error("oidc group mapping has unknown department or property", zap.String("group", mapping.Group), zap.Int64("department_id", mapping.DepartmentID), zap.Int64("property_id", mapping.PropertyID))
+			return 0, customErr.ErrOIDCProvisioningFailed
+		}
+
+		logger.FromContext(ctx, u.log).Error("create oidc user failed", zap.Error(err))
+		return 0, err
+	}
+}
+
+// resolveMappingPlacement returns the property and department a group
+// mapping puts a new account in. Chain admins belong to no property; every
+// other role needs one, and the mapped department must be part of it.
+func (u *authUseCaseImpl) resolveMappingPlacement(ctx context.Context, mapping *config.OIDCGroupMapping, role model.UserRole) (*int64, *int64, error) {
+	log := logger.FromContext(ctx, u.log).With(zap.String("group", mapping.Group))
+
+	if role == model.RoleChainAdmin {
+		if mapping.PropertyID != 0 || mapping.DepartmentID != 0 {
+			log.Error("oidc group mapping places a chain admin in a property")
+			return nil, nil, customErr.ErrOIDCProvisioningFailed
+		}
+		return nil, nil, nil
+	}
+
+	if mapping.PropertyID == 0 {
+		log.Error("oidc group mapping has no property")
+		return nil, nil, customErr.ErrOIDCProvisioningFailed
+	}
+	propertyID := mapping.PropertyID
+
+	if mapping.DepartmentID == 0 {
+		return &propertyID, nil, nil
+	}
+
+	dept, err := u.deptRepo.FindByID(ctx, mapping.DepartmentID)
+	if err != nil {
+		log.Error("find department by id failed", zap.Int64("id", mapping.DepartmentID), zap.Error(err))
+		return nil, nil, err
+	}
+	if dept == nil || dept.PropertyID == nil || *dept.PropertyID != propertyID {
+		log.Error("oidc group mapping department is not in its property", zap.Int64("department_id", mapping.DepartmentID), zap.Int64("property_id", propertyID))
+		return nil, nil, customErr.ErrOIDCProvisioningFailed
+	}
+	departmentID := mapping.DepartmentID
+
+	return &propertyID, &departmentID, nil
+}
+
+func (u *authUseCaseImpl) linkOIDCIdentity(ctx context.Context, tx *gorm.DB, userID int64, identity *port.OIDCIdentity) error {
+	id, err := u.idGen.NextID()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate user identity id failed", zap.Error(err))
+		return err
+	}
+
+	now := time.Now()
+	return u.identityRepo.CreateTx(tx, &model.UserIdentity{
+		ID:          id,
+		UserID:      userID,
+		Issuer:      identity.Issuer,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+	})
+}
+
+func matchGroupMapping(mappings []config.OIDCGroupMapping, groups []string) *config.OIDCGroupMapping {
+	for i := range mappings {
+		if slices.Contains(groups, mappings[i].Group) {
+			return &mappings[i]
+		}
+	}
+	return nil
+}
+
+// oidcUsername derives a username from preferred_username or the email's
+// local part, keeping only characters usernames allow.
+func oidcUsername(identity *port.OIDCIdentity) string {
+	source := identity.PreferredUsername
+	if source == "" {
+		source, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(source) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+
+	username := truncateUsername(b.String(), maxUsernameLength)
+	if len(username) < minUsernameLength {
+		username += utils.GenerateOTP(uint8(minUsernameLength - len(username) + usernameCollisionDigits))
+	}
+
+	return username
+}
+
+func truncateUsername(username string, n int) string {
+	if len(username) > n {
+		return username[:n]
+	}
+	return username
+}
+
+// normalizeVNPhone converts +84/84-prefixed numbers to the local 10-digit
+// form stored on users. It returns "" when the number is not a Vietnamese
+// mobile number.
+func normalizeVNPhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+
+	if strings.HasPrefix(digits, "84") && len(digits) == 11 {
+		digits = "0" + digits[2:]
+	}
+	if len(digits) != 10 || digits[0] != '0' || !strings.ContainsRune("35789", rune(digits[1])) {
+		return ""
+	}
+
+	return digits
+}
+
+// safeRedirectPath only lets a login return to a path on the frontend, never
+// to another host.
+func safeRedirectPath(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.HasPrefix(redirect, "/\\") {
+		return defaultOIDCRedirectPath
+	}
+	return redirect
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/oidc"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/oidc/oidctest"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	testClientID     = "instay"
+	testClientSecret = "secret"
+	testRedirectURL  = "http://app.test/api/v2/auth/oidc/callback"
+)
+
+type memoryCache struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func (c *memoryCache) SetObject(_ context.Context, key string, data []byte, _ time.Duration) error {
+	return c.SetString(context.Background(), key, string(data), 0)
+}
+
+func (c *memoryCache) GetObject(_ context.Context, key string) ([]byte, error) {
+	str, err := c.GetString(context.Background(), key)
+	if str == "" {
+		return nil, err
+	}
+	return []byte(str), err
+}
+
+func (c *memoryCache) Del(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *memoryCache) SetString(_ context.Context, key, str string, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = str
+	return nil
+}
+
+func (c *memoryCache) GetString(_ context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[key], nil
+}
+
+func (c *memoryCache) GetDelString(_ context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	str := c.data[key]
+	delete(c.data, key)
+	return str, nil
+}
+
+func (c *memoryCache) GetInt(context.Context, string) (int, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (c *memoryCache) Increment(context.Context, string) error {
+	return errors.New("not implemented")
+}
+
+func (c *memoryCache) IncrementBy(context.Context, string, int64, time.Duration) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	byEmail map[string]*model.User
+}
+
+func (r *fakeUserRepo) FindByEmail(_ context.Context, email string) (*model.User, error) {
+	return r.byEmail[email], nil
+}
+
+type fakeIdentityRepo struct {
+	repository.UserIdentityRepository
+	created int
+}
+
+func (r *fakeIdentityRepo) FindByIssuerAndSubject(context.Context, string, string) (*model.UserIdentity, error) {
+	return nil, nil
+}
+
+func (r *fakeIdentityRepo) Create(context.Context, *model.UserIdentity) error {
+	r.created++
+	return nil
+}
+
+type oidcFixture struct {
+	idp        *oidctest.IdP
+	uc         *authUseCaseImpl
+	users      *fakeUserRepo
+	identities *fakeIdentityRepo
+}
+
+func newOIDCFixture(t *testing.T) *oidcFixture {
+	t.Helper()
+
+	idp, srv, err := oidctest.NewServer(testClientID, testClientSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+
+	idp.SetUser(oidctest.User{
+		Subject:       "subject-1",
+		Email:         "admin@instay.test",
+		EmailVerified: true,
+		GivenName:     "Ada",
+		FamilyName:    "Admin",
+	})
+
+	oidcCfg := config.OIDCConfig{
+		Enabled:      true,
+		IssuerURL:    srv.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+	}
+
+	f := &oidcFixture{
+		idp:        idp,
+		users:      &fakeUserRepo{byEmail: make(map[string]*model.User)},
+		identities: &fakeIdentityRepo{},
+	}
+	f.uc = &authUseCaseImpl{
+		oidcCfg:      oidcCfg,
+		log:          zap.NewNop(),
+		cachePro:     &memoryCache{data: make(map[string]string)},
+		oidcPro:      oidc.NewOIDCProvider(oidcCfg),
+		userRepo:     f.users,
+		identityRepo: f.identities,
+	}
+
+	return f
+}
+
+// authorize starts a login and follows it through the IdP, returning the
+// state cookie the browser would hold and the callback query it lands on.
+func (f *oidcFixture) authorize(t *testing.T) (string, dto.OIDCCallbackQuery) {
+	t.Helper()
+
+	authURL, state, err := f.uc.StartOIDCLogin(context.Background(), "/dashboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := location.Query()
+
+	return state, dto.OIDCCallbackQuery{
+		Code:  query.Get("code"),
+		State: query.Get("state"),
+		Error: query.Get("error"),
+	}
+}
+
+func TestCompleteOIDCLoginRejectsStateMismatch(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	for _, tc := range []struct {
+		name   string
+		cookie func(state string) string
+	}{
+		{"missing cookie", func(string) string { return "" }},
+		{"cookie from another login", func(string) string { return "attacker-state" }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state, query := f.authorize(t)
+
+			_, _, _, _, err := f.uc.CompleteOIDCLogin(context.Background(), "ua", tc.cookie(state), query)
+			if !errors.Is(err, customErr.ErrOIDCLoginFailed) {
+				t.Fatalf("err = %v, want ErrOIDCLoginFailed", err)
+			}
+		})
+	}
+}
+
+func TestCompleteOIDCLoginRejectsReplayedState(t *testing.T) {
+	f := newOIDCFixture(t)
+	f.users.byEmail["admin@instay.test"] = &model.User{ID: 1, Role: model.RoleStaff}
+	f.idp.OverrideClaims(map[string]any{"email_verified": false})
+
+	state, query := f.authorize(t)
+	if _, _, _, _, err := f.uc.CompleteOIDCLogin(context.Background(), "ua", state, query); !errors.Is(err, customErr.ErrOIDCAccountNotFound) {
+		t.Fatalf("first callback err = %v, want ErrOIDCAccountNotFound", err)
+	}
+
+	if _, _, _, _, err := f.uc.CompleteOIDCLogin(context.Background(), "ua", state, query); !errors.Is(err, customErr.ErrOIDCLoginFailed) {
+		t.Fatalf("replayed callback err = %v, want ErrOIDCLoginFailed", err)
+	}
+}
+
+func TestCompleteOIDCLoginRejectsInvalidIDToken(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		claims map[string]any
+	}{
+		{"bad nonce", map[string]any{"nonce": "forged"}},
+		{"missing nonce", map[string]any{"nonce": ""}},
+		{"wrong audience", map[string]any{"aud": "another-client"}},
+		{"wrong issuer", map[string]any{"iss": "https://evil.test"}},
+		{"expired", map[string]any{
+			"iat": time.Now().Add(-time.Hour).Unix(),
+			"exp": time.Now().Add(-10 * time.Minute).Unix(),
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newOIDCFixture(t)
+			f.idp.OverrideClaims(tc.claims)
+
+			state, query := f.authorize(t)
+			_, _, _, _, err := f.uc.CompleteOIDCLogin(context.Background(), "ua", state, query)
+			if !errors.Is(err, customErr.ErrOIDCLoginFailed) {
+				t.Fatalf("err = %v, want ErrOIDCLoginFailed", err)
+			}
+			if f.identities.created != 0 {
+				t.Fatalf("identity linked despite invalid token")
+			}
+		})
+	}
+}
+
+func TestCompleteOIDCLoginRefusesAdminEmailLink(t *testing.T) {
+	for _, role := range []model.UserRole{model.RoleAdmin, model.RoleChainAdmin} {
+		t.Run(string(role), func(t *testing.T) {
+			f := newOIDCFixture(t)
+			f.users.byEmail["admin@instay.test"] = &model.User{ID: 1, Role: role, IsActive: true}
+
+			state, query := f.authorize(t)
+			_, _, _, _, err := f.uc.CompleteOIDCLogin(context.Background(), "ua", state, query)
+			if !errors.Is(err, customErr.ErrOIDCAccountNotFound) {
+				t.Fatalf("err = %v, want ErrOIDCAccountNotFound", err)
+			}
+			if f.identities.created != 0 {
+				t.Fatalf("admin account linked by email")
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
 	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/tenant"
 	"github.com/InstaySystem/is_v2-be/pkg/utils"
 	"github.com/sony/sonyflake/v2"
 	"go.uber.org/zap"
@@ -31,14 +33,26 @@ func NewDepartmentUseCase(
 }
 
 func (u *departmentUseCaseImpl) CreateDepartment(ctx context.Context, userID int64, req dto.CreateDepartmentRequest) (int64, error) {
+	propertyID := req.PropertyID
+	if scoped := tenant.PropertyID(ctx); scoped != 0 {
+		if propertyID != nil && *propertyID != scoped {
+			return 0, customErr.ErrForbidden
+		}
+		propertyID = &scoped
+	}
+	if propertyID == nil {
+		return 0, customErr.ErrPropertyRequired
+	}
+
 	id, err := u.idGen.NextID()
 	if err != nil {
-		u.log.Error("generate department id failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("generate department id failed", zap.Error(err))
 		return 0, err
 	}
 
 	dept := &model.Department{
 		ID:          id,
+		PropertyID:  propertyID,
 		Name:        req.Name,
 		Description: req.Description,
 		IsActive:    req.IsActive,
@@ -49,13 +63,16 @@ func (u *departmentUseCaseImpl) CreateDepartment(ctx context.Context, userID int
 	if err = u.departmentRepo.Create(ctx, dept); err != nil {
 		if ok, constraint := utils.IsUniqueViolation(err); ok {
 			switch constraint {
-			case "departments_name_key":
+			case "departments_property_name_key":
 				return 0, customErr.ErrNameAlreadyExists
-			case "departments_phone_key":
+			case "departments_property_phone_key":
 				return 0, customErr.ErrPhoneAlreadyExists
 			}
 		}
-		u.log.Error("create department failed", zap.Error(err))
+		if ok, _ := utils.IsForeignKeyViolation(err); ok {
+			return 0, customErr.ErrPropertyNotFound
+		}
+		logger.FromContext(ctx, u.log).Error("create department failed", zap.Error(err))
 		return 0, err
 	}
 
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type EmailUseCase interface {
+	Send(ctx context.Context, msg dto.EmailMessage) error
+
+	SendPasswordChanged(ctx context.Context, user *model.User, changedAt time.Time)
+
+	SendNewLogin(ctx context.Context, user *model.User, device string, loginAt time.Time)
+
+	SendEmailChanged(ctx context.Context, user *model.User, oldEmail string, changedAt time.Time)
+
+	SendMagicLink(ctx context.Context, user *model.User, link string, expiresIn time.Duration)
+
+	IsSuppressed(ctx context.Context, email string) (bool, error)
+
+	GetEmailLogs(ctx context.Context, query dto.EmailLogPaginationQuery) ([]*model.EmailLog, *dto.MetaResponse, error)
+
+	GetSuppressions(ctx context.Context, query dto.EmailSuppressionPaginationQuery) ([]*model.EmailSuppression, *dto.MetaResponse, error)
+
+	DeleteSuppression(ctx context.Context, id int64) error
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/tenant"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var templateRoutingKeys = map[string]string{
+	constants.EmailTemplateWelcome:             constants.RoutingKeyWelcomeEmail,
+	constants.EmailTemplatePasswordChanged:     constants.RoutingKeyPasswordChangedEmail,
+	constants.EmailTemplateAccountDeactivated:  constants.RoutingKeyAccountDeactivatedEmail,
+	constants.EmailTemplateBookingConfirmation: constants.RoutingKeyBookingConfirmationEmail,
+	constants.EmailTemplateInvoice:             constants.RoutingKeyInvoiceEmail,
+	constants.EmailTemplateNewLogin:            constants.RoutingKeyNewLoginEmail,
+	constants.EmailTemplateEmailChanged:        constants.RoutingKeyEmailChangedEmail,
+	constants.EmailTemplateMagicLink:           constants.RoutingKeyMagicLinkEmail,
+}
+
+type emailUseCaseImpl struct {
+	log             *zap.Logger
+	mqPro           port.MessageQueueProvider
+	emailLogRepo    repository.EmailLogRepository
+	suppressionRepo repository.EmailSuppressionRepository
+}
+
+func NewEmailUseCase(
+	log *zap.Logger,
+	mqPro port.MessageQueueProvider,
+	emailLogRepo repository.EmailLogRepository,
+	suppressionRepo repository.EmailSuppressionRepository,
+) EmailUseCase {
+	return &emailUseCaseImpl{
+		log,
+		mqPro,
+		emailLogRepo,
+		suppressionRepo,
+	}
+}
+
+func (u *emailUseCaseImpl) Send(ctx context.Context, msg dto.EmailMessage) error {
+	routingKey, ok := templateRoutingKeys[msg.Template]
+	if !ok {
+		return fmt.Errorf("email template %q has no routing key", msg.Template)
+	}
+	if len(msg.To) == 0 {
+		return fmt.Errorf("email template %q has no recipients", msg.Template)
+	}
+
+	if !i18n.IsSupported(msg.Locale) {
+		msg.Locale = i18n.FromContext(ctx)
+	}
+	if msg.MessageID == "" {
+		msg.MessageID = uuid.NewString()
+	}
+	if propertyID := tenant.PropertyID(ctx); msg.PropertyID == nil && propertyID != 0 {
+		msg.PropertyID = &propertyID
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("json marshal email message failed", zap.String("template", msg.Template), zap.Error(err))
+		return err
+	}
+
+	go func(ctx context.Context) {
+		if err := u.mqPro.PublishMessage(ctx, constants.ExchangeEmail, routingKey, body); err != nil {
+			logger.FromContext(ctx, u.log).Error("publish email message failed", zap.String("template", msg.Template), zap.Strings("to", msg.To), zap.Error(err))
+		}
+	}(context.WithoutCancel(ctx))
+
+	return nil
+}
+
+func (u *emailUseCaseImpl) SendPasswordChanged(ctx context.Context, user *model.User, changedAt time.Time) {
+	u.sendSecurity(ctx, user, dto.EmailMessage{
+		Template: constants.EmailTemplatePasswordChanged,
+		To:       []string{user.Email},
+		Data: map[string]any{
+			"ChangedAt": formatEmailTime(changedAt),
+		},
+	})
+}
+
+func (u *emailUseCaseImpl) SendNewLogin(ctx context.Context, user *model.User, device string, loginAt time.Time) {
+	u.sendSecurity(ctx, user, dto.EmailMessage{
+		Template: constants.EmailTemplateNewLogin,
+		To:       []string{user.Email},
+		Data: map[string]any{
+			"Device":  device,
+			"LoginAt": formatEmailTime(loginAt),
+		},
+	})
+}
+
+// SendEmailChanged warns the previous address, which is the only one an
+// attacker who took over the account cannot read.
+func (u *emailUseCaseImpl) SendEmailChanged(ctx context.Context, user *model.User, oldEmail string, changedAt time.Time) {
+	u.sendSecurity(ctx, user, dto.EmailMessage{
+		Template: constants.EmailTemplateEmailChanged,
+		To:       []string{oldEmail},
+		Data: map[string]any{
+			"NewEmail":  user.Email,
+			"ChangedAt": formatEmailTime(changedAt),
+		},
+	})
+}
+
+func (u *emailUseCaseImpl) SendMagicLink(ctx context.Context, user *model.User, link string, expiresIn time.Duration) {
+	u.sendSecurity(ctx, user, dto.EmailMessage{
+		Template: constants.EmailTemplateMagicLink,
+		To:       []string{user.Email},
+		Data: map[string]any{
+			"Link":      link,
+			"ExpiresIn": int(expiresIn.Minutes()),
+		},
+	})
+}
+
+func (u *emailUseCaseImpl) sendSecurity(ctx context.Context, user *model.User, msg dto.EmailMessage) {
+	msg.Locale = user.Locale
+	msg.PropertyID = user.PropertyID
+	msg.Data["FullName"] = user.FirstName + " " + user.LastName
+
+	if err := u.Send(ctx, msg); err != nil {
+		logger.FromContext(ctx, u.log).Error("send security email failed", zap.Int64("user_id", user.ID), zap.String("template", msg.Template), zap.Error(err))
+	}
+}
+
+// IsSuppressed looks across every property: a suppressed address bounces no
+// matter which property sends to it.
+func (u *emailUseCaseImpl) IsSuppressed(ctx context.Context, email string) (bool, error) {
+	suppressed, err := u.suppressionRepo.ExistsByEmail(tenant.Unscoped(ctx), email)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("check email suppression failed", zap.String("email", email), zap.Error(err))
+		return false, err
+	}
+
+	return suppressed, nil
+}
+
+func (u *emailUseCaseImpl) GetEmailLogs(ctx context.Context, query dto.EmailLogPaginationQuery) ([]*model.EmailLog, *dto.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	emailLogs, total, err := u.emailLogRepo.FindAllPaginated(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find all email logs paginated failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	meta := utils.CalculateMeta(total, query.Page, query.Limit)
+
+	return emailLogs, meta, nil
+}
+
+func (u *emailUseCaseImpl) GetSuppressions(ctx context.Context, query dto.EmailSuppressionPaginationQuery) ([]*model.EmailSuppression, *dto.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	suppressions, total, err := u.suppressionRepo.FindAllPaginated(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find all email suppressions paginated failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	meta := utils.CalculateMeta(total, query.Page, query.Limit)
+
+	return suppressions, meta, nil
+}
+
+func (u *emailUseCaseImpl) DeleteSuppression(ctx context.Context, id int64) error {
+	if err := u.suppressionRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, customErr.ErrEmailSuppressionNotFound) {
+			return err
+		}
+		logger.FromContext(ctx, u.log).Error("delete email suppression failed", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// formatEmailTime renders an approximate, minute-precision UTC timestamp.
+func formatEmailTime(t time.Time) string {
+	return t.UTC().Format("02/01/2006 15:04 UTC")
+}
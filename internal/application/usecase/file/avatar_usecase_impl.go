@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ValidateAvatar accepts a confirmed avatar upload made by the acting user or
+// by the profile owner, that is not already attached to someone else.
+func (u *fileUseCaseImpl) ValidateAvatar(ctx context.Context, currentUserID, userID int64, key string) (*model.File, error) {
+	files, err := u.fileRepo.FindAllByKeys(ctx, []string{key})
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find avatar file failed", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, customErr.ErrInvalidAvatar
+	}
+	file := files[0]
+
+	if file.Status != model.FileStatusConfirmed || file.Purpose != model.FilePurposeAvatar {
+		return nil, customErr.ErrInvalidAvatar
+	}
+
+	if file.UploadedByID == nil || (*file.UploadedByID != currentUserID && *file.UploadedByID != userID) {
+		return nil, customErr.ErrInvalidAvatar
+	}
+
+	if file.IsLinked() && (*file.EntityType != model.FileEntityUser || *file.EntityID != userID) {
+		return nil, customErr.ErrInvalidAvatar
+	}
+
+	if _, err = checkUploadRule(file.OriginalName, file.ContentType, file.Size, file.Purpose); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// LinkAvatarTx detaches the user's previous avatar, leaving it to the orphan
+// cleanup, and attaches file when it is not nil.
+func (u *fileUseCaseImpl) LinkAvatarTx(tx *gorm.DB, userID int64, file *model.File) error {
+	if err := u.fileRepo.UpdateAllByEntityTx(tx, model.FileEntityUser, userID, model.FilePurposeAvatar, map[string]any{
+		"entity_type": nil,
+		"entity_id":   nil,
+	}); err != nil {
+		return err
+	}
+
+	if file == nil {
+		return nil
+	}
+
+	return u.fileRepo.UpdateTx(tx, file.ID, map[string]any{
+		"entity_type": model.FileEntityUser,
+		"entity_id":   userID,
+	})
+}
+
+// ResolveAvatarURLs fills AvatarURL on every user with a single file lookup,
+// preferring the thumbnail variant. Failures only leave the URLs empty.
+func (u *fileUseCaseImpl) ResolveAvatarURLs(ctx context.Context, users ...*model.User) {
+	keys := make([]string, 0, len(users))
+	seen := make(map[string]struct{}, len(users))
+	for _, user := range users {
+		if user == nil || user.AvatarKey == nil {
+			continue
+		}
+		if _, ok := seen[*user.AvatarKey]; ok {
+			continue
+		}
+		seen[*user.AvatarKey] = struct{}{}
+		keys = append(keys, *user.AvatarKey)
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	files, err := u.fileRepo.FindAllByKeysWithVariants(ctx, keys)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find avatar files failed", zap.Error(err))
+		return
+	}
+
+	urls := make(map[string]string, len(files))
+	for _, file := range files {
+		if file.Status != model.FileStatusConfirmed {
+			continue
+		}
+
+		key := file.Key
+		if variant := file.FindVariant(model.FileVariantThumbnail, ""); variant != nil {
+			key = variant.Key
+		}
+
+		viewURL, err := u.viewURL(ctx, key)
+		if err != nil {
+			continue
+		}
+		urls[file.Key] = viewURL
+	}
+
+	for _, user := range users {
+		if user != nil && user.AvatarKey != nil {
+			user.AvatarURL = urls[*user.AvatarKey]
+		}
+	}
+}
@@ -4,10 +4,32 @@ import (
 	"context"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"gorm.io/gorm"
 )
 
 type FileUseCase interface {
-	CreateUploadURLs(ctx context.Context, req dto.UploadPresignedURLsRequest) ([]*dto.UploadPresignedURLResponse, error)
+	CreateUploadURLs(ctx context.Context, userID int64, req dto.UploadPresignedURLsRequest) ([]*dto.UploadPresignedURLResponse, error)
 
-	CreateViewURLs(ctx context.Context, req dto.ViewPresignedURLsRequest) ([]*dto.ViewPresignedURLResponse, error)
+	ConfirmUploads(ctx context.Context, userID int64, req dto.ConfirmFilesRequest) ([]*model.File, error)
+
+	CreateMultipartUpload(ctx context.Context, userID int64, req dto.CreateMultipartUploadRequest) (*dto.MultipartUploadResponse, error)
+
+	GetMultipartUploads(ctx context.Context, userID int64) ([]*dto.MultipartUploadResponse, error)
+
+	GetMultipartUpload(ctx context.Context, userID int64, key string) (*dto.MultipartUploadResponse, error)
+
+	CreateUploadPartURLs(ctx context.Context, userID int64, key string, req dto.UploadPartURLsRequest) ([]*dto.UploadPartURLResponse, error)
+
+	CompleteMultipartUpload(ctx context.Context, userID int64, key string, req dto.CompleteMultipartUploadRequest) (*model.File, error)
+
+	AbortMultipartUpload(ctx context.Context, userID int64, key string) error
+
+	CreateViewURLs(ctx context.Context, userID int64, role model.UserRole, req dto.ViewPresignedURLsRequest) ([]*dto.ViewPresignedURLResponse, error)
+
+	ValidateAvatar(ctx context.Context, currentUserID, userID int64, key string) (*model.File, error)
+
+	LinkAvatarTx(tx *gorm.DB, userID int64, file *model.File) error
+
+	ResolveAvatarURLs(ctx context.Context, users ...*model.User)
 }
@@ -2,104 +2,397 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"mime"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
 	"github.com/InstaySystem/is_v2-be/pkg/utils"
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
+	"github.com/sony/sonyflake/v2"
 	"go.uber.org/zap"
 )
 
+// maxPresignedUploadSize caps a single presigned POST; larger files have to
+// go through the multipart flow so a dropped connection only costs one part.
+const maxPresignedUploadSize = 100 << 20
+
+type uploadRule struct {
+	contentTypes []string
+	maxSize      int64
+}
+
+var uploadRules = map[model.FilePurpose]uploadRule{
+	model.FilePurposeAvatar: {
+		contentTypes: []string{"image/jpeg", "image/png", "image/webp"},
+		maxSize:      5 << 20,
+	},
+	model.FilePurposeImage: {
+		contentTypes: []string{"image/jpeg", "image/png", "image/webp", "image/gif"},
+		maxSize:      10 << 20,
+	},
+	model.FilePurposeVideo: {
+		contentTypes: []string{"video/mp4", "video/quicktime", "video/webm"},
+		maxSize:      2 << 30,
+	},
+	model.FilePurposeDocument: {
+		contentTypes: []string{
+			"application/pdf",
+			"application/msword",
+			"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			"application/vnd.ms-excel",
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			"text/plain",
+			"text/csv",
+		},
+		maxSize: 20 << 20,
+	},
+}
+
 type fileUseCaseImpl struct {
-	cfg     config.MinIOConfig
-	client  *s3.Client
-	pClient *s3.PresignClient
-	log     *zap.Logger
+	uplCfg        config.UploadConfig
+	log           *zap.Logger
+	idGen         *sonyflake.Sonyflake
+	storPro       port.StorageProvider
+	quota         *UploadQuota
+	mqPro         port.MessageQueueProvider
+	fileRepo      repository.FileRepository
+	multipartRepo repository.MultipartUploadRepository
 }
 
 func NewFileUseCase(
-	cfg config.MinIOConfig,
-	stor *s3.Client,
+	uplCfg config.UploadConfig,
 	log *zap.Logger,
+	idGen *sonyflake.Sonyflake,
+	storPro port.StorageProvider,
+	cachePro port.CacheProvider,
+	mqPro port.MessageQueueProvider,
+	fileRepo repository.FileRepository,
+	multipartRepo repository.MultipartUploadRepository,
 ) FileUseCase {
-	pClient := s3.NewPresignClient(stor)
+	if uplCfg.URLExpiresIn <= 0 {
+		uplCfg.URLExpiresIn = 15 * time.Minute
+	}
+	if uplCfg.PartSize < minPartSize {
+		uplCfg.PartSize = defaultPartSize
+	}
+	if uplCfg.MultipartTTL <= 0 {
+		uplCfg.MultipartTTL = 24 * time.Hour
+	}
+
 	return &fileUseCaseImpl{
-		cfg,
-		stor,
-		pClient,
+		uplCfg,
 		log,
+		idGen,
+		storPro,
+		NewUploadQuota(uplCfg, log, cachePro),
+		mqPro,
+		fileRepo,
+		multipartRepo,
+	}
+}
+
+func (u *fileUseCaseImpl) CreateUploadURLs(ctx context.Context, userID int64, req dto.UploadPresignedURLsRequest) ([]*dto.UploadPresignedURLResponse, error) {
+	var totalSize int64
+	for i, file := range req.Files {
+		contentType, err := checkUploadRule(file.FileName, file.ContentType, file.Size, file.Purpose)
+		if err != nil {
+			return nil, err
+		}
+		if file.Size > maxPresignedUploadSize {
+			return nil, customErr.ErrMultipartUploadRequired.WithData(map[string]any{
+				"file_name": file.FileName,
+				"max_size":  maxPresignedUploadSize,
+			})
+		}
+		req.Files[i].ContentType = contentType
+		totalSize += file.Size
+	}
+
+	if err := u.quota.Reserve(ctx, userID, totalSize, int64(len(req.Files))); err != nil {
+		return nil, err
+	}
+
+	result, files, err := u.presignUploads(ctx, userID, req.Files)
+	if err != nil {
+		u.quota.Release(ctx, userID, totalSize, int64(len(req.Files)), time.Now())
+		return nil, err
+	}
+
+	if err = u.fileRepo.CreateAll(ctx, files); err != nil {
+		logger.FromContext(ctx, u.log).Error("create files failed", zap.Error(err))
+		u.quota.Release(ctx, userID, totalSize, int64(len(req.Files)), time.Now())
+		return nil, err
 	}
+
+	return result, nil
 }
 
-func (u *fileUseCaseImpl) CreateUploadURLs(ctx context.Context, req dto.UploadPresignedURLsRequest) ([]*dto.UploadPresignedURLResponse, error) {
-	result := make([]*dto.UploadPresignedURLResponse, 0, len(req.Files))
+func (u *fileUseCaseImpl) presignUploads(ctx context.Context, userID int64, reqFiles []dto.UploadPresignedURLRequest) ([]*dto.UploadPresignedURLResponse, []*model.File, error) {
+	result := make([]*dto.UploadPresignedURLResponse, 0, len(reqFiles))
+	files := make([]*model.File, 0, len(reqFiles))
 
-	for _, file := range req.Files {
-		name := strings.TrimSuffix(file.FileName, filepath.Ext(file.FileName))
-		ext := filepath.Ext(file.FileName)
+	for _, file := range reqFiles {
+		key := newObjectKey(file.FileName, file.Purpose)
+		presignedRes, err := u.storPro.PresignUpload(ctx, key, file.ContentType, file.Size, u.uplCfg.URLExpiresIn)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("generate upload presigned URL failed", zap.String("content_type", file.ContentType), zap.Error(err))
+			return nil, nil, err
+		}
 
-		key := fmt.Sprintf("%s-%s%s", uuid.NewString(), utils.GenerateSlug(name), ext)
-		presignedRes, err := u.pClient.PresignPutObject(ctx, &s3.PutObjectInput{
-			Bucket:      aws.String(u.cfg.Bucket),
-			Key:         aws.String(key),
-			ContentType: aws.String(file.ContentType),
-		}, func(opts *s3.PresignOptions) {
-			opts.Expires = 15 * time.Minute
-		})
+		id, err := u.idGen.NextID()
 		if err != nil {
-			u.log.Error("generate upload presigned URL failed", zap.String("content_type", file.ContentType), zap.Error(err))
-			return nil, err
+			logger.FromContext(ctx, u.log).Error("generate file id failed", zap.Error(err))
+			return nil, nil, err
 		}
 
+		files = append(files, &model.File{
+			ID:           id,
+			Key:          key,
+			OriginalName: file.FileName,
+			ContentType:  file.ContentType,
+			Purpose:      file.Purpose,
+			Status:       model.FileStatusPending,
+			UploadedByID: &userID,
+		})
+
 		result = append(result, &dto.UploadPresignedURLResponse{
-			Key: key,
-			Url: presignedRes.URL,
+			Key:    key,
+			Url:    presignedRes.URL,
+			Fields: presignedRes.Fields,
 		})
 	}
 
-	return result, nil
+	return result, files, nil
 }
 
-func (u *fileUseCaseImpl) CreateViewURLs(ctx context.Context, req dto.ViewPresignedURLsRequest) ([]*dto.ViewPresignedURLResponse, error) {
-	result := make([]*dto.ViewPresignedURLResponse, 0, len(req.Keys))
+func (u *fileUseCaseImpl) ConfirmUploads(ctx context.Context, userID int64, req dto.ConfirmFilesRequest) ([]*model.File, error) {
+	files, err := u.findOrderedByKeys(ctx, req.Keys, false)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, key := range req.Keys {
-		if _, err := u.client.HeadObject(ctx, &s3.HeadObjectInput{
-			Bucket: aws.String(u.cfg.Bucket),
-			Key:    aws.String(key),
-		}); err != nil {
-			var keyNotFound *types.NotFound
-			if errors.As(err, &keyNotFound) {
-				result = append(result, nil)
-				continue
-			}
-			u.log.Error("file check failed", zap.Error(err))
+	for _, file := range files {
+		if file == nil || file.UploadedByID == nil || *file.UploadedByID != userID {
+			return nil, customErr.ErrFileNotFound
+		}
+	}
+
+	for _, file := range files {
+		if file.Status == model.FileStatusConfirmed {
+			continue
+		}
+
+		if err = u.confirmFile(ctx, file); err != nil {
 			return nil, err
 		}
+	}
+
+	return files, nil
+}
 
-		presignedReq, err := u.pClient.PresignGetObject(ctx, &s3.GetObjectInput{
-			Bucket: aws.String(u.cfg.Bucket),
-			Key:    aws.String(key),
-		}, func(opts *s3.PresignOptions) {
-			opts.Expires = 15 * time.Minute
+func (u *fileUseCaseImpl) confirmFile(ctx context.Context, file *model.File) error {
+	head, err := u.storPro.Head(ctx, file.Key)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("file check failed", zap.String("key", file.Key), zap.Error(err))
+		return err
+	}
+	if head == nil {
+		return customErr.ErrFileNotUploaded.WithData(map[string]any{
+			"key": file.Key,
 		})
+	}
+
+	if _, err = checkUploadRule(file.OriginalName, head.ContentType, head.Size, file.Purpose); err != nil {
+		if _, delErr := u.storPro.Delete(ctx, []string{file.Key}); delErr != nil {
+			logger.FromContext(ctx, u.log).Error("delete rejected file failed", zap.String("key", file.Key), zap.Error(delErr))
+		}
+		return err
+	}
+
+	now := time.Now()
+	file.Size = head.Size
+	file.Checksum = head.Checksum
+	file.Status = model.FileStatusConfirmed
+	file.ConfirmedAt = &now
+	if head.ContentType != "" {
+		file.ContentType = head.ContentType
+	}
+
+	updateData := map[string]any{
+		"size":         file.Size,
+		"checksum":     file.Checksum,
+		"content_type": file.ContentType,
+		"status":       file.Status,
+		"confirmed_at": file.ConfirmedAt,
+	}
+
+	if err = u.fileRepo.Update(ctx, file.ID, updateData); err != nil {
+		if errors.Is(err, customErr.ErrFileNotFound) {
+			return err
+		}
+		logger.FromContext(ctx, u.log).Error("update file failed", zap.Int64("id", file.ID), zap.Error(err))
+		return err
+	}
+
+	if file.IsProcessableImage() {
+		u.publishImageProcess(ctx, file)
+	}
+
+	return nil
+}
+
+func (u *fileUseCaseImpl) CreateViewURLs(ctx context.Context, userID int64, role model.UserRole, req dto.ViewPresignedURLsRequest) ([]*dto.ViewPresignedURLResponse, error) {
+	files, err := u.findOrderedByKeys(ctx, req.Keys, req.Size != "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.ViewPresignedURLResponse, 0, len(req.Keys))
+
+	for _, file := range files {
+		if !canView(file, userID, role) {
+			result = append(result, nil)
+			continue
+		}
+
+		key := file.Key
+		if req.Size != "" {
+			if variant := file.FindVariant(req.Size, req.Format); variant != nil {
+				key = variant.Key
+			}
+		}
+
+		viewURL, err := u.viewURL(ctx, key)
 		if err != nil {
-			u.log.Error("generate view presigned URL failed", zap.Error(err))
 			return nil, err
 		}
 
 		result = append(result, &dto.ViewPresignedURLResponse{
-			Url: presignedReq.URL,
+			Url: viewURL,
 		})
 	}
 
 	return result, nil
 }
+
+func (u *fileUseCaseImpl) viewURL(ctx context.Context, key string) (string, error) {
+	if publicURL, ok := u.storPro.PublicURL(key); ok {
+		return publicURL, nil
+	}
+
+	viewURL, err := u.storPro.PresignDownload(ctx, key, 15*time.Minute)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate view presigned URL failed", zap.Error(err))
+		return "", err
+	}
+
+	return viewURL, nil
+}
+
+func (u *fileUseCaseImpl) findOrderedByKeys(ctx context.Context, keys []string, withVariants bool) ([]*model.File, error) {
+	var files []*model.File
+	var err error
+	if withVariants {
+		files, err = u.fileRepo.FindAllByKeysWithVariants(ctx, keys)
+	} else {
+		files, err = u.fileRepo.FindAllByKeys(ctx, keys)
+	}
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find files by keys failed", zap.Error(err))
+		return nil, err
+	}
+
+	byKey := make(map[string]*model.File, len(files))
+	for _, file := range files {
+		byKey[file.Key] = file
+	}
+
+	ordered := make([]*model.File, 0, len(keys))
+	for _, key := range keys {
+		ordered = append(ordered, byKey[key])
+	}
+
+	return ordered, nil
+}
+
+func (u *fileUseCaseImpl) publishImageProcess(ctx context.Context, file *model.File) {
+	imageMsg := dto.ImageProcessMessage{
+		FileID:      file.ID,
+		Key:         file.Key,
+		ContentType: file.ContentType,
+	}
+
+	go func(ctx context.Context, msg dto.ImageProcessMessage) {
+		body, err := json.Marshal(msg)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("json marshal failed", zap.Error(err))
+			return
+		}
+
+		if err = u.mqPro.PublishMessage(ctx, constants.ExchangeFile, constants.RoutingKeyImageVariants, body); err != nil {
+			logger.FromContext(ctx, u.log).Error("publish image process message failed", zap.String("key", msg.Key), zap.Error(err))
+		}
+	}(context.WithoutCancel(ctx), imageMsg)
+}
+
+func newObjectKey(fileName string, purpose model.FilePurpose) string {
+	ext := filepath.Ext(fileName)
+	name := strings.TrimSuffix(fileName, ext)
+
+	key := fmt.Sprintf("%s-%s%s", uuid.NewString(), utils.GenerateSlug(name), ext)
+	if purpose == model.FilePurposeAvatar {
+		key = constants.StoragePublicPrefix + key
+	}
+	return key
+}
+
+func checkUploadRule(fileName, contentType string, size int64, purpose model.FilePurpose) (string, error) {
+	rule, ok := uploadRules[purpose]
+	if !ok {
+		return "", customErr.ErrContentTypeNotAllowed.WithData(map[string]any{
+			"file_name": fileName,
+		})
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !slices.Contains(rule.contentTypes, mediaType) {
+		return "", customErr.ErrContentTypeNotAllowed.WithData(map[string]any{
+			"file_name": fileName,
+			"allowed":   rule.contentTypes,
+		})
+	}
+
+	if size > rule.maxSize {
+		return "", customErr.ErrFileTooLarge.WithData(map[string]any{
+			"file_name": fileName,
+			"max_size":  rule.maxSize,
+		})
+	}
+
+	return mediaType, nil
+}
+
+func canView(file *model.File, userID int64, role model.UserRole) bool {
+	if file == nil || file.Status != model.FileStatusConfirmed {
+		return false
+	}
+
+	if role.IsAdmin() || file.IsLinked() {
+		return true
+	}
+
+	return file.UploadedByID != nil && *file.UploadedByID == userID
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	minPartSize     = 5 << 20
+	defaultPartSize = 8 << 20
+	maxPartCount    = 10000
+)
+
+func (u *fileUseCaseImpl) CreateMultipartUpload(ctx context.Context, userID int64, req dto.CreateMultipartUploadRequest) (*dto.MultipartUploadResponse, error) {
+	contentType, err := checkUploadRule(req.FileName, req.ContentType, req.Size, req.Purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.quota.Reserve(ctx, userID, req.Size, 1); err != nil {
+		return nil, err
+	}
+
+	upload, err := u.createMultipartUpload(ctx, userID, req, contentType)
+	if err != nil {
+		u.quota.Release(ctx, userID, req.Size, 1, time.Now())
+		return nil, err
+	}
+
+	return toMultipartUploadResponse(upload, nil), nil
+}
+
+func (u *fileUseCaseImpl) createMultipartUpload(ctx context.Context, userID int64, req dto.CreateMultipartUploadRequest, contentType string) (*model.MultipartUpload, error) {
+	key := newObjectKey(req.FileName, req.Purpose)
+
+	uploadID, err := u.storPro.CreateMultipartUpload(ctx, key, contentType)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("create multipart upload failed", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	fileID, err := u.idGen.NextID()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate file id failed", zap.Error(err))
+		u.abortUpload(ctx, key, uploadID)
+		return nil, err
+	}
+
+	id, err := u.idGen.NextID()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate multipart upload id failed", zap.Error(err))
+		u.abortUpload(ctx, key, uploadID)
+		return nil, err
+	}
+
+	partSize, partCount := u.splitParts(req.Size)
+	upload := &model.MultipartUpload{
+		ID:        id,
+		FileID:    fileID,
+		UploadID:  uploadID,
+		PartSize:  partSize,
+		PartCount: partCount,
+		ExpiresAt: time.Now().Add(u.uplCfg.MultipartTTL),
+		File: &model.File{
+			ID:           fileID,
+			Key:          key,
+			OriginalName: req.FileName,
+			ContentType:  contentType,
+			Purpose:      req.Purpose,
+			Size:         req.Size,
+			Status:       model.FileStatusPending,
+			UploadedByID: &userID,
+		},
+	}
+
+	if err = u.multipartRepo.Create(ctx, upload); err != nil {
+		logger.FromContext(ctx, u.log).Error("create multipart upload record failed", zap.Error(err))
+		u.abortUpload(ctx, key, uploadID)
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (u *fileUseCaseImpl) GetMultipartUploads(ctx context.Context, userID int64) ([]*dto.MultipartUploadResponse, error) {
+	uploads, err := u.multipartRepo.FindAllByUploaderIDWithFile(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find multipart uploads failed", zap.Int64("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]*dto.MultipartUploadResponse, 0, len(uploads))
+	for _, upload := range uploads {
+		result = append(result, toMultipartUploadResponse(upload, nil))
+	}
+
+	return result, nil
+}
+
+func (u *fileUseCaseImpl) GetMultipartUpload(ctx context.Context, userID int64, key string) (*dto.MultipartUploadResponse, error) {
+	upload, err := u.findMultipartUpload(ctx, userID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := u.storPro.ListParts(ctx, key, upload.UploadID)
+	if err != nil {
+		if errors.Is(err, port.ErrMultipartUploadNotFound) {
+			return nil, customErr.ErrMultipartUploadNotFound
+		}
+		logger.FromContext(ctx, u.log).Error("list uploaded parts failed", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	return toMultipartUploadResponse(upload, parts), nil
+}
+
+func (u *fileUseCaseImpl) CreateUploadPartURLs(ctx context.Context, userID int64, key string, req dto.UploadPartURLsRequest) ([]*dto.UploadPartURLResponse, error) {
+	upload, err := u.findMultipartUpload(ctx, userID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.UploadPartURLResponse, 0, len(req.PartNumbers))
+	for _, partNumber := range req.PartNumbers {
+		if partNumber > upload.PartCount {
+			return nil, customErr.ErrInvalidUploadParts.WithData(map[string]any{
+				"part_number": partNumber,
+				"part_count":  upload.PartCount,
+			})
+		}
+
+		partURL, err := u.storPro.PresignUploadPart(ctx, key, upload.UploadID, partNumber, partLength(upload, partNumber), u.uplCfg.URLExpiresIn)
+		if err != nil {
+			if errors.Is(err, port.ErrMultipartUploadNotFound) {
+				return nil, customErr.ErrMultipartUploadNotFound
+			}
+			logger.FromContext(ctx, u.log).Error("generate upload part presigned URL failed", zap.String("key", key), zap.Int32("part_number", partNumber), zap.Error(err))
+			return nil, err
+		}
+
+		result = append(result, &dto.UploadPartURLResponse{
+			PartNumber: partNumber,
+			Url:        partURL,
+		})
+	}
+
+	return result, nil
+}
+
+func (u *fileUseCaseImpl) CompleteMultipartUpload(ctx context.Context, userID int64, key string, req dto.CompleteMultipartUploadRequest) (*model.File, error) {
+	upload, err := u.findMultipartUpload(ctx, userID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]*port.UploadedPart, 0, len(req.Parts))
+	for _, part := range req.Parts {
+		parts = append(parts, &port.UploadedPart{
+			PartNumber: part.PartNumber,
+			ETag:       part.ETag,
+		})
+	}
+	slices.SortFunc(parts, func(a, b *port.UploadedPart) int {
+		return int(a.PartNumber - b.PartNumber)
+	})
+
+	// Every part from 1 to PartCount must be present exactly once, otherwise
+	// the assembled object would silently miss a range of the file.
+	if len(parts) != int(upload.PartCount) {
+		return nil, customErr.ErrInvalidUploadParts
+	}
+	for i, part := range parts {
+		if part.PartNumber != int32(i+1) {
+			return nil, customErr.ErrInvalidUploadParts
+		}
+	}
+
+	if err = u.storPro.CompleteMultipartUpload(ctx, key, upload.UploadID, parts); err != nil {
+		switch {
+		case errors.Is(err, port.ErrMultipartUploadNotFound):
+			return nil, customErr.ErrMultipartUploadNotFound
+		case errors.Is(err, port.ErrInvalidUploadPart):
+			return nil, customErr.ErrInvalidUploadParts
+		}
+		logger.FromContext(ctx, u.log).Error("complete multipart upload failed", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	if err = u.multipartRepo.Delete(ctx, upload.ID); err != nil {
+		logger.FromContext(ctx, u.log).Error("delete multipart upload record failed", zap.Int64("id", upload.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if err = u.confirmFile(ctx, upload.File); err != nil {
+		return nil, err
+	}
+
+	return upload.File, nil
+}
+
+func (u *fileUseCaseImpl) AbortMultipartUpload(ctx context.Context, userID int64, key string) error {
+	upload, err := u.findMultipartUpload(ctx, userID, key)
+	if err != nil {
+		return err
+	}
+
+	if err = u.storPro.AbortMultipartUpload(ctx, key, upload.UploadID); err != nil {
+		logger.FromContext(ctx, u.log).Error("abort multipart upload failed", zap.String("key", key), zap.Error(err))
+		return err
+	}
+
+	deleted, err := u.fileRepo.DeleteAllByIDs(ctx, []int64{upload.FileID})
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("delete aborted file failed", zap.Int64("file_id", upload.FileID), zap.Error(err))
+		return err
+	}
+
+	// Only the call that removed the row gives the reservation back, so a
+	// concurrent abort by the cleanup job cannot release it twice.
+	if deleted > 0 {
+		u.quota.Release(ctx, userID, upload.File.Size, 1, upload.File.CreatedAt)
+	}
+
+	return nil
+}
+
+func (u *fileUseCaseImpl) findMultipartUpload(ctx context.Context, userID int64, key string) (*model.MultipartUpload, error) {
+	upload, err := u.multipartRepo.FindByFileKeyWithFile(ctx, key)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find multipart upload failed", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	if upload == nil || upload.File == nil || upload.File.UploadedByID == nil || *upload.File.UploadedByID != userID || time.Now().After(upload.ExpiresAt) {
+		return nil, customErr.ErrMultipartUploadNotFound
+	}
+
+	return upload, nil
+}
+
+func (u *fileUseCaseImpl) abortUpload(ctx context.Context, key, uploadID string) {
+	if err := u.storPro.AbortMultipartUpload(ctx, key, uploadID); err != nil {
+		logger.FromContext(ctx, u.log).Error("abort multipart upload failed", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// splitParts keeps the configured part size unless the file would need more
+// parts than S3 allows, in which case parts grow in whole MiB steps.
+func (u *fileUseCaseImpl) splitParts(size int64) (int64, int32) {
+	partSize := u.uplCfg.PartSize
+	if size > partSize*maxPartCount {
+		partSize = ((size+maxPartCount-1)/maxPartCount + 1<<20 - 1) / (1 << 20) * (1 << 20)
+	}
+
+	return partSize, int32((size + partSize - 1) / partSize)
+}
+
+// partLength is the exact size of a part, so the parts together can never
+// exceed the size the quota was reserved for.
+func partLength(upload *model.MultipartUpload, partNumber int32) int64 {
+	if partNumber < upload.PartCount {
+		return upload.PartSize
+	}
+	return upload.File.Size - int64(upload.PartCount-1)*upload.PartSize
+}
+
+func toMultipartUploadResponse(upload *model.MultipartUpload, parts []*port.UploadedPart) *dto.MultipartUploadResponse {
+	res := &dto.MultipartUploadResponse{
+		Key:          upload.File.Key,
+		OriginalName: upload.File.OriginalName,
+		ContentType:  upload.File.ContentType,
+		Size:         upload.File.Size,
+		PartSize:     upload.PartSize,
+		PartCount:    upload.PartCount,
+		ExpiresAt:    upload.ExpiresAt,
+	}
+
+	if parts != nil {
+		res.UploadedParts = make([]*dto.UploadedPartResponse, 0, len(parts))
+		for _, part := range parts {
+			res.UploadedParts = append(res.UploadedParts, &dto.UploadedPartResponse{
+				PartNumber: part.PartNumber,
+				ETag:       part.ETag,
+				Size:       part.Size,
+			})
+		}
+	}
+
+	return res
+}
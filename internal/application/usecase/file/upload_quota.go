@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const uploadQuotaWindow = 24 * time.Hour
+
+// UploadQuota counts each user's uploads per calendar day. A reservation is
+// released against the day it was made, so an upload abandoned overnight
+// does not free up quota on the next day.
+type UploadQuota struct {
+	cfg      config.UploadConfig
+	log      *zap.Logger
+	cachePro port.CacheProvider
+}
+
+func NewUploadQuota(cfg config.UploadConfig, log *zap.Logger, cachePro port.CacheProvider) *UploadQuota {
+	return &UploadQuota{cfg, log, cachePro}
+}
+
+func (q *UploadQuota) Reserve(ctx context.Context, userID, size, count int64) error {
+	now := time.Now()
+
+	if q.cfg.DailyQuotaFiles > 0 {
+		redisKey := quotaKey("files", userID, now)
+		total, err := q.cachePro.IncrementBy(ctx, redisKey, count, uploadQuotaWindow)
+		if err != nil {
+			logger.FromContext(ctx, q.log).Error("increase upload file quota failed", zap.Error(err))
+			return err
+		}
+		if total > q.cfg.DailyQuotaFiles {
+			q.decrement(ctx, redisKey, count)
+			return customErr.ErrUploadQuotaExceeded
+		}
+	}
+
+	if q.cfg.DailyQuotaBytes > 0 {
+		redisKey := quotaKey("bytes", userID, now)
+		total, err := q.cachePro.IncrementBy(ctx, redisKey, size, uploadQuotaWindow)
+		if err != nil {
+			logger.FromContext(ctx, q.log).Error("increase upload byte quota failed", zap.Error(err))
+			q.Release(ctx, userID, 0, count, now)
+			return err
+		}
+		if total > q.cfg.DailyQuotaBytes {
+			q.decrement(ctx, redisKey, size)
+			q.Release(ctx, userID, 0, count, now)
+			return customErr.ErrUploadQuotaExceeded
+		}
+	}
+
+	return nil
+}
+
+func (q *UploadQuota) Release(ctx context.Context, userID, size, count int64, reservedAt time.Time) {
+	if q.cfg.DailyQuotaFiles > 0 && count > 0 {
+		q.decrement(ctx, quotaKey("files", userID, reservedAt), count)
+	}
+	if q.cfg.DailyQuotaBytes > 0 && size > 0 {
+		q.decrement(ctx, quotaKey("bytes", userID, reservedAt), size)
+	}
+}
+
+// decrement keeps the window TTL, so releasing into a day whose counter has
+// already expired cannot leave a key behind forever.
+func (q *UploadQuota) decrement(ctx context.Context, redisKey string, value int64) {
+	if _, err := q.cachePro.IncrementBy(ctx, redisKey, -value, uploadQuotaWindow); err != nil {
+		logger.FromContext(ctx, q.log).Error("decrease upload quota failed", zap.String("key", redisKey), zap.Error(err))
+	}
+}
+
+func quotaKey(kind string, userID int64, day time.Time) string {
+	return fmt.Sprintf("upload_quota:%s:%d:%s", kind, userID, day.Format("20060102"))
+}
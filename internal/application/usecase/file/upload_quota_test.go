@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type counterCache struct {
+	port.CacheProvider
+	counters map[string]int64
+}
+
+func (c *counterCache) IncrementBy(_ context.Context, key string, value int64, _ time.Duration) (int64, error) {
+	c.counters[key] += value
+	return c.counters[key], nil
+}
+
+func newTestQuota(files, bytes int64) (*UploadQuota, *counterCache) {
+	cache := &counterCache{counters: make(map[string]int64)}
+	return NewUploadQuota(config.UploadConfig{DailyQuotaFiles: files, DailyQuotaBytes: bytes}, zap.NewNop(), cache), cache
+}
+
+func TestUploadQuotaReserve(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("within limits", func(t *testing.T) {
+		quota, cache := newTestQuota(2, 100)
+		if err := quota.Reserve(ctx, 1, 60, 1); err != nil {
+			t.Fatal(err)
+		}
+		if got := cache.counters[quotaKey("files", 1, now)]; got != 1 {
+			t.Fatalf("files = %d, want 1", got)
+		}
+		if got := cache.counters[quotaKey("bytes", 1, now)]; got != 60 {
+			t.Fatalf("bytes = %d, want 60", got)
+		}
+	})
+
+	t.Run("too many files", func(t *testing.T) {
+		quota, cache := newTestQuota(2, 100)
+		if err := quota.Reserve(ctx, 1, 10, 3); !errors.Is(err, customErr.ErrUploadQuotaExceeded) {
+			t.Fatalf("err = %v, want ErrUploadQuotaExceeded", err)
+		}
+		for key, got := range cache.counters {
+			if got != 0 {
+				t.Fatalf("%s = %d after a refused reservation", key, got)
+			}
+		}
+	})
+
+	t.Run("too many bytes gives the file count back", func(t *testing.T) {
+		quota, cache := newTestQuota(2, 100)
+		if err := quota.Reserve(ctx, 1, 101, 1); !errors.Is(err, customErr.ErrUploadQuotaExceeded) {
+			t.Fatalf("err = %v, want ErrUploadQuotaExceeded", err)
+		}
+		for key, got := range cache.counters {
+			if got != 0 {
+				t.Fatalf("%s = %d after a refused reservation", key, got)
+			}
+		}
+	})
+}
+
+func TestUploadQuotaReleaseUsesReservationDay(t *testing.T) {
+	ctx := context.Background()
+	quota, cache := newTestQuota(5, 1000)
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	cache.counters[quotaKey("files", 1, yesterday)] = 1
+	cache.counters[quotaKey("bytes", 1, yesterday)] = 300
+
+	quota.Release(ctx, 1, 300, 1, yesterday)
+
+	if got := cache.counters[quotaKey("files", 1, yesterday)]; got != 0 {
+		t.Fatalf("yesterday's files = %d, want 0", got)
+	}
+	if got := cache.counters[quotaKey("bytes", 1, yesterday)]; got != 0 {
+		t.Fatalf("yesterday's bytes = %d, want 0", got)
+	}
+	if _, ok := cache.counters[quotaKey("files", 1, time.Now())]; ok {
+		t.Fatal("release touched today's counter")
+	}
+}
+
+type abortStorage struct {
+	port.StorageProvider
+}
+
+func (abortStorage) AbortMultipartUpload(context.Context, string, string) error {
+	return nil
+}
+
+type abortMultipartRepo struct {
+	repository.MultipartUploadRepository
+	upload *model.MultipartUpload
+}
+
+func (r *abortMultipartRepo) FindByFileKeyWithFile(context.Context, string) (*model.MultipartUpload, error) {
+	return r.upload, nil
+}
+
+type abortFileRepo struct {
+	repository.FileRepository
+	rows int64
+}
+
+func (r *abortFileRepo) DeleteAllByIDs(context.Context, []int64) (int64, error) {
+	deleted := r.rows
+	r.rows = 0
+	return deleted, nil
+}
+
+func TestAbortMultipartUploadReleasesQuotaOnce(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(7)
+	reservedAt := time.Now().Add(-time.Hour)
+
+	quota, cache := newTestQuota(5, 1<<30)
+	cache.counters[quotaKey("files", userID, reservedAt)] = 1
+	cache.counters[quotaKey("bytes", userID, reservedAt)] = 50 << 20
+
+	u := &fileUseCaseImpl{
+		log:      zap.NewNop(),
+		storPro:  abortStorage{},
+		quota:    quota,
+		fileRepo: &abortFileRepo{rows: 1},
+		multipartRepo: &abortMultipartRepo{upload: &model.MultipartUpload{
+			FileID:    1,
+			UploadID:  "upload-1",
+			ExpiresAt: time.Now().Add(time.Hour),
+			File: &model.File{
+				ID:           1,
+				Key:          "uploads/video.mp4",
+				Size:         50 << 20,
+				UploadedByID: &userID,
+				CreatedAt:    reservedAt,
+			},
+		}},
+	}
+
+	for range 2 {
+		if err := u.AbortMultipartUpload(ctx, userID, "uploads/video.mp4"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := cache.counters[quotaKey("files", userID, reservedAt)]; got != 0 {
+		t.Fatalf("files = %d, want 0", got)
+	}
+	if got := cache.counters[quotaKey("bytes", userID, reservedAt)]; got != 0 {
+		t.Fatalf("bytes = %d, want 0", got)
+	}
+}
+
+func TestPartLength(t *testing.T) {
+	upload := &model.MultipartUpload{
+		PartSize:  8 << 20,
+		PartCount: 3,
+		File:      &model.File{Size: 20 << 20},
+	}
+
+	for _, tc := range []struct {
+		partNumber int32
+		want       int64
+	}{
+		{1, 8 << 20},
+		{2, 8 << 20},
+		{3, 4 << 20},
+	} {
+		if got := partLength(upload, tc.partNumber); got != tc.want {
+			t.Fatalf("partLength(%d) = %d, want %d", tc.partNumber, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type NotificationUseCase interface {
+	NotifyUser(ctx context.Context, userID int64, data dto.NotificationData) error
+
+	NotifyDepartment(ctx context.Context, departmentID int64, data dto.NotificationData) error
+
+	// NotifyOnDuty reaches the whole department instead only when nobody is
+	// on shift and fallbackToDepartment is set.
+	NotifyOnDuty(ctx context.Context, departmentID int64, data dto.NotificationData, fallbackToDepartment bool) (*dto.NotifyOnDutyResponse, error)
+
+	GetNotifications(ctx context.Context, userID int64, query dto.NotificationPaginationQuery) ([]*model.Notification, *dto.MetaResponse, error)
+
+	CountUnread(ctx context.Context, userID int64) (int64, error)
+
+	MarkRead(ctx context.Context, userID, notificationID int64) error
+
+	MarkAllRead(ctx context.Context, userID int64) (int64, error)
+}
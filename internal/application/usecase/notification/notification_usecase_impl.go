@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/mapper"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/sony/sonyflake/v2"
+	"go.uber.org/zap"
+)
+
+type notificationUseCaseImpl struct {
+	log         *zap.Logger
+	idGen       *sonyflake.Sonyflake
+	mqPro       port.MessageQueueProvider
+	realtimePro port.RealtimeProvider
+	userRepo    repository.UserRepository
+	notiRepo    repository.NotificationRepository
+}
+
+func NewNotificationUseCase(
+	log *zap.Logger,
+	idGen *sonyflake.Sonyflake,
+	mqPro port.MessageQueueProvider,
+	realtimePro port.RealtimeProvider,
+	userRepo repository.UserRepository,
+	notiRepo repository.NotificationRepository,
+) NotificationUseCase {
+	return &notificationUseCaseImpl{
+		log,
+		idGen,
+		mqPro,
+		realtimePro,
+		userRepo,
+		notiRepo,
+	}
+}
+
+func (u *notificationUseCaseImpl) NotifyUser(ctx context.Context, userID int64, data dto.NotificationData) error {
+	users, err := u.userRepo.FindAllActiveByIDs(ctx, []int64{userID})
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find active users by ids failed", zap.Int64("id", userID), zap.Error(err))
+		return err
+	}
+
+	return u.notify(ctx, users, data)
+}
+
+func (u *notificationUseCaseImpl) NotifyDepartment(ctx context.Context, departmentID int64, data dto.NotificationData) error {
+	users, err := u.userRepo.FindAllActiveByDepartmentID(ctx, departmentID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find active users by department id failed", zap.Int64("department_id", departmentID), zap.Error(err))
+		return err
+	}
+
+	return u.notify(ctx, users, data)
+}
+
+func (u *notificationUseCaseImpl) NotifyOnDuty(ctx context.Context, departmentID int64, data dto.NotificationData, fallbackToDepartment bool) (*dto.NotifyOnDutyResponse, error) {
+	audience := constants.NotificationAudienceOnDuty
+	users, err := u.userRepo.FindAllActiveOnDutyByDepartmentID(ctx, departmentID, time.Now())
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find on duty users by department id failed", zap.Int64("department_id", departmentID), zap.Error(err))
+		return nil, err
+	}
+
+	if len(users) == 0 && fallbackToDepartment {
+		audience = constants.NotificationAudienceDepartment
+		users, err = u.userRepo.FindAllActiveByDepartmentID(ctx, departmentID)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find active users by department id failed", zap.Int64("department_id", departmentID), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	if err = u.notify(ctx, users, data); err != nil {
+		return nil, err
+	}
+
+	return &dto.NotifyOnDutyResponse{
+		Audience:   audience,
+		Recipients: len(users),
+	}, nil
+}
+
+func (u *notificationUseCaseImpl) GetNotifications(ctx context.Context, userID int64, query dto.NotificationPaginationQuery) ([]*model.Notification, *dto.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	notis, total, err := u.notiRepo.FindAllByRecipientIDPaginated(ctx, userID, query)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find all notifications paginated failed", zap.Int64("recipient_id", userID), zap.Error(err))
+		return nil, nil, err
+	}
+
+	meta := utils.CalculateMeta(total, query.Page, query.Limit)
+
+	return notis, meta, nil
+}
+
+func (u *notificationUseCaseImpl) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	count, err := u.notiRepo.CountUnreadByRecipientID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("count unread notifications failed", zap.Int64("recipient_id", userID), zap.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (u *notificationUseCaseImpl) MarkRead(ctx context.Context, userID, notificationID int64) error {
+	if err := u.notiRepo.MarkReadByIDAndRecipientID(ctx, notificationID, userID); err != nil {
+		if errors.Is(err, customErr.ErrNotificationNotFound) {
+			return err
+		}
+		logger.FromContext(ctx, u.log).Error("mark notification read failed", zap.Int64("id", notificationID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (u *notificationUseCaseImpl) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	count, err := u.notiRepo.MarkAllReadByRecipientID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("mark all notifications read failed", zap.Int64("recipient_id", userID), zap.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (u *notificationUseCaseImpl) notify(ctx context.Context, users []*model.User, data dto.NotificationData) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	notis := make([]*model.Notification, 0, len(users))
+	for _, user := range users {
+		id, err := u.idGen.NextID()
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("generate notification id failed", zap.Error(err))
+			return err
+		}
+
+		notis = append(notis, &model.Notification{
+			ID:          id,
+			RecipientID: user.ID,
+			Type:        data.Type,
+			Title:       data.Title,
+			Body:        data.Body,
+			Link:        data.Link,
+		})
+	}
+
+	if err := u.notiRepo.CreateAll(ctx, notis); err != nil {
+		logger.FromContext(ctx, u.log).Error("create notifications failed", zap.Error(err))
+		return err
+	}
+
+	for i, user := range users {
+		event := port.RealtimeEvent{
+			Type: constants.EventNotificationCreated,
+			Data: mapper.ToNotificationResponse(notis[i]),
+		}
+		if err := u.realtimePro.PublishToUser(ctx, user.ID, event); err != nil {
+			logger.FromContext(ctx, u.log).Error("publish notification created failed", zap.Int64("user_id", user.ID), zap.Error(err))
+		}
+
+		if user.EmailNotifications {
+			u.publishEmail(ctx, user, data)
+		}
+	}
+
+	return nil
+}
+
+func (u *notificationUseCaseImpl) publishEmail(ctx context.Context, user *model.User, data dto.NotificationData) {
+	locale := user.Locale
+	if !i18n.IsSupported(locale) {
+		locale = i18n.FromContext(ctx)
+	}
+
+	emailMsg := dto.NotificationEmailMessage{
+		MessageID:  uuid.NewString(),
+		PropertyID: user.PropertyID,
+		To:         user.Email,
+		Title:      data.Title,
+		Body:       data.Body,
+		Link:       data.Link,
+		Locale:     locale,
+	}
+
+	go func(ctx context.Context, msg dto.NotificationEmailMessage) {
+		body, err := json.Marshal(msg)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("json marshal failed", zap.Error(err))
+			return
+		}
+
+		if err = u.mqPro.PublishMessage(ctx, constants.ExchangeEmail, constants.RoutingKeyNotificationEmail, body); err != nil {
+			logger.FromContext(ctx, u.log).Error("publish notification email message failed", zap.String("email", msg.To), zap.Error(err))
+		}
+	}(context.WithoutCancel(ctx), emailMsg)
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/sony/sonyflake/v2"
+	"go.uber.org/zap"
+)
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	onDuty     []*model.User
+	department []*model.User
+}
+
+func (r *fakeUserRepo) FindAllActiveOnDutyByDepartmentID(context.Context, int64, time.Time) ([]*model.User, error) {
+	return r.onDuty, nil
+}
+
+func (r *fakeUserRepo) FindAllActiveByDepartmentID(context.Context, int64) ([]*model.User, error) {
+	return r.department, nil
+}
+
+type fakeNotificationRepo struct {
+	repository.NotificationRepository
+	created []*model.Notification
+}
+
+func (r *fakeNotificationRepo) CreateAll(_ context.Context, notis []*model.Notification) error {
+	r.created = append(r.created, notis...)
+	return nil
+}
+
+type nopRealtime struct {
+	port.RealtimeProvider
+}
+
+func (nopRealtime) PublishToUser(context.Context, int64, port.RealtimeEvent) error {
+	return nil
+}
+
+func TestNotifyOnDuty(t *testing.T) {
+	idGen, err := sonyflake.New(sonyflake.Settings{
+		MachineID: func() (int, error) { return 1, nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	onShift := []*model.User{{ID: 1}}
+	department := []*model.User{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	for _, tc := range []struct {
+		name         string
+		onDuty       []*model.User
+		fallback     bool
+		wantAudience string
+		wantIDs      []int64
+	}{
+		{"staff on shift", onShift, true, constants.NotificationAudienceOnDuty, []int64{1}},
+		{"nobody on shift without fallback", nil, false, constants.NotificationAudienceOnDuty, nil},
+		{"nobody on shift with fallback", nil, true, constants.NotificationAudienceDepartment, []int64{1, 2, 3}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			notiRepo := &fakeNotificationRepo{}
+			u := &notificationUseCaseImpl{
+				log:         zap.NewNop(),
+				idGen:       idGen,
+				realtimePro: nopRealtime{},
+				userRepo:    &fakeUserRepo{onDuty: tc.onDuty, department: department},
+				notiRepo:    notiRepo,
+			}
+
+			res, err := u.NotifyOnDuty(context.Background(), 10, dto.NotificationData{Title: "Pool closed"}, tc.fallback)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Audience != tc.wantAudience || res.Recipients != len(tc.wantIDs) {
+				t.Fatalf("response = %+v, want audience %q with %d recipients", res, tc.wantAudience, len(tc.wantIDs))
+			}
+
+			if len(notiRepo.created) != len(tc.wantIDs) {
+				t.Fatalf("created %d notifications, want %d", len(notiRepo.created), len(tc.wantIDs))
+			}
+			for i, noti := range notiRepo.created {
+				if noti.RecipientID != tc.wantIDs[i] {
+					t.Fatalf("notification %d went to %d, want %d", i, noti.RecipientID, tc.wantIDs[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type PasswordUseCase interface {
+	Validate(ctx context.Context, user *model.User, newPassword string) error
+
+	SaveHistoryTx(tx *gorm.DB, userID int64, hashedPassword string) error
+
+	IsExpired(user *model.User) bool
+}
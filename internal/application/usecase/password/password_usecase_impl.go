@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/password"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/sony/sonyflake/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type passwordUseCaseImpl struct {
+	policy      *password.Policy
+	log         *zap.Logger
+	idGen       *sonyflake.Sonyflake
+	historyRepo repository.PasswordHistoryRepository
+}
+
+func NewPasswordUseCase(
+	policy *password.Policy,
+	log *zap.Logger,
+	idGen *sonyflake.Sonyflake,
+	historyRepo repository.PasswordHistoryRepository,
+) PasswordUseCase {
+	return &passwordUseCaseImpl{
+		policy,
+		log,
+		idGen,
+		historyRepo,
+	}
+}
+
+func (u *passwordUseCaseImpl) Validate(ctx context.Context, user *model.User, newPassword string) error {
+	if violations := u.policy.Check(newPassword, user.Username); len(violations) > 0 {
+		return customErr.ErrWeakPassword.WithData(map[string]any{
+			"violations": u.describe(ctx, violations),
+		})
+	}
+
+	if user.ID == 0 || u.policy.HistorySize() <= 0 {
+		return nil
+	}
+
+	reused, err := u.isReused(ctx, user, newPassword)
+	if err != nil {
+		return err
+	}
+	if reused {
+		return customErr.ErrPasswordReused.WithData(map[string]any{
+			"violations": u.describe(ctx, []string{password.RuleReused}),
+		})
+	}
+
+	return nil
+}
+
+func (u *passwordUseCaseImpl) SaveHistoryTx(tx *gorm.DB, userID int64, hashedPassword string) error {
+	keep := u.policy.HistorySize()
+	if keep <= 0 {
+		return nil
+	}
+
+	id, err := u.idGen.NextID()
+	if err != nil {
+		u.log.Error("generate password history id failed", zap.Error(err))
+		return err
+	}
+
+	history := &model.PasswordHistory{
+		ID:       id,
+		UserID:   userID,
+		Password: hashedPassword,
+	}
+
+	if err = u.historyRepo.CreateTx(tx, history); err != nil {
+		u.log.Error("create password history failed", zap.Int64("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	if err = u.historyRepo.DeleteAllExceptRecentTx(tx, userID, keep); err != nil {
+		u.log.Error("prune password history failed", zap.Int64("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (u *passwordUseCaseImpl) IsExpired(user *model.User) bool {
+	if user.PasswordChangedAt == nil {
+		return false
+	}
+
+	return u.policy.IsExpired(*user.PasswordChangedAt)
+}
+
+func (u *passwordUseCaseImpl) isReused(ctx context.Context, user *model.User, newPassword string) (bool, error) {
+	if user.Password != "" && utils.VerifyPassword(newPassword, user.Password) == nil {
+		return true, nil
+	}
+
+	histories, err := u.historyRepo.FindRecentByUserID(ctx, user.ID, u.policy.HistorySize())
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find recent password histories failed", zap.Int64("user_id", user.ID), zap.Error(err))
+		return false, err
+	}
+
+	for _, history := range histories {
+		if utils.VerifyPassword(newPassword, history.Password) == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (u *passwordUseCaseImpl) describe(ctx context.Context, violations []string) []*dto.PasswordViolationResponse {
+	locale := i18n.FromContext(ctx)
+
+	result := make([]*dto.PasswordViolationResponse, 0, len(violations))
+	for _, rule := range violations {
+		var message string
+		switch rule {
+		case password.RuleMinLength:
+			message = i18n.T(locale, "password."+rule, u.policy.MinLength())
+		case password.RuleReused:
+			message = i18n.T(locale, "password."+rule, u.policy.HistorySize())
+		default:
+			message = i18n.T(locale, "password."+rule)
+		}
+
+		result = append(result, &dto.PasswordViolationResponse{
+			Rule:    rule,
+			Message: message,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type PropertyUseCase interface {
+	CreateProperty(ctx context.Context, userID int64, req dto.CreatePropertyRequest) (int64, error)
+
+	GetProperties(ctx context.Context) ([]*model.Property, error)
+
+	GetPropertyByID(ctx context.Context, propertyID int64) (*model.Property, error)
+
+	UpdateProperty(ctx context.Context, propertyID, userID int64, req dto.UpdatePropertyRequest) error
+}
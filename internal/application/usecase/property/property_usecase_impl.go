@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/sony/sonyflake/v2"
+	"go.uber.org/zap"
+)
+
+type propertyUseCaseImpl struct {
+	log          *zap.Logger
+	idGen        *sonyflake.Sonyflake
+	propertyRepo repository.PropertyRepository
+}
+
+func NewPropertyUseCase(
+	log *zap.Logger,
+	idGen *sonyflake.Sonyflake,
+	propertyRepo repository.PropertyRepository,
+) PropertyUseCase {
+	return &propertyUseCaseImpl{
+		log,
+		idGen,
+		propertyRepo,
+	}
+}
+
+func (u *propertyUseCaseImpl) CreateProperty(ctx context.Context, userID int64, req dto.CreatePropertyRequest) (int64, error) {
+	id, err := u.idGen.NextID()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate property id failed", zap.Error(err))
+		return 0, err
+	}
+
+	property := &model.Property{
+		ID:          id,
+		Code:        req.Code,
+		Name:        req.Name,
+		Address:     req.Address,
+		IsActive:    *req.IsActive,
+		CreatedByID: &userID,
+		UpdatedByID: &userID,
+	}
+
+	if err = u.propertyRepo.Create(ctx, property); err != nil {
+		if ok, constraint := utils.IsUniqueViolation(err); ok && constraint == "properties_code_key" {
+			return 0, customErr.ErrPropertyCodeAlreadyExists
+		}
+		logger.FromContext(ctx, u.log).Error("create property failed", zap.Error(err))
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (u *propertyUseCaseImpl) GetProperties(ctx context.Context) ([]*model.Property, error) {
+	properties, err := u.propertyRepo.FindAll(ctx)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find all properties failed", zap.Error(err))
+		return nil, err
+	}
+
+	return properties, nil
+}
+
+func (u *propertyUseCaseImpl) GetPropertyByID(ctx context.Context, propertyID int64) (*model.Property, error) {
+	property, err := u.propertyRepo.FindByID(ctx, propertyID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find property by id failed", zap.Int64("id", propertyID), zap.Error(err))
+		return nil, err
+	}
+	if property == nil {
+		return nil, customErr.ErrPropertyNotFound
+	}
+
+	return property, nil
+}
+
+func (u *propertyUseCaseImpl) UpdateProperty(ctx context.Context, propertyID, userID int64, req dto.UpdatePropertyRequest) error {
+	updateData := map[string]any{
+		"code":          req.Code,
+		"name":          req.Name,
+		"address":       req.Address,
+		"is_active":     *req.IsActive,
+		"updated_by_id": userID,
+	}
+
+	if err := u.propertyRepo.Update(ctx, propertyID, updateData); err != nil {
+		if errors.Is(err, customErr.ErrPropertyNotFound) {
+			return err
+		}
+		if ok, constraint := utils.IsUniqueViolation(err); ok && constraint == "properties_code_key" {
+			return customErr.ErrPropertyCodeAlreadyExists
+		}
+		logger.FromContext(ctx, u.log).Error("update property failed", zap.Int64("id", propertyID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type ShiftUseCase interface {
+	CreateShift(ctx context.Context, userID int64, req dto.CreateShiftRequest) (int64, error)
+
+	GetShifts(ctx context.Context, query dto.ShiftPaginationQuery) ([]*model.Shift, *dto.MetaResponse, error)
+
+	GetShiftByID(ctx context.Context, shiftID int64) (*model.Shift, error)
+
+	UpdateShift(ctx context.Context, shiftID, userID int64, req dto.UpdateShiftRequest) error
+
+	DeleteShift(ctx context.Context, shiftID int64) error
+
+	AssignShift(ctx context.Context, shiftID, userID int64, req dto.AssignShiftRequest) (int64, error)
+
+	UnassignShift(ctx context.Context, shiftID, assignmentID int64) error
+
+	// GetRoster returns the start of the requested week and its shifts.
+	GetRoster(ctx context.Context, query dto.ShiftRosterQuery) (time.Time, []*model.Shift, error)
+
+	GetOnDuty(ctx context.Context, query dto.OnDutyQuery) ([]*model.ShiftAssignment, error)
+}
@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	fileUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/file"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/mapper"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/sony/sonyflake/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const maxShiftDuration = 24 * time.Hour
+
+type shiftUseCaseImpl struct {
+	db             *gorm.DB
+	log            *zap.Logger
+	idGen          *sonyflake.Sonyflake
+	deptRepo       repository.DepartmentRepository
+	userRepo       repository.UserRepository
+	shiftRepo      repository.ShiftRepository
+	assignmentRepo repository.ShiftAssignmentRepository
+	fileUC         fileUC.FileUseCase
+}
+
+func NewShiftUseCase(
+	db *gorm.DB,
+	log *zap.Logger,
+	idGen *sonyflake.Sonyflake,
+	deptRepo repository.DepartmentRepository,
+	userRepo repository.UserRepository,
+	shiftRepo repository.ShiftRepository,
+	assignmentRepo repository.ShiftAssignmentRepository,
+	fileUC fileUC.FileUseCase,
+) ShiftUseCase {
+	return &shiftUseCaseImpl{
+		db,
+		log,
+		idGen,
+		deptRepo,
+		userRepo,
+		shiftRepo,
+		assignmentRepo,
+		fileUC,
+	}
+}
+
+func (u *shiftUseCaseImpl) CreateShift(ctx context.Context, userID int64, req dto.CreateShiftRequest) (int64, error) {
+	if !isValidShiftTime(req.StartsAt, req.EndsAt) {
+		return 0, customErr.ErrInvalidShiftTime
+	}
+
+	dept, err := u.deptRepo.FindByID(ctx, req.DepartmentID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find department by id failed", zap.Int64("id", req.DepartmentID), zap.Error(err))
+		return 0, err
+	}
+	if dept == nil {
+		return 0, customErr.ErrDepartmentNotFound
+	}
+
+	id, err := u.idGen.NextID()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate shift id failed", zap.Error(err))
+		return 0, err
+	}
+
+	shift := &model.Shift{
+		ID:           id,
+		PropertyID:   dept.PropertyID,
+		DepartmentID: dept.ID,
+		Name:         req.Name,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+		Note:         req.Note,
+		CreatedByID:  &userID,
+		UpdatedByID:  &userID,
+	}
+
+	if err = u.shiftRepo.Create(ctx, shift); err != nil {
+		if ok, _ := utils.IsForeignKeyViolation(err); ok {
+			return 0, customErr.ErrDepartmentNotFound
+		}
+		logger.FromContext(ctx, u.log).Error("create shift failed", zap.Error(err))
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (u *shiftUseCaseImpl) GetShifts(ctx context.Context, query dto.ShiftPaginationQuery) ([]*model.Shift, *dto.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	shifts, total, err := u.shiftRepo.FindAllPaginated(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find all shifts paginated failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	meta := utils.CalculateMeta(total, query.Page, query.Limit)
+
+	return shifts, meta, nil
+}
+
+func (u *shiftUseCaseImpl) GetShiftByID(ctx context.Context, shiftID int64) (*model.Shift, error) {
+	shift, err := u.shiftRepo.FindByIDWithDetails(ctx, shiftID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find shift by id failed", zap.Int64("id", shiftID), zap.Error(err))
+		return nil, err
+	}
+	if shift == nil {
+		return nil, customErr.ErrShiftNotFound
+	}
+
+	u.resolveAssigneeAvatars(ctx, shift)
+
+	return shift, nil
+}
+
+// UpdateShift re-checks every assignee against the new times; the shift and
+// its assignees stay locked until the update commits, so a concurrent
+// assignment cannot slip in between.
+func (u *shiftUseCaseImpl) UpdateShift(ctx context.Context, shiftID, userID int64, req dto.UpdateShiftRequest) error {
+	if !isValidShiftTime(req.StartsAt, req.EndsAt) {
+		return customErr.ErrInvalidShiftTime
+	}
+
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		shift, err := u.shiftRepo.FindByIDForUpdateTx(tx, shiftID)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find shift for update failed", zap.Int64("id", shiftID), zap.Error(err))
+			return err
+		}
+		if shift == nil {
+			return customErr.ErrShiftNotFound
+		}
+
+		assignments, err := u.assignmentRepo.FindAllByShiftIDTx(tx, shiftID)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find shift assignments failed", zap.Int64("shift_id", shiftID), zap.Error(err))
+			return err
+		}
+
+		if len(assignments) > 0 {
+			userIDs := make([]int64, 0, len(assignments))
+			for _, assignment := range assignments {
+				userIDs = append(userIDs, assignment.UserID)
+			}
+
+			if _, err = u.userRepo.FindAllByIDsForUpdateTx(tx, userIDs); err != nil {
+				logger.FromContext(ctx, u.log).Error("find users for update failed", zap.Int64s("ids", userIDs), zap.Error(err))
+				return err
+			}
+
+			if err = u.checkConflicts(ctx, tx, userIDs, shiftID, req.StartsAt, req.EndsAt); err != nil {
+				return err
+			}
+		}
+
+		updateData := map[string]any{
+			"name":          req.Name,
+			"starts_at":     req.StartsAt,
+			"ends_at":       req.EndsAt,
+			"note":          req.Note,
+			"updated_by_id": userID,
+		}
+
+		if err = u.shiftRepo.UpdateTx(tx, shiftID, updateData); err != nil {
+			if errors.Is(err, customErr.ErrShiftNotFound) {
+				return err
+			}
+			logger.FromContext(ctx, u.log).Error("update shift failed", zap.Int64("id", shiftID), zap.Error(err))
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (u *shiftUseCaseImpl) DeleteShift(ctx context.Context, shiftID int64) error {
+	if err := u.shiftRepo.Delete(ctx, shiftID); err != nil {
+		if errors.Is(err, customErr.ErrShiftNotFound) {
+			return err
+		}
+		logger.FromContext(ctx, u.log).Error("delete shift failed", zap.Int64("id", shiftID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (u *shiftUseCaseImpl) AssignShift(ctx context.Context, shiftID, userID int64, req dto.AssignShiftRequest) (int64, error) {
+	id, err := u.idGen.NextID()
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("generate shift assignment id failed", zap.Error(err))
+		return 0, err
+	}
+
+	if err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		shift, err := u.shiftRepo.FindByIDForUpdateTx(tx, shiftID)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find shift for update failed", zap.Int64("id", shiftID), zap.Error(err))
+			return err
+		}
+		if shift == nil {
+			return customErr.ErrShiftNotFound
+		}
+
+		users, err := u.userRepo.FindAllByIDsForUpdateTx(tx, []int64{req.UserID})
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find user for update failed", zap.Int64("id", req.UserID), zap.Error(err))
+			return err
+		}
+		if len(users) == 0 {
+			return customErr.ErrUserNotFound
+		}
+		if user := users[0]; !user.IsActive || user.DepartmentID == nil || *user.DepartmentID != shift.DepartmentID {
+			return customErr.ErrUserNotInDepartment
+		}
+
+		if err = u.checkConflicts(ctx, tx, []int64{req.UserID}, shiftID, shift.StartsAt, shift.EndsAt); err != nil {
+			return err
+		}
+
+		assignment := &model.ShiftAssignment{
+			ID:          id,
+			PropertyID:  shift.PropertyID,
+			ShiftID:     shiftID,
+			UserID:      req.UserID,
+			Role:        req.Role,
+			CreatedByID: &userID,
+		}
+
+		if err = u.assignmentRepo.CreateTx(tx, assignment); err != nil {
+			if ok, constraint := utils.IsUniqueViolation(err); ok && constraint == "shift_assignments_shift_user_key" {
+				return customErr.ErrAlreadyAssignedToShift
+			}
+			logger.FromContext(ctx, u.log).Error("create shift assignment failed", zap.Int64("shift_id", shiftID), zap.Error(err))
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (u *shiftUseCaseImpl) UnassignShift(ctx context.Context, shiftID, assignmentID int64) error {
+	if err := u.assignmentRepo.DeleteByIDAndShiftID(ctx, assignmentID, shiftID); err != nil {
+		if errors.Is(err, customErr.ErrShiftAssignmentNotFound) {
+			return err
+		}
+		logger.FromContext(ctx, u.log).Error("delete shift assignment failed", zap.Int64("id", assignmentID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (u *shiftUseCaseImpl) GetRoster(ctx context.Context, query dto.ShiftRosterQuery) (time.Time, []*model.Shift, error) {
+	weekStart := query.WeekStart
+	if weekStart.IsZero() {
+		now := time.Now()
+		daysSinceMonday := (int(now.Weekday()) + 6) % 7
+		weekStart = time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, 0, 0, now.Location())
+	}
+
+	shifts, err := u.shiftRepo.FindAllWithAssignmentsBetween(ctx, query.DepartmentID, weekStart, weekStart.AddDate(0, 0, 7))
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find shifts between failed", zap.Time("week_start", weekStart), zap.Error(err))
+		return time.Time{}, nil, err
+	}
+
+	u.resolveAssigneeAvatars(ctx, shifts...)
+
+	return weekStart, shifts, nil
+}
+
+func (u *shiftUseCaseImpl) GetOnDuty(ctx context.Context, query dto.OnDutyQuery) ([]*model.ShiftAssignment, error) {
+	at := query.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	assignments, err := u.assignmentRepo.FindAllOnDuty(ctx, query.DepartmentID, at)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find on duty assignments failed", zap.Int64("department_id", query.DepartmentID), zap.Error(err))
+		return nil, err
+	}
+
+	users := make([]*model.User, 0, len(assignments))
+	for _, assignment := range assignments {
+		users = append(users, assignment.User)
+	}
+	u.fileUC.ResolveAvatarURLs(ctx, users...)
+
+	return assignments, nil
+}
+
+// checkConflicts fails with the clashing shifts when any of the users is
+// already on another shift overlapping [startsAt, endsAt). Callers hold the
+// users' row locks so the answer stays true until they commit.
+func (u *shiftUseCaseImpl) checkConflicts(ctx context.Context, tx *gorm.DB, userIDs []int64, shiftID int64, startsAt, endsAt time.Time) error {
+	conflicts, err := u.assignmentRepo.FindAllOverlappingTx(tx, userIDs, startsAt, endsAt, shiftID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find overlapping shift assignments failed", zap.Int64("shift_id", shiftID), zap.Error(err))
+		return err
+	}
+	if len(conflicts) > 0 {
+		return customErr.ErrShiftConflict.WithData(map[string]any{
+			"conflicts": mapper.ToShiftConflictsResponse(conflicts),
+		})
+	}
+
+	return nil
+}
+
+func (u *shiftUseCaseImpl) resolveAssigneeAvatars(ctx context.Context, shifts ...*model.Shift) {
+	var users []*model.User
+	for _, shift := range shifts {
+		for _, assignment := range shift.Assignments {
+			if assignment.User != nil {
+				users = append(users, assignment.User)
+			}
+		}
+	}
+
+	u.fileUC.ResolveAvatarURLs(ctx, users...)
+}
+
+func isValidShiftTime(startsAt, endsAt time.Time) bool {
+	return endsAt.After(startsAt) && endsAt.Sub(startsAt) <= maxShiftDuration
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/sony/sonyflake/v2"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// txDriver only supports BEGIN/COMMIT/ROLLBACK, which is all the use case
+// sends itself once the repositories are faked.
+type txDriver struct{}
+
+func (txDriver) Open(string) (driver.Conn, error) { return txConn{}, nil }
+
+type txConn struct{}
+
+func (txConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (txConn) Close() error                        { return nil }
+func (txConn) Begin() (driver.Tx, error)           { return txConn{}, nil }
+func (txConn) Commit() error                       { return nil }
+func (txConn) Rollback() error                     { return nil }
+
+func init() {
+	sql.Register("shift-test", txDriver{})
+}
+
+type fakeShiftRepo struct {
+	repository.ShiftRepository
+	shifts map[int64]*model.Shift
+}
+
+func (r *fakeShiftRepo) FindByIDForUpdateTx(_ *gorm.DB, id int64) (*model.Shift, error) {
+	return r.shifts[id], nil
+}
+
+func (r *fakeShiftRepo) UpdateTx(_ *gorm.DB, id int64, updateData map[string]any) error {
+	r.shifts[id].StartsAt = updateData["starts_at"].(time.Time)
+	r.shifts[id].EndsAt = updateData["ends_at"].(time.Time)
+	return nil
+}
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[int64]*model.User
+}
+
+func (r *fakeUserRepo) FindAllByIDsForUpdateTx(_ *gorm.DB, ids []int64) ([]*model.User, error) {
+	var users []*model.User
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+type fakeAssignmentRepo struct {
+	repository.ShiftAssignmentRepository
+	shifts      *fakeShiftRepo
+	assignments []*model.ShiftAssignment
+	checked     []int64
+}
+
+func (r *fakeAssignmentRepo) CreateTx(_ *gorm.DB, assignment *model.ShiftAssignment) error {
+	r.assignments = append(r.assignments, assignment)
+	return nil
+}
+
+func (r *fakeAssignmentRepo) FindAllByShiftIDTx(_ *gorm.DB, shiftID int64) ([]*model.ShiftAssignment, error) {
+	var assignments []*model.ShiftAssignment
+	for _, assignment := range r.assignments {
+		if assignment.ShiftID == shiftID {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments, nil
+}
+
+// FindAllOverlappingTx applies the same half-open test as the SQL query.
+func (r *fakeAssignmentRepo) FindAllOverlappingTx(_ *gorm.DB, userIDs []int64, startsAt, endsAt time.Time, excludeShiftID int64) ([]*model.ShiftAssignment, error) {
+	r.checked = append(r.checked, userIDs...)
+
+	var conflicts []*model.ShiftAssignment
+	for _, assignment := range r.assignments {
+		shift := r.shifts.shifts[assignment.ShiftID]
+		if slices.Contains(userIDs, assignment.UserID) && shift.ID != excludeShiftID &&
+			shift.StartsAt.Before(endsAt) && shift.EndsAt.After(startsAt) {
+			conflicts = append(conflicts, &model.ShiftAssignment{UserID: assignment.UserID, Shift: shift})
+		}
+	}
+	return conflicts, nil
+}
+
+type shiftFixture struct {
+	uc          *shiftUseCaseImpl
+	shifts      *fakeShiftRepo
+	assignments *fakeAssignmentRepo
+}
+
+var day = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+func newShiftFixture(t *testing.T) *shiftFixture {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DriverName: "shift-test"}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idGen, err := sonyflake.New(sonyflake.Settings{
+		MachineID: func() (int, error) { return 1, nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	departmentID := int64(1)
+	shifts := &fakeShiftRepo{shifts: map[int64]*model.Shift{
+		1: {ID: 1, DepartmentID: departmentID, StartsAt: day.Add(6 * time.Hour), EndsAt: day.Add(14 * time.Hour)},
+		2: {ID: 2, DepartmentID: departmentID, StartsAt: day.Add(14 * time.Hour), EndsAt: day.Add(22 * time.Hour)},
+		3: {ID: 3, DepartmentID: departmentID, StartsAt: day.Add(12 * time.Hour), EndsAt: day.Add(20 * time.Hour)},
+	}}
+	users := &fakeUserRepo{users: map[int64]*model.User{
+		10: {ID: 10, IsActive: true, DepartmentID: &departmentID},
+		11: {ID: 11, IsActive: true, DepartmentID: &departmentID},
+	}}
+	assignments := &fakeAssignmentRepo{shifts: shifts, assignments: []*model.ShiftAssignment{
+		{ID: 100, ShiftID: 1, UserID: 10},
+	}}
+
+	return &shiftFixture{
+		uc: &shiftUseCaseImpl{
+			db:             db,
+			log:            zap.NewNop(),
+			idGen:          idGen,
+			userRepo:       users,
+			shiftRepo:      shifts,
+			assignmentRepo: assignments,
+		},
+		shifts:      shifts,
+		assignments: assignments,
+	}
+}
+
+func isShiftConflict(err error) bool {
+	var apiErr *customErr.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == constants.CodeShiftConflict
+}
+
+func TestAssignShiftConflicts(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		shiftID  int64
+		conflict bool
+	}{
+		{"shift starting when the other ends", 2, false},
+		{"overlapping shift", 3, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newShiftFixture(t)
+
+			_, err := f.uc.AssignShift(context.Background(), tc.shiftID, 1, dto.AssignShiftRequest{UserID: 10, Role: model.ShiftRoleMember})
+			if tc.conflict != isShiftConflict(err) {
+				t.Fatalf("err = %v, want conflict %v", err, tc.conflict)
+			}
+			if !tc.conflict && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestUpdateShiftRechecksEveryAssignee(t *testing.T) {
+	f := newShiftFixture(t)
+	ctx := context.Background()
+
+	for _, userID := range []int64{10, 11} {
+		if _, err := f.uc.AssignShift(ctx, 2, 1, dto.AssignShiftRequest{UserID: userID, Role: model.ShiftRoleMember}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f.assignments.checked = nil
+	err := f.uc.UpdateShift(ctx, 2, 1, dto.UpdateShiftRequest{
+		Name:     "Late",
+		StartsAt: day.Add(13 * time.Hour),
+		EndsAt:   day.Add(21 * time.Hour),
+	})
+	if !isShiftConflict(err) {
+		t.Fatalf("err = %v, want shift conflict", err)
+	}
+	if !slices.Equal(f.assignments.checked, []int64{10, 11}) {
+		t.Fatalf("checked users %v, want [10 11]", f.assignments.checked)
+	}
+	if got := f.shifts.shifts[2].StartsAt; !got.Equal(day.Add(14 * time.Hour)) {
+		t.Fatalf("shift moved to %v despite the conflict", got)
+	}
+
+	if err = f.uc.UpdateShift(ctx, 2, 1, dto.UpdateShiftRequest{
+		Name:     "Late",
+		StartsAt: day.Add(14 * time.Hour),
+		EndsAt:   day.Add(23 * time.Hour),
+	}); err != nil {
+		t.Fatalf("moving the end of a touching shift: %v", err)
+	}
+}
@@ -8,9 +8,15 @@ import (
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
 	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	emailUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/email"
+	fileUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/file"
+	passwordUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/password"
 	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
 	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/tenant"
 	"github.com/InstaySystem/is_v2-be/pkg/utils"
 	"github.com/sony/sonyflake/v2"
 	"go.uber.org/zap"
@@ -18,13 +24,17 @@ import (
 )
 
 type userUseCaseImpl struct {
-	db        *gorm.DB
-	log       *zap.Logger
-	idGen     *sonyflake.Sonyflake
-	cachePro  port.CacheProvider
-	userRepo  repository.UserRepository
-	deptRepo  repository.DepartmentRepository
-	tokenRepo repository.TokenRepository
+	db          *gorm.DB
+	log         *zap.Logger
+	idGen       *sonyflake.Sonyflake
+	cachePro    port.CacheProvider
+	userRepo    repository.UserRepository
+	deptRepo    repository.DepartmentRepository
+	tokenRepo   repository.TokenRepository
+	passwordUC  passwordUC.PasswordUseCase
+	fileUC      fileUC.FileUseCase
+	emailUC     emailUC.EmailUseCase
+	realtimePro port.RealtimeProvider
 }
 
 func NewUserUseCase(
@@ -35,6 +45,10 @@ func NewUserUseCase(
 	userRepo repository.UserRepository,
 	deptRepo repository.DepartmentRepository,
 	tokenRepo repository.TokenRepository,
+	passwordUC passwordUC.PasswordUseCase,
+	fileUC fileUC.FileUseCase,
+	emailUC emailUC.EmailUseCase,
+	realtimePro port.RealtimeProvider,
 ) UserUseCase {
 	return &userUseCaseImpl{
 		db,
@@ -44,68 +58,115 @@ func NewUserUseCase(
 		userRepo,
 		deptRepo,
 		tokenRepo,
+		passwordUC,
+		fileUC,
+		emailUC,
+		realtimePro,
 	}
 }
 
 func (u *userUseCaseImpl) CreateUser(ctx context.Context, userID int64, req dto.CreateUserRequest) (int64, error) {
+	propertyID, err := u.resolveUserProperty(ctx, req.Role, req.PropertyID, req.DepartmentID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := u.passwordUC.Validate(ctx, &model.User{Username: req.Username}, req.Password); err != nil {
+		return 0, err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		u.log.Error("hash password failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("hash password failed", zap.Error(err))
 		return 0, err
 	}
 
 	id, err := u.idGen.NextID()
 	if err != nil {
-		u.log.Error("generate user id failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("generate user id failed", zap.Error(err))
 		return 0, err
 	}
 
+	now := time.Now()
 	user := &model.User{
-		ID:           id,
-		Username:     req.Username,
-		Email:        req.Email,
-		Password:     hashedPassword,
-		FirstName:    req.FirstName,
-		LastName:     req.LastName,
-		Phone:        req.Phone,
-		Role:         req.Role,
-		IsActive:     *req.IsActive,
-		DepartmentID: req.DepartmentID,
-		CreatedByID:  &userID,
-		UpdatedByID:  &userID,
-	}
-
-	if err = u.userRepo.Create(ctx, user); err != nil {
-		if ok, constraint := utils.IsUniqueViolation(err); ok {
-			switch constraint {
-			case "users_email_key":
-				return 0, customErr.ErrEmailAlreadyExists
-			case "users_username_key":
-				return 0, customErr.ErrUsernameAlreadyExists
-			case "users_phone_key":
-				return 0, customErr.ErrPhoneAlreadyExists
+		ID:                id,
+		Username:          req.Username,
+		Email:             req.Email,
+		Password:          hashedPassword,
+		PasswordChangedAt: &now,
+		FirstName:         req.FirstName,
+		LastName:          req.LastName,
+		Phone:             req.Phone,
+		Role:              req.Role,
+		IsActive:          *req.IsActive,
+		DepartmentID:      req.DepartmentID,
+		PropertyID:        propertyID,
+		CreatedByID:       &userID,
+		UpdatedByID:       &userID,
+	}
+
+	// Chain admins belong to no property, so the insert must not be stamped
+	// with the one the creator has selected.
+	txCtx := ctx
+	if req.Role == model.RoleChainAdmin {
+		txCtx = tenant.Unscoped(ctx)
+	}
+
+	if err = u.db.WithContext(txCtx).Transaction(func(tx *gorm.DB) error {
+		if err := u.userRepo.CreateTx(tx, user); err != nil {
+			if ok, constraint := utils.IsUniqueViolation(err); ok {
+				switch constraint {
+				case "users_email_key":
+					return customErr.ErrEmailAlreadyExists
+				case "users_username_key":
+					return customErr.ErrUsernameAlreadyExists
+				case "users_phone_key":
+					return customErr.ErrPhoneAlreadyExists
+				}
 			}
+			if ok, constraint := utils.IsForeignKeyViolation(err); ok {
+				if constraint == "fk_users_property" {
+					return customErr.ErrPropertyNotFound
+				}
+				return customErr.ErrDepartmentNotFound
+			}
+			logger.FromContext(ctx, u.log).Error("create user failed", zap.Error(err))
+			return err
 		}
-		if ok, _ := utils.IsForeignKeyViolation(err); ok {
-			return 0, customErr.ErrDepartmentNotFound
-		}
-		u.log.Error("create user failed", zap.Error(err))
+
+		return u.passwordUC.SaveHistoryTx(tx, id, hashedPassword)
+	}); err != nil {
 		return 0, err
 	}
 
+	if err = u.emailUC.Send(ctx, dto.EmailMessage{
+		Template:   constants.EmailTemplateWelcome,
+		Locale:     user.Locale,
+		PropertyID: user.PropertyID,
+		To:         []string{user.Email},
+		Data: map[string]any{
+			"FullName": user.FirstName + " " + user.LastName,
+			"Username": user.Username,
+		},
+	}); err != nil {
+		logger.FromContext(ctx, u.log).Error("send welcome email failed", zap.Int64("id", id), zap.Error(err))
+	}
+
 	return id, nil
 }
 
 func (u *userUseCaseImpl) GetUserByID(ctx context.Context, userID int64) (*model.User, error) {
 	user, err := u.userRepo.FindByIDWithDetails(ctx, userID)
 	if err != nil {
-		u.log.Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
 		return nil, err
 	}
 	if user == nil {
 		return nil, customErr.ErrUserNotFound
 	}
 
+	u.fileUC.ResolveAvatarURLs(ctx, user, user.CreatedBy, user.UpdatedBy)
+
 	return user, nil
 }
 
@@ -119,20 +180,27 @@ func (u *userUseCaseImpl) GetUsers(ctx context.Context, query dto.UserPagination
 
 	users, total, err := u.userRepo.FindAllWithDepartmentPaginated(ctx, query)
 	if err != nil {
-		u.log.Error("find all users paginated failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("find all users paginated failed", zap.Error(err))
 		return nil, nil, err
 	}
 
+	u.fileUC.ResolveAvatarURLs(ctx, users...)
+
 	meta := utils.CalculateMeta(total, query.Page, query.Limit)
 
 	return users, meta, nil
 }
 
 func (u *userUseCaseImpl) UpdateUser(ctx context.Context, userID, currentUserID int64, req dto.UpdateUserRequest) error {
-	if userID == currentUserID && (*req.IsActive == false || req.Role == model.RoleStaff) {
+	propertyID, err := u.resolveUserProperty(ctx, req.Role, req.PropertyID, req.DepartmentID)
+	if err != nil {
+		return err
+	}
+
+	if userID == currentUserID && (*req.IsActive == false || !req.Role.IsAdmin()) {
 		exists, err := u.userRepo.ExistsActiveAdminExceptID(ctx, userID)
 		if err != nil {
-			u.log.Error("check active admin except id failed", zap.Int64("id", userID), zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("check active admin except id failed", zap.Int64("id", userID), zap.Error(err))
 			return err
 		}
 		if !exists {
@@ -140,6 +208,30 @@ func (u *userUseCaseImpl) UpdateUser(ctx context.Context, userID, currentUserID
 		}
 	}
 
+	var deactivated *model.User
+	if *req.IsActive == false {
+		user, err := u.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+			return err
+		}
+		if user == nil {
+			return customErr.ErrUserNotFound
+		}
+		if user.IsActive {
+			deactivated = user
+		}
+	}
+
+	var avatar *model.File
+	if req.AvatarKey != nil && *req.AvatarKey != "" {
+		file, err := u.fileUC.ValidateAvatar(ctx, currentUserID, userID, *req.AvatarKey)
+		if err != nil {
+			return err
+		}
+		avatar = file
+	}
+
 	updateData := map[string]any{
 		"username":      req.Username,
 		"email":         req.Username,
@@ -149,8 +241,15 @@ func (u *userUseCaseImpl) UpdateUser(ctx context.Context, userID, currentUserID
 		"role":          req.Role,
 		"is_active":     *req.IsActive,
 		"department_id": req.DepartmentID,
+		"property_id":   propertyID,
 		"updated_by_id": currentUserID,
 	}
+	if req.AvatarKey != nil {
+		updateData["avatar_key"] = nil
+		if avatar != nil {
+			updateData["avatar_key"] = avatar.Key
+		}
+	}
 
 	if err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := u.userRepo.UpdateTx(tx, userID, updateData); err != nil {
@@ -167,16 +266,26 @@ func (u *userUseCaseImpl) UpdateUser(ctx context.Context, userID, currentUserID
 					return customErr.ErrPhoneAlreadyExists
 				}
 			}
-			if ok, _ := utils.IsForeignKeyViolation(err); ok {
+			if ok, constraint := utils.IsForeignKeyViolation(err); ok {
+				if constraint == "fk_users_property" {
+					return customErr.ErrPropertyNotFound
+				}
 				return customErr.ErrDepartmentNotFound
 			}
-			u.log.Error("update user failed", zap.Int64("id", userID), zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("update user failed", zap.Int64("id", userID), zap.Error(err))
 			return err
 		}
 
+		if req.AvatarKey != nil {
+			if err := u.fileUC.LinkAvatarTx(tx, userID, avatar); err != nil {
+				logger.FromContext(ctx, u.log).Error("link avatar failed", zap.Int64("id", userID), zap.Error(err))
+				return err
+			}
+		}
+
 		if *req.IsActive == false {
 			if err := u.tokenRepo.UpdateAllByUserIDTx(tx, userID, map[string]any{"revoked_at": time.Now()}); err != nil {
-				u.log.Error("update all token by user id failed", zap.Error(err))
+				logger.FromContext(ctx, u.log).Error("update all token by user id failed", zap.Error(err))
 				return err
 			}
 		}
@@ -189,36 +298,75 @@ func (u *userUseCaseImpl) UpdateUser(ctx context.Context, userID, currentUserID
 	if *req.IsActive == false {
 		redisKey := fmt.Sprintf("user_version:%d", userID)
 		if err := u.cachePro.Increment(ctx, redisKey); err != nil {
-			u.log.Error("increase token version failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("increase token version failed", zap.Error(err))
 		}
+
+		u.publishSessionRevoked(ctx, userID)
+
+		if deactivated != nil {
+			if err := u.emailUC.Send(ctx, dto.EmailMessage{
+				Template:   constants.EmailTemplateAccountDeactivated,
+				PropertyID: deactivated.PropertyID,
+				Locale:     deactivated.Locale,
+				To:         []string{deactivated.Email},
+				Data: map[string]any{
+					"FullName": deactivated.FirstName + " " + deactivated.LastName,
+				},
+			}); err != nil {
+				logger.FromContext(ctx, u.log).Error("send account deactivated email failed", zap.Int64("id", userID), zap.Error(err))
+			}
+		}
+		return nil
+	}
+
+	if err := u.realtimePro.PublishToUser(ctx, userID, port.RealtimeEvent{Type: constants.EventUserUpdated}); err != nil {
+		logger.FromContext(ctx, u.log).Error("publish user updated failed", zap.Int64("user_id", userID), zap.Error(err))
 	}
 
 	return nil
 }
 
 func (u *userUseCaseImpl) UpdateUserPassword(ctx context.Context, userID, currentUserID int64, newPassword string) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx, u.log).Error("find user by id failed", zap.Int64("id", userID), zap.Error(err))
+		return err
+	}
+	if user == nil {
+		return customErr.ErrUserNotFound
+	}
+
+	if err = u.passwordUC.Validate(ctx, user, newPassword); err != nil {
+		return err
+	}
+
 	hashedPassword, err := utils.HashPassword(newPassword)
 	if err != nil {
-		u.log.Error("hash password failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("hash password failed", zap.Error(err))
 		return err
 	}
 
 	if err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		updateData := map[string]any{
-			"password":      hashedPassword,
-			"updated_by_id": currentUserID,
+			"password":            hashedPassword,
+			"password_changed_at": time.Now(),
+			"updated_by_id":       currentUserID,
 		}
 
 		if err = u.userRepo.UpdateTx(tx, userID, updateData); err != nil {
 			if errors.Is(err, customErr.ErrUserNotFound) {
 				return err
 			}
-			u.log.Error("update password failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("update password failed", zap.Error(err))
+			return err
+		}
+
+		if err = u.passwordUC.SaveHistoryTx(tx, userID, hashedPassword); err != nil {
 			return err
 		}
 
 		if err := u.tokenRepo.UpdateAllByUserIDTx(tx, userID, map[string]any{"revoked_at": time.Now()}); err != nil {
-			u.log.Error("update all token by user id failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("update all token by user id failed", zap.Error(err))
 			return err
 		}
 		return nil
@@ -228,9 +376,13 @@ func (u *userUseCaseImpl) UpdateUserPassword(ctx context.Context, userID, curren
 
 	redisKey := fmt.Sprintf("user_version:%d", userID)
 	if err = u.cachePro.Increment(ctx, redisKey); err != nil {
-		u.log.Error("increase token version failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("increase token version failed", zap.Error(err))
 	}
 
+	u.publishSessionRevoked(ctx, userID)
+
+	u.emailUC.SendPasswordChanged(ctx, user, time.Now())
+
 	return nil
 }
 
@@ -238,7 +390,7 @@ func (u *userUseCaseImpl) DeleteUser(ctx context.Context, userID, currentUserID
 	if userID == currentUserID {
 		exists, err := u.userRepo.ExistsActiveAdminExceptID(ctx, userID)
 		if err != nil {
-			u.log.Error("check active admin except id failed", zap.Int64("id", userID), zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("check active admin except id failed", zap.Int64("id", userID), zap.Error(err))
 			return err
 		}
 		if !exists {
@@ -254,12 +406,12 @@ func (u *userUseCaseImpl) DeleteUser(ctx context.Context, userID, currentUserID
 			if ok, _ := utils.IsForeignKeyViolation(err); ok {
 				return customErr.ErrProtectedRecord
 			}
-			u.log.Error("delete user failed", zap.Int64("id", userID), zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("delete user failed", zap.Int64("id", userID), zap.Error(err))
 			return err
 		}
 
 		if err := u.tokenRepo.DeleteAllByUserIDTx(tx, userID); err != nil {
-			u.log.Error("delete all token by user id failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("delete all token by user id failed", zap.Error(err))
 			return err
 		}
 
@@ -270,24 +422,29 @@ func (u *userUseCaseImpl) DeleteUser(ctx context.Context, userID, currentUserID
 
 	redisKey := fmt.Sprintf("user_version:%d", userID)
 	if err := u.cachePro.Del(ctx, redisKey); err != nil {
-		u.log.Error("delete user version failed", zap.Error(err))
+		logger.FromContext(ctx, u.log).Error("delete user version failed", zap.Error(err))
 	}
 
+	u.publishSessionRevoked(ctx, userID)
+
 	return nil
 }
 
 func (u *userUseCaseImpl) DeleteUsers(ctx context.Context, currentUserID int64, userIDs []int64) (int64, error) {
-	var rowDeleted int64
+	var deletedIDs []int64
 	var err error
 	if err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		rowDeleted, err = u.userRepo.DeleteAllByIDsTx(tx, userIDs)
+		deletedIDs, err = u.userRepo.DeleteAllByIDsTx(tx, userIDs)
 		if err != nil {
-			u.log.Error("delete users failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("delete users failed", zap.Error(err))
 			return err
 		}
+		if len(deletedIDs) == 0 {
+			return nil
+		}
 
-		if err := u.tokenRepo.DeleteAllByUserIDsTx(tx, userIDs); err != nil {
-			u.log.Error("delete all token by user ids failed", zap.Error(err))
+		if err := u.tokenRepo.DeleteAllByUserIDsTx(tx, deletedIDs); err != nil {
+			logger.FromContext(ctx, u.log).Error("delete all token by user ids failed", zap.Error(err))
 			return err
 		}
 
@@ -296,12 +453,65 @@ func (u *userUseCaseImpl) DeleteUsers(ctx context.Context, currentUserID int64,
 		return 0, err
 	}
 
-	for _, id := range userIDs {
+	for _, id := range deletedIDs {
 		redisKey := fmt.Sprintf("user_version:%d", id)
 		if err := u.cachePro.Del(ctx, redisKey); err != nil {
-			u.log.Error("delete user version failed", zap.Error(err))
+			logger.FromContext(ctx, u.log).Error("delete user version failed", zap.Error(err))
 		}
+
+		u.publishSessionRevoked(ctx, id)
 	}
 
-	return rowDeleted, nil
+	return int64(len(deletedIDs)), nil
+}
+
+// resolveUserProperty decides which property a created or updated user
+// belongs to. Chain admins belong to none and only chain admins may grant
+// the role. Everyone else joins the property the request is scoped to, or
+// the requested one when a chain admin works across properties; their
+// department must be in that same property.
+func (u *userUseCaseImpl) resolveUserProperty(ctx context.Context, role model.UserRole, requested, departmentID *int64) (*int64, error) {
+	if role == model.RoleChainAdmin {
+		if !tenant.IsChainAdmin(ctx) {
+			return nil, customErr.ErrForbidden
+		}
+		return nil, nil
+	}
+
+	propertyID := requested
+	if scoped := tenant.PropertyID(ctx); scoped != 0 {
+		if requested != nil && *requested != scoped {
+			return nil, customErr.ErrForbidden
+		}
+		propertyID = &scoped
+	}
+	if propertyID == nil {
+		return nil, customErr.ErrPropertyRequired
+	}
+
+	if departmentID != nil {
+		dept, err := u.deptRepo.FindByID(ctx, *departmentID)
+		if err != nil {
+			logger.FromContext(ctx, u.log).Error("find department by id failed", zap.Int64("id", *departmentID), zap.Error(err))
+			return nil, err
+		}
+		if dept == nil || !sameProperty(dept.PropertyID, propertyID) {
+			return nil, customErr.ErrDepartmentNotFound
+		}
+	}
+
+	return propertyID, nil
+}
+
+func sameProperty(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (u *userUseCaseImpl) publishSessionRevoked(ctx context.Context, userID int64) {
+	if err := u.realtimePro.PublishToUser(ctx, userID, port.RealtimeEvent{Type: constants.EventSessionRevoked}); err != nil {
+		logger.FromContext(ctx, u.log).Error("publish session revoked failed", zap.Int64("user_id", userID), zap.Error(err))
+	}
 }
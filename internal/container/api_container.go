@@ -7,10 +7,19 @@ import (
 
 func (c *Container) initAPI() {
 	c.FileHTTPHdl = httpHdl.NewFileHandler(c.fileUC)
+	if c.localStor != nil {
+		c.StorageHTTPHdl = httpHdl.NewStorageHandler(c.localStor, c.cachePro)
+	}
 	c.AuthHTTPHdl = httpHdl.NewAuthHandler(c.cfg, c.authUC)
 	c.UserHTTPHdl = httpHdl.NewUserHandler(c.userUC)
 	c.DepartmentHTTPHdl = httpHdl.NewDepartmentHandler(c.departmentUC)
+	c.PropertyHTTPHdl = httpHdl.NewPropertyHandler(c.propertyUC)
+	c.ShiftHTTPHdl = httpHdl.NewShiftHandler(c.shiftUC)
+	c.RealtimeHTTPHdl = httpHdl.NewRealtimeHandler(c.realtimeHub, c.authUC)
+	c.NotificationHTTPHdl = httpHdl.NewNotificationHandler(c.notificationUC)
+	c.EmailHTTPHdl = httpHdl.NewEmailHandler(c.emailUC)
 
 	c.CtxHTTPMid = httpMid.NewContextMiddleware(c.Log)
 	c.AuthHTTPMid = httpMid.NewAuthMiddleware(c.cfg.JWT, c.Log, c.jwtPro, c.cachePro)
+	c.TenantHTTPMid = httpMid.NewTenantMiddleware(c.Log, c.propertyRepo)
 }
@@ -6,7 +6,12 @@ import (
 	"github.com/InstaySystem/is_v2-be/internal/application/port"
 	authUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/auth"
 	departmentUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/department"
+	emailUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/email"
 	fileUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/file"
+	notificationUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/notification"
+	passwordUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/password"
+	propertyUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/property"
+	shiftUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/shift"
 	userUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/user"
 	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
 	httpHdl "github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/handler"
@@ -14,8 +19,12 @@ import (
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/initialization"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/persistence/orm"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/local"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/rabbitmq"
+	redisPro "github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/redis"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/sms"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/smtp"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/realtime"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/redis/go-redis/v9"
 	"github.com/sony/sonyflake/v2"
@@ -23,30 +32,64 @@ import (
 )
 
 type Container struct {
-	cfg               *config.Config
-	Log               *zap.Logger
-	DB                *initialization.Database
-	cache             *redis.Client
-	mq                *initialization.MQ
-	stor              *s3.Client
-	IDGen             *sonyflake.Sonyflake
-	jwtPro            port.JWTProvider
-	MQPro             port.MessageQueueProvider
-	cachePro          port.CacheProvider
-	SMTPPro           port.SMTPProvider
-	UserRepo          repository.UserRepository
-	TokenRepo         repository.TokenRepository
-	departmentRepo    repository.DepartmentRepository
-	fileUC            fileUC.FileUseCase
-	authUC            authUC.AuthUseCase
-	userUC            userUC.UserUseCase
-	departmentUC      departmentUC.DepartmentUseCase
-	FileHTTPHdl       *httpHdl.FileHandler
-	AuthHTTPHdl       *httpHdl.AuthHandler
-	UserHTTPHdl       *httpHdl.UserHandler
-	DepartmentHTTPHdl *httpHdl.DepartmentHandler
-	CtxHTTPMid        *httpMid.ContextMiddleware
-	AuthHTTPMid       *httpMid.AuthMiddleware
+	cfg                 *config.Config
+	Log                 *zap.Logger
+	tracer              *initialization.Tracer
+	meter               *initialization.Meter
+	DB                  *initialization.Database
+	cache               *redis.Client
+	mq                  *initialization.MQ
+	stor                *s3.Client
+	localStor           *local.Storage
+	StorPro             port.StorageProvider
+	IDGen               *sonyflake.Sonyflake
+	jwtPro              port.JWTProvider
+	oidcPro             port.OIDCProvider
+	MQPro               port.MessageQueueProvider
+	cachePro            port.CacheProvider
+	realtimeHub         *realtime.Hub
+	realtimePro         port.RealtimeProvider
+	SMTPPro             port.SMTPProvider
+	SMSPro              port.SMSProvider
+	UserRepo            repository.UserRepository
+	TokenRepo           repository.TokenRepository
+	departmentRepo      repository.DepartmentRepository
+	propertyRepo        repository.PropertyRepository
+	shiftRepo           repository.ShiftRepository
+	shiftAssignmentRepo repository.ShiftAssignmentRepository
+	passwordHistoryRepo repository.PasswordHistoryRepository
+	FileRepo            repository.FileRepository
+	FileVariantRepo     repository.FileVariantRepository
+	MultipartUploadRepo repository.MultipartUploadRepository
+	UploadQuota         *fileUC.UploadQuota
+	notificationRepo    repository.NotificationRepository
+	EmailLogRepo        repository.EmailLogRepository
+	SuppressionRepo     repository.EmailSuppressionRepository
+	roleSettingRepo     repository.RoleSettingRepository
+	identityRepo        repository.UserIdentityRepository
+	impersonationRepo   repository.ImpersonationSessionRepository
+	passwordUC          passwordUC.PasswordUseCase
+	emailUC             emailUC.EmailUseCase
+	fileUC              fileUC.FileUseCase
+	authUC              authUC.AuthUseCase
+	userUC              userUC.UserUseCase
+	departmentUC        departmentUC.DepartmentUseCase
+	propertyUC          propertyUC.PropertyUseCase
+	shiftUC             shiftUC.ShiftUseCase
+	notificationUC      notificationUC.NotificationUseCase
+	FileHTTPHdl         *httpHdl.FileHandler
+	StorageHTTPHdl      *httpHdl.StorageHandler
+	AuthHTTPHdl         *httpHdl.AuthHandler
+	UserHTTPHdl         *httpHdl.UserHandler
+	DepartmentHTTPHdl   *httpHdl.DepartmentHandler
+	PropertyHTTPHdl     *httpHdl.PropertyHandler
+	ShiftHTTPHdl        *httpHdl.ShiftHandler
+	RealtimeHTTPHdl     *httpHdl.RealtimeHandler
+	NotificationHTTPHdl *httpHdl.NotificationHandler
+	EmailHTTPHdl        *httpHdl.EmailHandler
+	CtxHTTPMid          *httpMid.ContextMiddleware
+	AuthHTTPMid         *httpMid.AuthMiddleware
+	TenantHTTPMid       *httpMid.TenantMiddleware
 }
 
 func NewContainer(cfg *config.Config) *Container {
@@ -111,13 +154,45 @@ func (c *Container) InitConsumer() (err error) {
 		return err
 	}
 
+	c.tracer, err = initialization.InitTracer(c.cfg.OTel, "consumer")
+	if err != nil {
+		return err
+	}
+
+	c.DB, err = initialization.InitDatabase(c.cfg.PostgreSQL)
+	if err != nil {
+		return err
+	}
+
+	if err = c.initStorage(); err != nil {
+		return err
+	}
+
 	c.mq, err = initialization.InitRabbitMQ(c.cfg.RabbitMQ)
 	if err != nil {
 		return err
 	}
 
+	c.IDGen, err = initialization.InitSnowFlake()
+	if err != nil {
+		return err
+	}
+
+	c.SMTPPro, err = smtp.NewSMTPProvider(c.cfg.SMTPConfig)
+	if err != nil {
+		return err
+	}
+
+	c.SMSPro, err = sms.NewSMSProvider(c.cfg.SMSConfig, c.Log)
+	if err != nil {
+		return err
+	}
+
 	c.MQPro = rabbitmq.NewMessageQueueProvider(c.mq.Conn, c.mq.Chan, c.Log)
-	c.SMTPPro = smtp.NewSMTPProvider(c.cfg.SMTPConfig)
+	c.FileRepo = orm.NewFileRepository(c.DB.Gorm)
+	c.FileVariantRepo = orm.NewFileVariantRepository(c.DB.Gorm)
+	c.EmailLogRepo = orm.NewEmailLogRepository(c.DB.Gorm)
+	c.SuppressionRepo = orm.NewEmailSuppressionRepository(c.DB.Gorm)
 
 	return nil
 }
@@ -134,17 +209,51 @@ func (c *Container) InitScheduler() (err error) {
 		return err
 	}
 
+	c.tracer, err = initialization.InitTracer(c.cfg.OTel, "scheduler")
+	if err != nil {
+		return err
+	}
+
+	c.meter, err = initialization.InitMeter(c.cfg.OTel, "scheduler")
+	if err != nil {
+		return err
+	}
+
 	c.DB, err = initialization.InitDatabase(c.cfg.PostgreSQL)
 	if err != nil {
 		return err
 	}
 
+	c.cache, err = initialization.InitRedis(c.cfg.Redis)
+	if err != nil {
+		return err
+	}
+
+	if err = c.initStorage(); err != nil {
+		return err
+	}
+
 	c.TokenRepo = orm.NewTokenRepository(c.DB.Gorm)
+	c.FileRepo = orm.NewFileRepository(c.DB.Gorm)
+	c.MultipartUploadRepo = orm.NewMultipartUploadRepository(c.DB.Gorm)
+	c.UploadQuota = fileUC.NewUploadQuota(c.cfg.Upload, c.Log, redisPro.NewCacheProvider(c.cache))
 
 	return nil
 }
 
 func (c *Container) Cleanup() {
+	if c.realtimeHub != nil {
+		c.realtimeHub.Close()
+	}
+	if c.SMTPPro != nil {
+		c.SMTPPro.Close()
+	}
+	if c.tracer != nil {
+		c.tracer.Close()
+	}
+	if c.meter != nil {
+		c.meter.Close()
+	}
 	if c.DB != nil {
 		c.DB.Close()
 	}
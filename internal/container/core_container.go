@@ -1,11 +1,18 @@
 package container
 
 import (
+	"context"
+
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/initialization"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/jwt"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/local"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/oidc"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/rabbitmq"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/redis"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/s3"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/smtp"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/realtime"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
 )
 
 func (c *Container) initCore() (err error) {
@@ -14,6 +21,11 @@ func (c *Container) initCore() (err error) {
 		return err
 	}
 
+	c.tracer, err = initialization.InitTracer(c.cfg.OTel, "server")
+	if err != nil {
+		return err
+	}
+
 	c.DB, err = initialization.InitDatabase(c.cfg.PostgreSQL)
 	if err != nil {
 		return err
@@ -24,8 +36,7 @@ func (c *Container) initCore() (err error) {
 		return err
 	}
 
-	c.stor, err = initialization.InitS3(c.cfg.MinIO)
-	if err != nil {
+	if err = c.initStorage(); err != nil {
 		return err
 	}
 
@@ -41,11 +52,43 @@ func (c *Container) initCore() (err error) {
 
 	c.jwtPro = jwt.NewJWTProvider(c.cfg.JWT)
 
+	c.oidcPro = oidc.NewOIDCProvider(c.cfg.OIDC)
+
 	c.cachePro = redis.NewCacheProvider(c.cache)
 
+	c.realtimeHub = realtime.NewHub(c.cache, c.Log)
+	c.realtimePro = c.realtimeHub
+	if err = c.realtimeHub.Start(context.Background()); err != nil {
+		return err
+	}
+
 	c.MQPro = rabbitmq.NewMessageQueueProvider(c.mq.Conn, c.mq.Chan, c.Log)
 
-	c.SMTPPro = smtp.NewSMTPProvider(c.cfg.SMTPConfig)
+	c.SMTPPro, err = smtp.NewSMTPProvider(c.cfg.SMTPConfig)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Container) initStorage() (err error) {
+	if c.cfg.Storage.Driver == constants.StorageDriverLocal {
+		c.localStor, err = local.NewStorageProvider(c.cfg.Storage)
+		if err != nil {
+			return err
+		}
+
+		c.StorPro = c.localStor
+		return nil
+	}
+
+	c.stor, err = initialization.InitS3(c.cfg.MinIO)
+	if err != nil {
+		return err
+	}
+
+	c.StorPro = s3.NewStorageProvider(c.cfg.MinIO, c.stor)
 
 	return nil
 }
@@ -3,18 +3,41 @@ package container
 import (
 	authUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/auth"
 	departmentUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/department"
+	emailUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/email"
 	fileUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/file"
+	notificationUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/notification"
+	passwordUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/password"
+	propertyUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/property"
+	shiftUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/shift"
 	userUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/user"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/persistence/orm"
+	"github.com/InstaySystem/is_v2-be/pkg/password"
 )
 
 func (c *Container) initLogic() {
 	c.UserRepo = orm.NewUserRepository(c.DB.Gorm)
 	c.TokenRepo = orm.NewTokenRepository(c.DB.Gorm)
 	c.departmentRepo = orm.NewDepartmentRepository(c.DB.Gorm)
+	c.shiftRepo = orm.NewShiftRepository(c.DB.Gorm)
+	c.shiftAssignmentRepo = orm.NewShiftAssignmentRepository(c.DB.Gorm)
+	c.propertyRepo = orm.NewPropertyRepository(c.DB.Gorm)
+	c.passwordHistoryRepo = orm.NewPasswordHistoryRepository(c.DB.Gorm)
+	c.FileRepo = orm.NewFileRepository(c.DB.Gorm)
+	c.MultipartUploadRepo = orm.NewMultipartUploadRepository(c.DB.Gorm)
+	c.notificationRepo = orm.NewNotificationRepository(c.DB.Gorm)
+	c.EmailLogRepo = orm.NewEmailLogRepository(c.DB.Gorm)
+	c.SuppressionRepo = orm.NewEmailSuppressionRepository(c.DB.Gorm)
+	c.roleSettingRepo = orm.NewRoleSettingRepository(c.DB.Gorm)
+	c.identityRepo = orm.NewUserIdentityRepository(c.DB.Gorm)
+	c.impersonationRepo = orm.NewImpersonationSessionRepository(c.DB.Gorm)
 
-	c.fileUC = fileUC.NewFileUseCase(c.cfg.MinIO, c.stor, c.Log)
-	c.authUC = authUC.NewAuthUseCase(c.cfg.JWT, c.DB.Gorm, c.Log, c.IDGen, c.jwtPro, c.cachePro, c.MQPro, c.UserRepo, c.TokenRepo)
-	c.userUC = userUC.NewUserUseCase(c.DB.Gorm, c.Log, c.IDGen, c.cachePro, c.UserRepo, c.departmentRepo, c.TokenRepo)
+	c.passwordUC = passwordUC.NewPasswordUseCase(password.NewPolicy(password.Settings(c.cfg.Password)), c.Log, c.IDGen, c.passwordHistoryRepo)
+	c.emailUC = emailUC.NewEmailUseCase(c.Log, c.MQPro, c.EmailLogRepo, c.SuppressionRepo)
+	c.fileUC = fileUC.NewFileUseCase(c.cfg.Upload, c.Log, c.IDGen, c.StorPro, c.cachePro, c.MQPro, c.FileRepo, c.MultipartUploadRepo)
+	c.authUC = authUC.NewAuthUseCase(c.cfg.JWT, c.cfg.MagicLink, c.cfg.OIDC, c.DB.Gorm, c.Log, c.IDGen, c.jwtPro, c.cachePro, c.MQPro, c.oidcPro, c.UserRepo, c.departmentRepo, c.TokenRepo, c.roleSettingRepo, c.identityRepo, c.impersonationRepo, c.passwordUC, c.fileUC, c.emailUC, c.realtimePro)
+	c.userUC = userUC.NewUserUseCase(c.DB.Gorm, c.Log, c.IDGen, c.cachePro, c.UserRepo, c.departmentRepo, c.TokenRepo, c.passwordUC, c.fileUC, c.emailUC, c.realtimePro)
 	c.departmentUC = departmentUC.NewDepartmentUseCase(c.Log, c.IDGen, c.departmentRepo)
+	c.propertyUC = propertyUC.NewPropertyUseCase(c.Log, c.IDGen, c.propertyRepo)
+	c.shiftUC = shiftUC.NewShiftUseCase(c.DB.Gorm, c.Log, c.IDGen, c.departmentRepo, c.UserRepo, c.shiftRepo, c.shiftAssignmentRepo, c.fileUC)
+	c.notificationUC = notificationUC.NewNotificationUseCase(c.Log, c.IDGen, c.MQPro, c.realtimePro, c.UserRepo, c.notificationRepo)
 }
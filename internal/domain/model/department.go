@@ -4,8 +4,9 @@ import "time"
 
 type Department struct {
 	ID          int64     `gorm:"type:bigint;primaryKey" json:"id"`
-	Name        string    `gorm:"type:varchar(150);not null;uniqueIndex:departments_name_key" json:"name"`
-	Phone       string    `gorm:"type:char(20);not null;uniqueIndex:departments_phone_key" json:"phone"`
+	PropertyID  *int64    `gorm:"type:bigint;not null;uniqueIndex:departments_property_name_key,priority:1;uniqueIndex:departments_property_phone_key,priority:1" json:"property_id"`
+	Name        string    `gorm:"type:varchar(150);not null;uniqueIndex:departments_property_name_key,priority:2" json:"name"`
+	Phone       string    `gorm:"type:char(20);not null;uniqueIndex:departments_property_phone_key,priority:2" json:"phone"`
 	Description string    `gorm:"type:text;not null" json:"description"`
 	IsActive    bool      `gorm:"type:boolean;not null" json:"is_active"`
 	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
@@ -13,7 +14,8 @@ type Department struct {
 	CreatedByID *int64    `gorm:"type:bigint" json:"created_by_id"`
 	UpdatedByID *int64    `gorm:"type:bigint" json:"updated_by_id"`
 
-	CreatedBy *User   `gorm:"foreignKey:CreatedByID;references:ID;constraint:-" json:"created_by"`
-	UpdatedBy *User   `gorm:"foreignKey:UpdatedByID;references:ID;constraint:-" json:"updated_by"`
-	Users     []*User `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_users_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"users"`
+	Property  *Property `gorm:"foreignKey:PropertyID;references:ID;constraint:fk_departments_property,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"property"`
+	CreatedBy *User     `gorm:"foreignKey:CreatedByID;references:ID;constraint:-" json:"created_by"`
+	UpdatedBy *User     `gorm:"foreignKey:UpdatedByID;references:ID;constraint:-" json:"updated_by"`
+	Users     []*User   `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_users_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"users"`
 }
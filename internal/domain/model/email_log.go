@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type EmailStatus string
+
+const (
+	EmailStatusSent       EmailStatus = "sent"
+	EmailStatusRetrying   EmailStatus = "retrying"
+	EmailStatusFailed     EmailStatus = "failed"
+	EmailStatusBounced    EmailStatus = "bounced"
+	EmailStatusSuppressed EmailStatus = "suppressed"
+)
+
+type EmailLog struct {
+	ID        int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	MessageID string `gorm:"type:varchar(64);not null;uniqueIndex:email_logs_message_id_key" json:"message_id"`
+	Recipient string `gorm:"type:varchar(500);not null;index:email_logs_recipient_idx" json:"recipient"`
+	// PropertyID is the property the email was sent for; mail sent outside
+	// any property is only visible chain-wide.
+	PropertyID *int64      `gorm:"type:bigint;index:email_logs_property_id_idx" json:"property_id"`
+	Template   string      `gorm:"type:varchar(50);not null" json:"template"`
+	Status     EmailStatus `gorm:"type:varchar(20);not null;check:email_logs_status_check,status IN ('sent', 'retrying', 'failed', 'bounced', 'suppressed')" json:"status"`
+	Attempts   int         `gorm:"type:integer;not null" json:"attempts"`
+	Response   string      `gorm:"type:text;not null" json:"response"`
+	SentAt     *time.Time  `json:"sent_at"`
+	CreatedAt  time.Time   `gorm:"autoCreateTime;index:email_logs_created_at_idx" json:"created_at"`
+	UpdatedAt  time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type EmailSuppression struct {
+	ID    int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	Email string `gorm:"type:varchar(150);not null;uniqueIndex:email_suppressions_email_key" json:"email"`
+	// PropertyID only decides who may see and lift the suppression; the
+	// address stays suppressed for every property.
+	PropertyID *int64    `gorm:"type:bigint;index:email_suppressions_property_id_idx" json:"property_id"`
+	Reason     string    `gorm:"type:text;not null" json:"reason"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+type FileStatus string
+
+const (
+	FileStatusPending   FileStatus = "pending"
+	FileStatusConfirmed FileStatus = "confirmed"
+)
+
+type FilePurpose string
+
+const (
+	FilePurposeAvatar   FilePurpose = "avatar"
+	FilePurposeImage    FilePurpose = "image"
+	FilePurposeDocument FilePurpose = "document"
+	FilePurposeVideo    FilePurpose = "video"
+)
+
+const FileEntityUser = "user"
+
+type File struct {
+	ID           int64       `gorm:"type:bigint;primaryKey" json:"id"`
+	Key          string      `gorm:"type:varchar(255);not null;uniqueIndex:files_key_key" json:"key"`
+	OriginalName string      `gorm:"type:varchar(255);not null" json:"original_name"`
+	ContentType  string      `gorm:"type:varchar(100);not null" json:"content_type"`
+	Purpose      FilePurpose `gorm:"type:varchar(20);not null;check:files_purpose_check,purpose IN ('avatar', 'image', 'document', 'video')" json:"purpose"`
+	Size         int64       `gorm:"type:bigint;not null;default:0" json:"size"`
+	Checksum     string      `gorm:"type:varchar(100);not null;default:''" json:"checksum"`
+	Status       FileStatus  `gorm:"type:varchar(20);not null;default:pending;check:status IN ('pending', 'confirmed');index:files_status_created_at_idx,priority:1" json:"status"`
+	EntityType   *string     `gorm:"type:varchar(50);index:files_entity_type_entity_id_idx,priority:1" json:"entity_type"`
+	EntityID     *int64      `gorm:"type:bigint;index:files_entity_type_entity_id_idx,priority:2" json:"entity_id"`
+	UploadedByID *int64      `gorm:"type:bigint;index" json:"uploaded_by_id"`
+	PropertyID   *int64      `gorm:"type:bigint;index:files_property_id_idx" json:"property_id"`
+	ConfirmedAt  *time.Time  `json:"confirmed_at"`
+	CreatedAt    time.Time   `gorm:"autoCreateTime;index:files_status_created_at_idx,priority:2" json:"created_at"`
+	UpdatedAt    time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+
+	UploadedBy *User          `gorm:"foreignKey:UploadedByID;references:ID;constraint:fk_files_uploaded_by,OnUpdate:CASCADE,OnDelete:SET NULL" json:"uploaded_by"`
+	Variants   []*FileVariant `gorm:"foreignKey:FileID;references:ID;constraint:fk_file_variants_file,OnUpdate:CASCADE,OnDelete:CASCADE" json:"variants"`
+}
+
+func (f *File) IsLinked() bool {
+	return f.EntityType != nil && f.EntityID != nil
+}
+
+func (f *File) IsProcessableImage() bool {
+	if f.Purpose != FilePurposeAvatar && f.Purpose != FilePurposeImage {
+		return false
+	}
+
+	switch f.ContentType {
+	case "image/jpeg", "image/png", "image/webp":
+		return true
+	}
+	return false
+}
+
+// FindVariant returns the variant of the given size, in WebP when format is
+// "webp" and in the original image format otherwise.
+func (f *File) FindVariant(size FileVariantSize, format string) *FileVariant {
+	webp := format == "webp"
+	for _, v := range f.Variants {
+		if v.Size == size && (v.Format == "webp") == webp {
+			return v
+		}
+	}
+	return nil
+}
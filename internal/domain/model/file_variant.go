@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+type FileVariantSize string
+
+const (
+	FileVariantThumbnail FileVariantSize = "thumbnail"
+	FileVariantMedium    FileVariantSize = "medium"
+	FileVariantLarge     FileVariantSize = "large"
+)
+
+type FileVariant struct {
+	ID          int64           `gorm:"type:bigint;primaryKey" json:"id"`
+	FileID      int64           `gorm:"type:bigint;not null;uniqueIndex:file_variants_file_id_size_format_key,priority:1" json:"file_id"`
+	Size        FileVariantSize `gorm:"type:varchar(20);not null;uniqueIndex:file_variants_file_id_size_format_key,priority:2" json:"size"`
+	Format      string          `gorm:"type:varchar(10);not null;uniqueIndex:file_variants_file_id_size_format_key,priority:3" json:"format"`
+	Key         string          `gorm:"type:varchar(255);not null;uniqueIndex:file_variants_key_key" json:"key"`
+	ContentType string          `gorm:"type:varchar(100);not null" json:"content_type"`
+	Width       int             `gorm:"type:integer;not null" json:"width"`
+	Height      int             `gorm:"type:integer;not null" json:"height"`
+	Bytes       int64           `gorm:"type:bigint;not null" json:"bytes"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
+
+	File *File `gorm:"foreignKey:FileID;references:ID;constraint:fk_file_variants_file,OnUpdate:CASCADE,OnDelete:CASCADE" json:"file"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// ImpersonationSession is the audit record of an admin acting as another
+// user. EndedAt stays nil when the session simply expired.
+type ImpersonationSession struct {
+	ID      int64 `gorm:"type:bigint;primaryKey" json:"id"`
+	AdminID int64 `gorm:"type:bigint;not null;index:impersonation_sessions_admin_id_idx" json:"admin_id"`
+	UserID  int64 `gorm:"type:bigint;not null;index:impersonation_sessions_user_id_idx" json:"user_id"`
+	// PropertyID is the impersonated user's property, so each property only
+	// sees its own sessions.
+	PropertyID *int64     `gorm:"type:bigint;index:impersonation_sessions_property_id_idx" json:"property_id"`
+	Reason     string     `gorm:"type:text;not null" json:"reason"`
+	IPAddress  string     `gorm:"type:varchar(45);not null" json:"ip_address"`
+	UserAgent  string     `gorm:"type:varchar(255);not null" json:"user_agent"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	EndedAt    *time.Time `json:"ended_at"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime;index:impersonation_sessions_created_at_idx" json:"created_at"`
+
+	Admin *User `gorm:"foreignKey:AdminID;references:ID;constraint:fk_impersonation_sessions_admin,OnUpdate:CASCADE,OnDelete:CASCADE" json:"admin"`
+	User  *User `gorm:"foreignKey:UserID;references:ID;constraint:fk_impersonation_sessions_user,OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type MultipartUpload struct {
+	ID         int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	FileID     int64     `gorm:"type:bigint;not null;uniqueIndex:multipart_uploads_file_id_key" json:"file_id"`
+	PropertyID *int64    `gorm:"type:bigint;index:multipart_uploads_property_id_idx" json:"property_id"`
+	UploadID   string    `gorm:"type:varchar(255);not null" json:"upload_id"`
+	PartSize   int64     `gorm:"type:bigint;not null" json:"part_size"`
+	PartCount  int32     `gorm:"type:integer;not null" json:"part_count"`
+	ExpiresAt  time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	File *File `gorm:"foreignKey:FileID;references:ID;constraint:fk_multipart_uploads_file,OnUpdate:CASCADE,OnDelete:CASCADE" json:"file"`
+}
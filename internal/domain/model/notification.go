@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type Notification struct {
+	ID          int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	RecipientID int64      `gorm:"type:bigint;not null;index:notifications_recipient_id_read_at_idx,priority:1" json:"recipient_id"`
+	Type        string     `gorm:"type:varchar(50);not null" json:"type"`
+	Title       string     `gorm:"type:varchar(255);not null" json:"title"`
+	Body        string     `gorm:"type:text;not null" json:"body"`
+	Link        *string    `gorm:"type:varchar(500)" json:"link"`
+	ReadAt      *time.Time `gorm:"index:notifications_recipient_id_read_at_idx,priority:2" json:"read_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	Recipient *User `gorm:"foreignKey:RecipientID;references:ID;constraint:fk_notifications_recipient,OnUpdate:CASCADE,OnDelete:CASCADE" json:"recipient"`
+}
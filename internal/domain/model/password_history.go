@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type PasswordHistory struct {
+	ID        int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	UserID    int64     `gorm:"type:bigint;not null;index:password_histories_user_id_created_at_idx,priority:1" json:"user_id"`
+	Password  string    `gorm:"type:varchar(255);not null" json:"password"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index:password_histories_user_id_created_at_idx,priority:2" json:"created_at"`
+
+	User *User `gorm:"foreignKey:UserID;references:ID;constraint:fk_password_histories_user,OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Property is one hotel of the chain. Code doubles as the subdomain the
+// property is served from.
+type Property struct {
+	ID          int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	Code        string    `gorm:"type:varchar(50);not null;uniqueIndex:properties_code_key" json:"code"`
+	Name        string    `gorm:"type:varchar(150);not null" json:"name"`
+	Address     string    `gorm:"type:text;not null" json:"address"`
+	IsActive    bool      `gorm:"type:boolean;not null" json:"is_active"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedByID *int64    `gorm:"type:bigint" json:"created_by_id"`
+	UpdatedByID *int64    `gorm:"type:bigint" json:"updated_by_id"`
+
+	CreatedBy   *User         `gorm:"foreignKey:CreatedByID;references:ID;constraint:-" json:"created_by"`
+	UpdatedBy   *User         `gorm:"foreignKey:UpdatedByID;references:ID;constraint:-" json:"updated_by"`
+	Departments []*Department `gorm:"foreignKey:PropertyID;references:ID;constraint:fk_departments_property,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"departments"`
+	Users       []*User       `gorm:"foreignKey:PropertyID;references:ID;constraint:fk_users_property,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"users"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// RoleSetting holds per-role authentication options. A role without a row
+// uses the zero value, so every option is opt-in.
+type RoleSetting struct {
+	Role             UserRole  `gorm:"type:varchar(20);primaryKey;check:role_settings_role_check,role IN ('staff', 'admin', 'chain_admin')" json:"role"`
+	MagicLinkEnabled bool      `gorm:"type:boolean;not null;default:false" json:"magic_link_enabled"`
+	UpdatedByID      *int64    `gorm:"type:bigint" json:"updated_by_id"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	UpdatedBy *User `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_role_settings_updated_by,OnUpdate:CASCADE,OnDelete:SET NULL" json:"updated_by"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type ShiftRole string
+
+const (
+	ShiftRoleLead   ShiftRole = "lead"
+	ShiftRoleMember ShiftRole = "member"
+)
+
+type Shift struct {
+	ID           int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	PropertyID   *int64    `gorm:"type:bigint;index:shifts_property_id_idx" json:"property_id"`
+	DepartmentID int64     `gorm:"type:bigint;not null;index:shifts_department_starts_at_idx,priority:1" json:"department_id"`
+	Name         string    `gorm:"type:varchar(100);not null" json:"name"`
+	StartsAt     time.Time `gorm:"not null;index:shifts_department_starts_at_idx,priority:2" json:"starts_at"`
+	EndsAt       time.Time `gorm:"not null;check:shifts_time_check,ends_at > starts_at" json:"ends_at"`
+	Note         string    `gorm:"type:text;not null" json:"note"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedByID  *int64    `gorm:"type:bigint" json:"created_by_id"`
+	UpdatedByID  *int64    `gorm:"type:bigint" json:"updated_by_id"`
+
+	Property    *Property          `gorm:"foreignKey:PropertyID;references:ID;constraint:fk_shifts_property,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"property"`
+	Department  *Department        `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_shifts_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"department"`
+	CreatedBy   *User              `gorm:"foreignKey:CreatedByID;references:ID;constraint:-" json:"created_by"`
+	UpdatedBy   *User              `gorm:"foreignKey:UpdatedByID;references:ID;constraint:-" json:"updated_by"`
+	Assignments []*ShiftAssignment `gorm:"foreignKey:ShiftID;references:ID;constraint:fk_shift_assignments_shift,OnUpdate:CASCADE,OnDelete:CASCADE" json:"assignments"`
+}
+
+type ShiftAssignment struct {
+	ID          int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	PropertyID  *int64    `gorm:"type:bigint;index:shift_assignments_property_id_idx" json:"property_id"`
+	ShiftID     int64     `gorm:"type:bigint;not null;uniqueIndex:shift_assignments_shift_user_key,priority:1" json:"shift_id"`
+	UserID      int64     `gorm:"type:bigint;not null;uniqueIndex:shift_assignments_shift_user_key,priority:2;index:shift_assignments_user_id_idx" json:"user_id"`
+	Role        ShiftRole `gorm:"type:varchar(20);not null;check:shift_assignments_role_check,role IN ('lead', 'member')" json:"role"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	CreatedByID *int64    `gorm:"type:bigint" json:"created_by_id"`
+
+	Shift     *Shift `gorm:"foreignKey:ShiftID;references:ID;constraint:fk_shift_assignments_shift,OnUpdate:CASCADE,OnDelete:CASCADE" json:"shift"`
+	User      *User  `gorm:"foreignKey:UserID;references:ID;constraint:fk_shift_assignments_user,OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+	CreatedBy *User  `gorm:"foreignKey:CreatedByID;references:ID;constraint:-" json:"created_by"`
+}
@@ -7,28 +7,41 @@ type UserRole string
 const (
 	RoleStaff UserRole = "staff"
 	RoleAdmin UserRole = "admin"
+	// RoleChainAdmin administers every property of the chain.
+	RoleChainAdmin UserRole = "chain_admin"
 )
 
 type User struct {
-	ID           int64     `gorm:"type:bigint;primaryKey" json:"id"`
-	Username     string    `gorm:"type:varchar(50);not null;uniqueIndex:users_username_key" json:"username"`
-	Email        string    `gorm:"type:varchar(150);not null;uniqueIndex:users_email_key" json:"email"`
-	Role         UserRole  `gorm:"type:varchar(20);check:role IN ('staff', 'admin')" json:"role"`
-	FirstName    string    `gorm:"type:varchar(150);not null" json:"first_name"`
-	LastName     string    `gorm:"type:varchar(150);not null" json:"last_name"`
-	Phone        string    `gorm:"type:char(10);not null;uniqueIndex:users_phone_key" json:"phone"`
-	Password     string    `gorm:"type:varchar(255);not null" json:"password"`
-	IsActive     bool      `gorm:"type:boolean;not null" json:"is_active"`
-	DepartmentID *int64    `gorm:"type:bigint" json:"department_id"`
-	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	CreatedByID  *int64    `gorm:"type:bigint" json:"created_by_id"`
-	UpdatedByID  *int64    `gorm:"type:bigint" json:"updated_by_id"`
-
-	Department *Department `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_users_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"department"`
-	CreatedBy  *User       `gorm:"foreignKey:CreatedByID;references:ID;constraint:-" json:"created_by"`
-	UpdatedBy  *User       `gorm:"foreignKey:UpdatedByID;references:ID;constraint:-" json:"updated_by"`
-	Tokens     []*Token    `gorm:"foreignKey:UserID;references:ID;constraint:fk_tokens_user,OnUpdate:CASCADE,OnDelete:CASCADE" json:"tokens"`
+	ID                 int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	Username           string     `gorm:"type:varchar(50);not null;uniqueIndex:users_username_key" json:"username"`
+	Email              string     `gorm:"type:varchar(150);not null;uniqueIndex:users_email_key" json:"email"`
+	Role               UserRole   `gorm:"type:varchar(20);check:users_role_check,role IN ('staff', 'admin', 'chain_admin')" json:"role"`
+	FirstName          string     `gorm:"type:varchar(150);not null" json:"first_name"`
+	LastName           string     `gorm:"type:varchar(150);not null" json:"last_name"`
+	Phone              string     `gorm:"type:char(10);not null;uniqueIndex:users_phone_key" json:"phone"`
+	Password           string     `gorm:"type:varchar(255);not null" json:"password"`
+	PasswordChangedAt  *time.Time `json:"password_changed_at"`
+	IsActive           bool       `gorm:"type:boolean;not null" json:"is_active"`
+	Locale             string     `gorm:"type:varchar(10);not null;default:vi" json:"locale"`
+	AvatarKey          *string    `gorm:"type:varchar(255)" json:"avatar_key"`
+	EmailNotifications bool       `gorm:"type:boolean;not null;default:false" json:"email_notifications"`
+	DepartmentID       *int64     `gorm:"type:bigint" json:"department_id"`
+	PropertyID         *int64     `gorm:"type:bigint;index:users_property_id_idx" json:"property_id"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedByID        *int64     `gorm:"type:bigint" json:"created_by_id"`
+	UpdatedByID        *int64     `gorm:"type:bigint" json:"updated_by_id"`
+
+	Property      *Property          `gorm:"foreignKey:PropertyID;references:ID;constraint:fk_users_property,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"property"`
+	Department    *Department        `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_users_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"department"`
+	CreatedBy     *User              `gorm:"foreignKey:CreatedByID;references:ID;constraint:-" json:"created_by"`
+	UpdatedBy     *User              `gorm:"foreignKey:UpdatedByID;references:ID;constraint:-" json:"updated_by"`
+	Tokens        []*Token           `gorm:"foreignKey:UserID;references:ID;constraint:fk_tokens_user,OnUpdate:CASCADE,OnDelete:CASCADE" json:"tokens"`
+	Passwords     []*PasswordHistory `gorm:"foreignKey:UserID;references:ID;constraint:fk_password_histories_user,OnUpdate:CASCADE,OnDelete:CASCADE" json:"passwords"`
+	Notifications []*Notification    `gorm:"foreignKey:RecipientID;references:ID;constraint:fk_notifications_recipient,OnUpdate:CASCADE,OnDelete:CASCADE" json:"notifications"`
+
+	// AvatarURL is resolved from AvatarKey per request and never persisted.
+	AvatarURL string `gorm:"-" json:"-"`
 }
 
 func IsValidRole(role UserRole) bool {
@@ -37,7 +50,24 @@ func IsValidRole(role UserRole) bool {
 		return true
 	case RoleStaff:
 		return true
+	case RoleChainAdmin:
+		return true
 	default:
 		return false
 	}
 }
+
+// AssignedPropertyID returns the user's property, or 0 for users not bound
+// to one.
+func (u *User) AssignedPropertyID() int64 {
+	if u.PropertyID == nil {
+		return 0
+	}
+	return *u.PropertyID
+}
+
+// IsAdmin reports whether the role has admin rights; a chain admin is an
+// admin of every property.
+func (r UserRole) IsAdmin() bool {
+	return r == RoleAdmin || r == RoleChainAdmin
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// UserIdentity links an account at an external OpenID Connect issuer to a
+// local user. An issuer/subject pair maps to at most one user.
+type UserIdentity struct {
+	ID          int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	UserID      int64      `gorm:"type:bigint;not null;index:user_identities_user_id_idx" json:"user_id"`
+	Issuer      string     `gorm:"type:varchar(255);not null;uniqueIndex:user_identities_issuer_subject_key,priority:1" json:"issuer"`
+	Subject     string     `gorm:"type:varchar(255);not null;uniqueIndex:user_identities_issuer_subject_key,priority:2" json:"subject"`
+	Email       string     `gorm:"type:varchar(150);not null" json:"email"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	User *User `gorm:"foreignKey:UserID;references:ID;constraint:fk_user_identities_user,OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type EmailLogRepository interface {
+	Create(ctx context.Context, emailLog *model.EmailLog) error
+
+	FindByMessageID(ctx context.Context, messageID string) (*model.EmailLog, error)
+
+	Update(ctx context.Context, id int64, updateData map[string]any) error
+
+	FindAllPaginated(ctx context.Context, query dto.EmailLogPaginationQuery) ([]*model.EmailLog, int64, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type EmailSuppressionRepository interface {
+	Create(ctx context.Context, suppression *model.EmailSuppression) error
+
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+
+	FindAllEmailsByEmails(ctx context.Context, emails []string) ([]string, error)
+
+	FindAllPaginated(ctx context.Context, query dto.EmailSuppressionPaginationQuery) ([]*model.EmailSuppression, int64, error)
+
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type FileRepository interface {
+	CreateAll(ctx context.Context, files []*model.File) error
+
+	FindAllByKeys(ctx context.Context, keys []string) ([]*model.File, error)
+
+	FindAllByKeysWithVariants(ctx context.Context, keys []string) ([]*model.File, error)
+
+	Update(ctx context.Context, id int64, updateData map[string]any) error
+
+	UpdateTx(tx *gorm.DB, id int64, updateData map[string]any) error
+
+	UpdateAllByEntityTx(tx *gorm.DB, entityType string, entityID int64, purpose model.FilePurpose, updateData map[string]any) error
+
+	FindAllUnlinkedBefore(ctx context.Context, before time.Time, afterID int64, limit int) ([]*model.File, error)
+
+	DeleteAllByIDs(ctx context.Context, ids []int64) (int64, error)
+}
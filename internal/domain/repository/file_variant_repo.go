@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type FileVariantRepository interface {
+	CreateAll(ctx context.Context, variants []*model.FileVariant) error
+}
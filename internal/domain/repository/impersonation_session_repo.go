@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type ImpersonationSessionRepository interface {
+	Create(ctx context.Context, session *model.ImpersonationSession) error
+
+	FindByID(ctx context.Context, id int64) (*model.ImpersonationSession, error)
+
+	Update(ctx context.Context, id int64, updateData map[string]any) error
+
+	FindAllPaginated(ctx context.Context, query dto.ImpersonationSessionPaginationQuery) ([]*model.ImpersonationSession, int64, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type MultipartUploadRepository interface {
+	Create(ctx context.Context, upload *model.MultipartUpload) error
+
+	FindByFileKeyWithFile(ctx context.Context, key string) (*model.MultipartUpload, error)
+
+	FindAllByUploaderIDWithFile(ctx context.Context, userID int64) ([]*model.MultipartUpload, error)
+
+	FindAllExpiredWithFile(ctx context.Context, before time.Time, afterID int64, limit int) ([]*model.MultipartUpload, error)
+
+	Delete(ctx context.Context, id int64) error
+}
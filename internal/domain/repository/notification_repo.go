@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type NotificationRepository interface {
+	CreateAll(ctx context.Context, notifications []*model.Notification) error
+
+	FindAllByRecipientIDPaginated(ctx context.Context, recipientID int64, query dto.NotificationPaginationQuery) ([]*model.Notification, int64, error)
+
+	CountUnreadByRecipientID(ctx context.Context, recipientID int64) (int64, error)
+
+	MarkReadByIDAndRecipientID(ctx context.Context, id, recipientID int64) error
+
+	MarkAllReadByRecipientID(ctx context.Context, recipientID int64) (int64, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type PasswordHistoryRepository interface {
+	CreateTx(tx *gorm.DB, history *model.PasswordHistory) error
+
+	FindRecentByUserID(ctx context.Context, userID int64, limit int) ([]*model.PasswordHistory, error)
+
+	DeleteAllExceptRecentTx(tx *gorm.DB, userID int64, keep int) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type PropertyRepository interface {
+	Create(ctx context.Context, property *model.Property) error
+
+	FindByID(ctx context.Context, id int64) (*model.Property, error)
+
+	FindByCode(ctx context.Context, code string) (*model.Property, error)
+
+	FindAll(ctx context.Context) ([]*model.Property, error)
+
+	Update(ctx context.Context, id int64, updateData map[string]any) error
+}
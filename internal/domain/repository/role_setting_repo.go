@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+)
+
+type RoleSettingRepository interface {
+	FindAll(ctx context.Context) ([]*model.RoleSetting, error)
+
+	FindByRole(ctx context.Context, role model.UserRole) (*model.RoleSetting, error)
+
+	Upsert(ctx context.Context, setting *model.RoleSetting) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type ShiftAssignmentRepository interface {
+	CreateTx(tx *gorm.DB, assignment *model.ShiftAssignment) error
+
+	FindAllByShiftIDTx(tx *gorm.DB, shiftID int64) ([]*model.ShiftAssignment, error)
+
+	// FindAllOverlappingTx returns, with their shift, the assignments of
+	// userIDs on shifts other than excludeShiftID that overlap
+	// [startsAt, endsAt).
+	FindAllOverlappingTx(tx *gorm.DB, userIDs []int64, startsAt, endsAt time.Time, excludeShiftID int64) ([]*model.ShiftAssignment, error)
+
+	FindAllOnDuty(ctx context.Context, departmentID int64, at time.Time) ([]*model.ShiftAssignment, error)
+
+	DeleteByIDAndShiftID(ctx context.Context, id, shiftID int64) error
+}
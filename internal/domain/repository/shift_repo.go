@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type ShiftRepository interface {
+	Create(ctx context.Context, shift *model.Shift) error
+
+	FindByID(ctx context.Context, id int64) (*model.Shift, error)
+
+	FindByIDWithDetails(ctx context.Context, id int64) (*model.Shift, error)
+
+	FindByIDForUpdateTx(tx *gorm.DB, id int64) (*model.Shift, error)
+
+	FindAllPaginated(ctx context.Context, query dto.ShiftPaginationQuery) ([]*model.Shift, int64, error)
+
+	// FindAllWithAssignmentsBetween returns the shifts overlapping [from, to),
+	// ordered by start. A zero departmentID matches every department.
+	FindAllWithAssignmentsBetween(ctx context.Context, departmentID int64, from, to time.Time) ([]*model.Shift, error)
+
+	UpdateTx(tx *gorm.DB, id int64, updateData map[string]any) error
+
+	Delete(ctx context.Context, id int64) error
+}
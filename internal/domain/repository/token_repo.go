@@ -14,6 +14,8 @@ type TokenRepository interface {
 
 	FindByToken(ctx context.Context, token string) (*model.Token, error)
 
+	FindAllUserAgentsByUserID(ctx context.Context, userID int64) ([]string, error)
+
 	UpdateAllByUserIDTx(tx *gorm.DB, userID int64, updateData map[string]any) error
 
 	DeleteAllByUserIDTx(tx *gorm.DB, userID int64) error
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository interface {
+	FindByIssuerAndSubject(ctx context.Context, issuer, subject string) (*model.UserIdentity, error)
+
+	Create(ctx context.Context, identity *model.UserIdentity) error
+
+	CreateTx(tx *gorm.DB, identity *model.UserIdentity) error
+
+	Update(ctx context.Context, id int64, updateData map[string]any) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
 	"github.com/InstaySystem/is_v2-be/internal/domain/model"
@@ -11,6 +12,8 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *model.User) error
 
+	CreateTx(tx *gorm.DB, user *model.User) error
+
 	FindByUsernameWithDepartment(ctx context.Context, username string) (*model.User, error)
 
 	FindByIDWithDepartment(ctx context.Context, id int64) (*model.User, error)
@@ -25,6 +28,8 @@ type UserRepository interface {
 
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
 
+	FindByPhone(ctx context.Context, phone string) (*model.User, error)
+
 	Update(ctx context.Context, id int64, updateData map[string]any) error
 
 	FindAllWithDepartmentPaginated(ctx context.Context, query dto.UserPaginationQuery) ([]*model.User, int64, error)
@@ -33,7 +38,19 @@ type UserRepository interface {
 
 	DeleteTx(tx *gorm.DB, id int64) error
 
-	DeleteAllByIDsTx(tx *gorm.DB, ids []int64) (int64, error)
+	// DeleteAllByIDsTx returns the IDs actually deleted, which excludes users
+	// outside the caller's property.
+	DeleteAllByIDsTx(tx *gorm.DB, ids []int64) ([]int64, error)
 
 	ExistsActiveAdmin(ctx context.Context) (bool, error)
+
+	FindAllActiveByIDs(ctx context.Context, ids []int64) ([]*model.User, error)
+
+	FindAllActiveByDepartmentID(ctx context.Context, departmentID int64) ([]*model.User, error)
+
+	FindAllActiveOnDutyByDepartmentID(ctx context.Context, departmentID int64, at time.Time) ([]*model.User, error)
+
+	// FindAllByIDsForUpdateTx locks the users' rows, in ID order, until tx
+	// ends.
+	FindAllByIDsForUpdateTx(tx *gorm.DB, ids []int64) ([]*model.User, error)
 }
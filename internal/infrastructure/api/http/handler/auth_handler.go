@@ -4,20 +4,29 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
 	authUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/auth"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
 	"github.com/InstaySystem/is_v2-be/pkg/constants"
 	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
 	"github.com/InstaySystem/is_v2-be/pkg/mapper"
 	"github.com/InstaySystem/is_v2-be/pkg/utils"
 	"github.com/InstaySystem/is_v2-be/pkg/validator"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	oidcStateCookieName   = "oidc_state"
+	oidcStateCookieMaxAge = 10 * 60
+)
+
 type AuthHandler struct {
 	cfg    *config.Config
 	authUC authUC.AuthUseCase
@@ -39,11 +48,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	var req dto.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
@@ -61,6 +67,235 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 }
 
+func (h *AuthHandler) RequestMagicLink(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var req dto.MagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	if err := h.authUC.RequestMagicLink(ctx, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeRequestMagicLinkSuccess, "Magic link requested successfully", nil)
+}
+
+func (h *AuthHandler) ConsumeMagicLink(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var req dto.ConsumeMagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	user, accessToken, refreshToken, err := h.authUC.ConsumeMagicLink(ctx, c.Request.UserAgent(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.storeTokenInCookie(c, accessToken, refreshToken, int(h.cfg.JWT.AccessExpiresIn.Seconds()), int(h.cfg.JWT.RefreshExpiresIn.Seconds()))
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeLoginSuccess, "Login successfully", gin.H{
+		"user": mapper.ToUserResponse(user),
+	})
+}
+
+func (h *AuthHandler) OIDCLogin(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	var query dto.OIDCLoginQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	authURL, state, err := h.authUC.StartOIDCLogin(ctx, query.Redirect)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.storeOIDCStateInCookie(c, state, oidcStateCookieMaxAge)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback is reached by a browser redirect, so failures are reported by
+// sending the user back to the frontend with an error code instead of JSON.
+func (h *AuthHandler) OIDCCallback(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	stateCookie, _ := c.Cookie(oidcStateCookieName)
+	h.storeOIDCStateInCookie(c, "", -1)
+
+	var query dto.OIDCCallbackQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Redirect(http.StatusFound, h.oidcErrorURL(errors.ErrOIDCLoginFailed))
+		return
+	}
+
+	_, accessToken, refreshToken, redirect, err := h.authUC.CompleteOIDCLogin(ctx, c.Request.UserAgent(), stateCookie, query)
+	if err != nil {
+		c.Redirect(http.StatusFound, h.oidcErrorURL(err))
+		return
+	}
+
+	h.storeTokenInCookie(c, accessToken, refreshToken, int(h.cfg.JWT.AccessExpiresIn.Seconds()), int(h.cfg.JWT.RefreshExpiresIn.Seconds()))
+
+	c.Redirect(http.StatusFound, strings.TrimRight(h.cfg.OIDC.FrontendURL, "/")+redirect)
+}
+
+func (h *AuthHandler) StartImpersonation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	adminID := c.GetInt64(middleware.CtxUserID)
+	if adminID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	var req dto.StartImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	user, accessToken, ttl, err := h.authUC.StartImpersonation(ctx, adminID, userID, c.ClientIP(), c.Request.UserAgent(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Only the access cookie is replaced; the admin's refresh cookie is
+	// needed to end the impersonation.
+	h.storeAccessTokenInCookie(c, accessToken, int(ttl.Seconds()))
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeStartImpersonationSuccess, "Start impersonation successfully", gin.H{
+		"user": mapper.ToUserResponse(user),
+	})
+}
+
+func (h *AuthHandler) StopImpersonation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	adminID := c.GetInt64(middleware.CtxImpersonatorID)
+	sessionID := c.GetInt64(middleware.CtxImpersonationID)
+	if adminID == 0 || sessionID == 0 {
+		c.Error(errors.ErrNotImpersonating)
+		return
+	}
+
+	accessToken := c.GetString(middleware.CtxAccessToken)
+	refreshToken := c.GetString(middleware.CtxRefreshToken)
+	accessTTL := c.GetDuration(middleware.CtxAccessTTL)
+
+	admin, newAccessToken, newRefreshToken, err := h.authUC.StopImpersonation(ctx, c.Request.UserAgent(), adminID, sessionID, accessToken, refreshToken, accessTTL)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.storeTokenInCookie(c, newAccessToken, newRefreshToken, int(h.cfg.JWT.AccessExpiresIn.Seconds()), int(h.cfg.JWT.RefreshExpiresIn.Seconds()))
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeStopImpersonationSuccess, "Stop impersonation successfully", gin.H{
+		"user": mapper.ToUserResponse(admin),
+	})
+}
+
+func (h *AuthHandler) GetImpersonationSessions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query dto.ImpersonationSessionPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	sessions, meta, err := h.authUC.GetImpersonationSessions(ctx, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"impersonation_sessions": mapper.ToImpersonationSessionsResponse(sessions),
+		"meta":                   meta,
+	})
+}
+
+func (h *AuthHandler) GetRoleSettings(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	settings, err := h.authUC.GetRoleSettings(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"role_settings": mapper.ToRoleSettingsResponse(settings),
+	})
+}
+
+func (h *AuthHandler) UpdateRoleSetting(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	var req dto.UpdateRoleSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	setting, err := h.authUC.UpdateRoleSetting(ctx, userID, model.UserRole(c.Param("role")), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeUpdateRoleSettingSuccess, "Role setting updated successfully", gin.H{
+		"role_setting": mapper.ToRoleSettingResponse(setting),
+	})
+}
+
 func (h *AuthHandler) Logout(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
@@ -136,9 +371,18 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 		return
 	}
 
-	utils.OKResponse(c, gin.H{
+	res := gin.H{
 		"user": mapper.ToUserResponse(user),
-	})
+	}
+	if impersonatorID := c.GetInt64(middleware.CtxImpersonatorID); impersonatorID != 0 {
+		res["impersonation"] = &dto.ImpersonationResponse{
+			SessionID:      c.GetInt64(middleware.CtxImpersonationID),
+			ImpersonatorID: impersonatorID,
+			ExpiresAt:      time.Now().Add(c.GetDuration(middleware.CtxAccessTTL)),
+		}
+	}
+
+	utils.OKResponse(c, res)
 }
 
 func (h *AuthHandler) ChangePassword(c *gin.Context) {
@@ -153,11 +397,8 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 
 	var req dto.ChangePasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
@@ -178,16 +419,13 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 
 	var req dto.ForgotPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
 
-	forgotPasswordToken, err := h.authUC.ForgotPassword(ctx, req.Email)
+	forgotPasswordToken, err := h.authUC.ForgotPassword(ctx, req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -204,11 +442,8 @@ func (h *AuthHandler) VerifyForgotPassword(c *gin.Context) {
 
 	var req dto.VerifyForgotPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
@@ -230,11 +465,8 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 
 	var req dto.ResetPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
@@ -259,11 +491,8 @@ func (h *AuthHandler) UpdateInfo(c *gin.Context) {
 
 	var req dto.UpdateInfoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
@@ -283,6 +512,22 @@ func (h *AuthHandler) storeTokenInCookie(c *gin.Context, accessToken, refreshTok
 	isSecure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
 	domain := utils.ExtractRootDomain(c.Request.Host)
 
+	h.storeAccessTokenInCookie(c, accessToken, accessExpiresIn)
+	c.SetCookie(
+		h.cfg.JWT.RefreshName,
+		refreshToken,
+		refreshExpiresIn,
+		fmt.Sprintf("%s/auth", h.cfg.Server.APIPrefix),
+		domain,
+		isSecure,
+		true,
+	)
+}
+
+func (h *AuthHandler) storeAccessTokenInCookie(c *gin.Context, accessToken string, accessExpiresIn int) {
+	isSecure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	domain := utils.ExtractRootDomain(c.Request.Host)
+
 	c.SetSameSite(http.SameSiteLaxMode)
 	c.SetCookie(
 		h.cfg.JWT.AccessName,
@@ -293,13 +538,28 @@ func (h *AuthHandler) storeTokenInCookie(c *gin.Context, accessToken, refreshTok
 		isSecure,
 		true,
 	)
+}
+
+func (h *AuthHandler) storeOIDCStateInCookie(c *gin.Context, state string, maxAge int) {
+	isSecure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+
+	c.SetSameSite(http.SameSiteLaxMode)
 	c.SetCookie(
-		h.cfg.JWT.RefreshName,
-		refreshToken,
-		refreshExpiresIn,
-		fmt.Sprintf("%s/auth", h.cfg.Server.APIPrefix),
-		domain,
+		oidcStateCookieName,
+		state,
+		maxAge,
+		fmt.Sprintf("%s/auth/oidc", h.cfg.Server.APIPrefix),
+		"",
 		isSecure,
 		true,
 	)
 }
+
+func (h *AuthHandler) oidcErrorURL(err error) string {
+	code := constants.CodeInternalError
+	if apiErr, ok := err.(*errors.APIError); ok {
+		code = apiErr.Code
+	}
+
+	return fmt.Sprintf("%s/?error=%d", strings.TrimRight(h.cfg.OIDC.FrontendURL, "/"), code)
+}
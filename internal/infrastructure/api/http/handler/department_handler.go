@@ -10,6 +10,7 @@ import (
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
 	"github.com/InstaySystem/is_v2-be/pkg/constants"
 	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
 	"github.com/InstaySystem/is_v2-be/pkg/utils"
 	"github.com/InstaySystem/is_v2-be/pkg/validator"
 	"github.com/gin-gonic/gin"
@@ -35,11 +36,8 @@ func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
 
 	var req dto.CreateDepartmentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
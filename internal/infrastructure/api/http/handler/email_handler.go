@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	emailUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/email"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/mapper"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/InstaySystem/is_v2-be/pkg/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type EmailHandler struct {
+	emailUC emailUC.EmailUseCase
+}
+
+func NewEmailHandler(emailUC emailUC.EmailUseCase) *EmailHandler {
+	return &EmailHandler{emailUC}
+}
+
+func (h *EmailHandler) GetEmailLogs(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query dto.EmailLogPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	emailLogs, meta, err := h.emailUC.GetEmailLogs(ctx, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"email_logs": mapper.ToEmailLogsResponse(emailLogs),
+		"meta":       meta,
+	})
+}
+
+func (h *EmailHandler) GetSuppressions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query dto.EmailSuppressionPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	suppressions, meta, err := h.emailUC.GetSuppressions(ctx, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"suppressions": mapper.ToEmailSuppressionsResponse(suppressions),
+		"meta":         meta,
+	})
+}
+
+func (h *EmailHandler) DeleteSuppression(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	suppressionIDStr := c.Param("id")
+	suppressionID, err := strconv.ParseInt(suppressionIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	if err = h.emailUC.DeleteSuppression(ctx, suppressionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeDeleteEmailSuppressionSuccess, "Email suppression deleted successfully", nil)
+}
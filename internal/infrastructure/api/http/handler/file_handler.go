@@ -2,11 +2,17 @@ package handler
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
 	fileUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/file"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
 	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/mapper"
 	"github.com/InstaySystem/is_v2-be/pkg/utils"
 	"github.com/InstaySystem/is_v2-be/pkg/validator"
 	"github.com/gin-gonic/gin"
@@ -24,18 +30,21 @@ func (h *FileHandler) UploadPresignedURLs(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
 	var req dto.UploadPresignedURLsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
 
-	presignedURLs, err := h.fileUC.CreateUploadURLs(ctx, req)
+	presignedURLs, err := h.fileUC.CreateUploadURLs(ctx, userID, req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -46,22 +55,55 @@ func (h *FileHandler) UploadPresignedURLs(c *gin.Context) {
 	})
 }
 
+func (h *FileHandler) ConfirmUploads(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	var req dto.ConfirmFilesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	files, err := h.fileUC.ConfirmUploads(ctx, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeConfirmFilesSuccess, "Files confirmed successfully", gin.H{
+		"files": mapper.ToFilesResponse(files),
+	})
+}
+
 func (h *FileHandler) ViewPresignedURLs(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+	role := model.UserRole(c.GetString(middleware.CtxRole))
+
 	var req dto.ViewPresignedURLsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
 
-	presignedURLs, err := h.fileUC.CreateViewURLs(ctx, req)
+	presignedURLs, err := h.fileUC.CreateViewURLs(ctx, userID, role, req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -71,3 +113,150 @@ func (h *FileHandler) ViewPresignedURLs(c *gin.Context) {
 		"presigned_urls": presignedURLs,
 	})
 }
+
+func (h *FileHandler) CreateMultipartUpload(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	var req dto.CreateMultipartUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	upload, err := h.fileUC.CreateMultipartUpload(ctx, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusCreated, constants.CodeCreateUploadSuccess, "Upload created successfully", gin.H{
+		"upload": upload,
+	})
+}
+
+func (h *FileHandler) GetMultipartUploads(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	uploads, err := h.fileUC.GetMultipartUploads(ctx, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"uploads": uploads,
+	})
+}
+
+func (h *FileHandler) GetMultipartUpload(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	upload, err := h.fileUC.GetMultipartUpload(ctx, userID, c.Param("key"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"upload": upload,
+	})
+}
+
+func (h *FileHandler) UploadPartURLs(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	var req dto.UploadPartURLsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	partURLs, err := h.fileUC.CreateUploadPartURLs(ctx, userID, c.Param("key"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"presigned_urls": partURLs,
+	})
+}
+
+func (h *FileHandler) CompleteMultipartUpload(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	var req dto.CompleteMultipartUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	file, err := h.fileUC.CompleteMultipartUpload(ctx, userID, c.Param("key"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeCompleteUploadSuccess, "Upload completed successfully", gin.H{
+		"file": mapper.ToFileResponse(file),
+	})
+}
+
+func (h *FileHandler) AbortMultipartUpload(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	if err := h.fileUC.AbortMultipartUpload(ctx, userID, c.Param("key")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeAbortUploadSuccess, "Upload aborted successfully", nil)
+}
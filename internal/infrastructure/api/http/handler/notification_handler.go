@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	notificationUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/notification"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/mapper"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/InstaySystem/is_v2-be/pkg/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationUC notificationUC.NotificationUseCase
+}
+
+func NewNotificationHandler(notificationUC notificationUC.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{notificationUC}
+}
+
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	var query dto.NotificationPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	notis, meta, err := h.notificationUC.GetNotifications(ctx, userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"notifications": mapper.ToNotificationsResponse(notis),
+		"meta":          meta,
+	})
+}
+
+func (h *NotificationHandler) CountUnread(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	count, err := h.notificationUC.CountUnread(ctx, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"count": count,
+	})
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	notificationIDStr := c.Param("id")
+	notificationID, err := strconv.ParseInt(notificationIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	if err = h.notificationUC.MarkRead(ctx, userID, notificationID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeMarkNotificationReadSuccess, "Notification marked as read", nil)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	count, err := h.notificationUC.MarkAllRead(ctx, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeMarkNotificationsReadSuccess, "Notifications marked as read", gin.H{
+		"count": count,
+	})
+}
+
+func (h *NotificationHandler) NotifyOnDuty(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var req dto.NotifyOnDutyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	res, err := h.notificationUC.NotifyOnDuty(ctx, req.DepartmentID, dto.NotificationData{
+		Type:  constants.NotificationTypeAnnouncement,
+		Title: req.Title,
+		Body:  req.Body,
+		Link:  req.Link,
+	}, req.FallbackToDepartment)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeNotifyOnDutySuccess, "Notification sent to on-duty staff", res)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	propertyUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/property"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/mapper"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/InstaySystem/is_v2-be/pkg/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type PropertyHandler struct {
+	propertyUC propertyUC.PropertyUseCase
+}
+
+func NewPropertyHandler(propertyUC propertyUC.PropertyUseCase) *PropertyHandler {
+	return &PropertyHandler{propertyUC}
+}
+
+func (h *PropertyHandler) CreateProperty(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	var req dto.CreatePropertyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	id, err := h.propertyUC.CreateProperty(ctx, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusCreated, constants.CodeCreatePropertySuccess, "Property created successfully", gin.H{
+		"property_id": id,
+	})
+}
+
+func (h *PropertyHandler) GetProperties(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	properties, err := h.propertyUC.GetProperties(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"properties": mapper.ToPropertiesResponse(properties),
+	})
+}
+
+func (h *PropertyHandler) GetPropertyByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	propertyIDStr := c.Param("id")
+	propertyID, err := strconv.ParseInt(propertyIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	property, err := h.propertyUC.GetPropertyByID(ctx, propertyID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"property": mapper.ToPropertyResponse(property),
+	})
+}
+
+func (h *PropertyHandler) UpdateProperty(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	propertyIDStr := c.Param("id")
+	propertyID, err := strconv.ParseInt(propertyIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	var req dto.UpdatePropertyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	if err := h.propertyUC.UpdateProperty(ctx, propertyID, userID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeUpdatePropertySuccess, "Property updated successfully", nil)
+}
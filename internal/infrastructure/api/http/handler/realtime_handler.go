@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	authUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/auth"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/realtime"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const realtimeHeartbeat = 25 * time.Second
+
+type RealtimeHandler struct {
+	hub    *realtime.Hub
+	authUC authUC.AuthUseCase
+}
+
+func NewRealtimeHandler(hub *realtime.Hub, authUC authUC.AuthUseCase) *RealtimeHandler {
+	return &RealtimeHandler{
+		hub,
+		authUC,
+	}
+}
+
+// Events streams the caller's user and department channels as Server-Sent
+// Events. The stream ends when the access token expires or the session is
+// revoked, so the client has to refresh (or log out) before reconnecting.
+func (h *RealtimeHandler) Events(c *gin.Context) {
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	accessTTL, ok := c.Value(middleware.CtxAccessTTL).(time.Duration)
+	if !ok || accessTTL <= 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	user, err := h.authUC.GetMe(ctx, userID)
+	cancel()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	channels := []string{realtime.UserChannel(user.ID)}
+	if user.DepartmentID != nil {
+		channels = append(channels, realtime.DepartmentChannel(*user.DepartmentID))
+	}
+
+	sub := h.hub.Subscribe(channels...)
+	defer sub.Close()
+
+	// Streams outlive the server-wide write timeout.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.WriteString(": connected\n\n")
+	c.Writer.Flush()
+
+	expiry := time.NewTimer(accessTTL)
+	defer expiry.Stop()
+
+	heartbeat := time.NewTicker(realtimeHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-expiry.C:
+			c.SSEvent(constants.EventSessionExpired, nil)
+			c.Writer.Flush()
+			return
+		case <-heartbeat.C:
+			c.Writer.WriteString(": ping\n\n")
+			c.Writer.Flush()
+		case msg, ok := <-sub.C:
+			if !ok {
+				return
+			}
+
+			c.SSEvent(msg.Type, msg.Data)
+			c.Writer.Flush()
+
+			if msg.Type == constants.EventSessionRevoked {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	shiftUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/shift"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/mapper"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/InstaySystem/is_v2-be/pkg/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type ShiftHandler struct {
+	shiftUC shiftUC.ShiftUseCase
+}
+
+func NewShiftHandler(shiftUC shiftUC.ShiftUseCase) *ShiftHandler {
+	return &ShiftHandler{shiftUC}
+}
+
+func (h *ShiftHandler) CreateShift(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	var req dto.CreateShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	id, err := h.shiftUC.CreateShift(ctx, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusCreated, constants.CodeCreateShiftSuccess, "Shift created successfully", gin.H{
+		"shift_id": id,
+	})
+}
+
+func (h *ShiftHandler) GetShifts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query dto.ShiftPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	shifts, meta, err := h.shiftUC.GetShifts(ctx, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"shifts": mapper.ToShiftsResponse(shifts),
+		"meta":   meta,
+	})
+}
+
+func (h *ShiftHandler) GetShiftByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	shiftIDStr := c.Param("id")
+	shiftID, err := strconv.ParseInt(shiftIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	shift, err := h.shiftUC.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"shift": mapper.ToShiftResponse(shift),
+	})
+}
+
+func (h *ShiftHandler) UpdateShift(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	shiftIDStr := c.Param("id")
+	shiftID, err := strconv.ParseInt(shiftIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	var req dto.UpdateShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	if err := h.shiftUC.UpdateShift(ctx, shiftID, userID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeUpdateShiftSuccess, "Shift updated successfully", nil)
+}
+
+func (h *ShiftHandler) DeleteShift(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	shiftIDStr := c.Param("id")
+	shiftID, err := strconv.ParseInt(shiftIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	if err := h.shiftUC.DeleteShift(ctx, shiftID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeDeleteShiftSuccess, "Shift deleted successfully", nil)
+}
+
+func (h *ShiftHandler) AssignShift(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userID := c.GetInt64(middleware.CtxUserID)
+	if userID == 0 {
+		c.Error(errors.ErrUnAuth)
+		return
+	}
+
+	shiftIDStr := c.Param("id")
+	shiftID, err := strconv.ParseInt(shiftIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	var req dto.AssignShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	id, err := h.shiftUC.AssignShift(ctx, shiftID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusCreated, constants.CodeAssignShiftSuccess, "Staff assigned to shift successfully", gin.H{
+		"assignment_id": id,
+	})
+}
+
+func (h *ShiftHandler) UnassignShift(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	shiftIDStr := c.Param("id")
+	shiftID, err := strconv.ParseInt(shiftIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	assignmentIDStr := c.Param("assignment_id")
+	assignmentID, err := strconv.ParseInt(assignmentIDStr, 10, 64)
+	if err != nil {
+		c.Error(errors.ErrInvalidID)
+		return
+	}
+
+	if err := h.shiftUC.UnassignShift(ctx, shiftID, assignmentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.APIResponse(c, http.StatusOK, constants.CodeUnassignShiftSuccess, "Staff removed from shift successfully", nil)
+}
+
+func (h *ShiftHandler) GetRoster(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query dto.ShiftRosterQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	weekStart, shifts, err := h.shiftUC.GetRoster(ctx, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"roster": mapper.ToShiftRosterResponse(weekStart, shifts),
+	})
+}
+
+func (h *ShiftHandler) GetOnDuty(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query dto.OnDutyQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.ErrBadRequest.WithData(gin.H{
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
+		}))
+		return
+	}
+
+	assignments, err := h.shiftUC.GetOnDuty(ctx, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.OKResponse(c, gin.H{
+		"on_duty": mapper.ToOnDutyResponse(assignments),
+	})
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/local"
+	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxUploadFieldSize    = 1 << 10
+	maxUploadFormOverhead = 64 << 10
+)
+
+type StorageHandler struct {
+	stor     *local.Storage
+	cachePro port.CacheProvider
+}
+
+func NewStorageHandler(stor *local.Storage, cachePro port.CacheProvider) *StorageHandler {
+	return &StorageHandler{stor, cachePro}
+}
+
+// Upload mirrors an S3 presigned POST: form fields first, the "file" part last.
+func (h *StorageHandler) Upload(c *gin.Context) {
+	claims, err := h.stor.VerifyToken(c.Query("token"), local.OpUpload)
+	if err != nil || claims.Nonce == "" {
+		c.Error(errors.ErrInvalidToken)
+		return
+	}
+
+	// Like an S3 presigned POST the token may be retried after a failed
+	// attempt, but once an upload succeeds it cannot overwrite the object.
+	nonceKey := fmt.Sprintf("storage_upload:%s", claims.Nonce)
+	uses, err := h.cachePro.IncrementBy(c.Request.Context(), nonceKey, 1, time.Until(time.Unix(claims.ExpiresAt, 0))+time.Minute)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if uses > 1 {
+		c.Error(errors.ErrInvalidToken)
+		return
+	}
+	uploaded := false
+	defer func() {
+		if !uploaded {
+			h.cachePro.Del(context.WithoutCancel(c.Request.Context()), nonceKey)
+		}
+	}()
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, claims.MaxSize+maxUploadFormOverhead)
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		c.Error(errors.ErrBadRequest)
+		return
+	}
+
+	fields := make(map[string]string)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.Error(errors.ErrBadRequest)
+			return
+		}
+
+		if part.FormName() != "file" {
+			value, err := io.ReadAll(io.LimitReader(part, maxUploadFieldSize))
+			if err != nil {
+				c.Error(errors.ErrBadRequest)
+				return
+			}
+			fields[part.FormName()] = string(value)
+			continue
+		}
+
+		if fields["Content-Type"] != claims.ContentType {
+			c.Error(errors.ErrContentTypeNotAllowed)
+			return
+		}
+
+		if _, err = h.stor.Write(claims.Key, claims.ContentType, part, claims.MaxSize); err != nil {
+			if stdErrors.Is(err, local.ErrObjectLarge) {
+				c.Error(errors.ErrFileTooLarge)
+				return
+			}
+			c.Error(err)
+			return
+		}
+
+		uploaded = true
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.Error(errors.ErrBadRequest)
+}
+
+// UploadPart mirrors an S3 presigned UploadPart: the raw part is the request
+// body and its ETag is returned in the response header.
+func (h *StorageHandler) UploadPart(c *gin.Context) {
+	claims, err := h.stor.VerifyToken(c.Query("token"), local.OpUploadPart)
+	if err != nil {
+		c.Error(errors.ErrInvalidToken)
+		return
+	}
+
+	etag, err := h.stor.WritePart(claims, c.Request.Body)
+	if err != nil {
+		switch {
+		case stdErrors.Is(err, local.ErrObjectLarge):
+			c.Error(errors.ErrFileTooLarge)
+		case stdErrors.Is(err, port.ErrMultipartUploadNotFound):
+			c.Error(errors.ErrMultipartUploadNotFound)
+		case stdErrors.Is(err, port.ErrInvalidUploadPart):
+			c.Error(errors.ErrInvalidUploadParts)
+		default:
+			c.Error(err)
+		}
+		return
+	}
+
+	c.Header("ETag", etag)
+	c.Status(http.StatusOK)
+}
+
+func (h *StorageHandler) Download(c *gin.Context) {
+	claims, err := h.stor.VerifyToken(c.Query("token"), local.OpDownload)
+	if err != nil {
+		c.Error(errors.ErrInvalidToken)
+		return
+	}
+
+	file, info, err := h.stor.Open(claims.Key)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if file == nil {
+		c.Error(errors.ErrFileNotFound)
+		return
+	}
+	defer file.Close()
+
+	if info.ContentType != "" {
+		c.Header("Content-Type", info.ContentType)
+	}
+	c.Header("Cache-Control", "private, max-age=0")
+
+	http.ServeContent(c.Writer, c.Request, path.Base(claims.Key), info.LastModified, file)
+}
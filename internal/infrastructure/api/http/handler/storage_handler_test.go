@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/local"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type counterCache struct {
+	port.CacheProvider
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+func (c *counterCache) IncrementBy(_ context.Context, key string, value int64, _ time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counters[key] += value
+	return c.counters[key], nil
+}
+
+func (c *counterCache) Del(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.counters, key)
+	return nil
+}
+
+type storageFixture struct {
+	stor   *local.Storage
+	router *gin.Engine
+}
+
+func newStorageFixture(t *testing.T) *storageFixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	stor, err := local.NewStorageProvider(config.StorageConfig{
+		LocalRoot:    t.TempDir(),
+		LocalBaseURL: "http://app.test",
+		SigningKey:   "test-key",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hdl := NewStorageHandler(stor, &counterCache{counters: make(map[string]int64)})
+	router := gin.New()
+	router.Use(middleware.NewContextMiddleware(zap.NewNop()).ErrorHandler())
+	router.POST("/storage/upload", hdl.Upload)
+
+	return &storageFixture{stor, router}
+}
+
+func (f *storageFixture) upload(t *testing.T, presigned *port.PresignedUpload, contentType, body string) int {
+	t.Helper()
+
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	form.WriteField("Content-Type", contentType)
+	part, err := form.CreateFormFile("file", "avatar.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte(body))
+	form.Close()
+
+	presignedURL, err := url.Parse(presigned.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/storage/upload?"+presignedURL.RawQuery, &buf)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	rec := httptest.NewRecorder()
+	f.router.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+func TestUploadTokenIsSingleUse(t *testing.T) {
+	f := newStorageFixture(t)
+
+	presigned, err := f.stor.PresignUpload(context.Background(), "public/avatars/a.png", "image/png", 1<<10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := f.upload(t, presigned, "image/png", "first"); code != http.StatusNoContent {
+		t.Fatalf("first upload status = %d, want %d", code, http.StatusNoContent)
+	}
+	if code := f.upload(t, presigned, "image/png", "overwrite"); code == http.StatusNoContent {
+		t.Fatal("token accepted a second upload")
+	}
+
+	file, _, err := f.stor.Open("public/avatars/a.png")
+	if err != nil || file == nil {
+		t.Fatalf("open uploaded object: %v", err)
+	}
+	defer file.Close()
+
+	var got bytes.Buffer
+	got.ReadFrom(file)
+	if got.String() != "first" {
+		t.Fatalf("object = %q, want %q", got.String(), "first")
+	}
+}
+
+func TestUploadTokenCanBeRetriedAfterFailure(t *testing.T) {
+	f := newStorageFixture(t)
+
+	presigned, err := f.stor.PresignUpload(context.Background(), "public/avatars/b.png", "image/png", 1<<10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := f.upload(t, presigned, "image/gif", "wrong type"); code == http.StatusNoContent {
+		t.Fatal("upload with the wrong content type succeeded")
+	}
+	if code := f.upload(t, presigned, "image/png", "retry"); code != http.StatusNoContent {
+		t.Fatalf("retry status = %d, want %d", code, http.StatusNoContent)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
 	"github.com/InstaySystem/is_v2-be/pkg/constants"
 	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
 	"github.com/InstaySystem/is_v2-be/pkg/mapper"
 	"github.com/InstaySystem/is_v2-be/pkg/utils"
 	"github.com/InstaySystem/is_v2-be/pkg/validator"
@@ -38,30 +39,27 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	var req dto.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
 
-	if req.Role == model.RoleAdmin {
+	if req.Role.IsAdmin() {
 		if req.DepartmentID != nil {
 			c.Error(errors.ErrBadRequest.WithData(gin.H{
-				"field": "departmentid",
-				"tag":   "notrequired",
-				"param": "",
+				"errors": []*validator.FieldError{
+					validator.NewFieldError(i18n.FromContext(ctx), "department_id", "notrequired", ""),
+				},
 			}))
 			return
 		}
 	} else {
 		if req.DepartmentID == nil {
 			c.Error(errors.ErrBadRequest.WithData(gin.H{
-				"field": "departmentid",
-				"tag":   "required",
-				"param": "",
+				"errors": []*validator.FieldError{
+					validator.NewFieldError(i18n.FromContext(ctx), "department_id", "required", ""),
+				},
 			}))
 			return
 		}
@@ -106,11 +104,8 @@ func (h *UserHandler) GetUsers(c *gin.Context) {
 
 	var query dto.UserPaginationQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
@@ -128,9 +123,13 @@ func (h *UserHandler) GetUsers(c *gin.Context) {
 }
 
 func (h *UserHandler) GetAllRoles(c *gin.Context) {
+	locale := i18n.FromContext(c.Request.Context())
 	rolesMap := map[string]string{
-		constants.RoleAdminDisplayName: string(model.RoleAdmin),
-		constants.RoleStaffDisplayName: string(model.RoleStaff),
+		i18n.T(locale, "role.admin"): string(model.RoleAdmin),
+		i18n.T(locale, "role.staff"): string(model.RoleStaff),
+	}
+	if model.UserRole(c.GetString(middleware.CtxRole)) == model.RoleChainAdmin {
+		rolesMap[i18n.T(locale, "role.chain_admin")] = string(model.RoleChainAdmin)
 	}
 
 	utils.OKResponse(c, gin.H{
@@ -157,30 +156,27 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	var req dto.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
 
-	if req.Role == model.RoleAdmin {
+	if req.Role.IsAdmin() {
 		if req.DepartmentID != nil {
 			c.Error(errors.ErrBadRequest.WithData(gin.H{
-				"field": "departmentid",
-				"tag":   "notrequired",
-				"param": "",
+				"errors": []*validator.FieldError{
+					validator.NewFieldError(i18n.FromContext(ctx), "department_id", "notrequired", ""),
+				},
 			}))
 			return
 		}
 	} else {
 		if req.DepartmentID == nil {
 			c.Error(errors.ErrBadRequest.WithData(gin.H{
-				"field": "departmentid",
-				"tag":   "required",
-				"param": "",
+				"errors": []*validator.FieldError{
+					validator.NewFieldError(i18n.FromContext(ctx), "department_id", "required", ""),
+				},
 			}))
 			return
 		}
@@ -213,11 +209,8 @@ func (h *UserHandler) UpdateUserPassword(c *gin.Context) {
 
 	var req dto.UpdateUserPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
@@ -267,11 +260,8 @@ func (h *UserHandler) DeleteUsers(c *gin.Context) {
 
 	var req dto.DeleteManyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		field, tag, param := validator.HandleRequestError(err)
 		c.Error(errors.ErrBadRequest.WithData(gin.H{
-			"field": field,
-			"tag":   tag,
-			"param": param,
+			"errors": validator.HandleRequestError(i18n.FromContext(ctx), err),
 		}))
 		return
 	}
@@ -11,6 +11,8 @@ import (
 	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
 	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -21,6 +23,10 @@ const (
 	CtxRefreshToken = "refresh_token"
 	CtxAccessTTL    = "access_ttl"
 	CtxRole         = "role"
+	// CtxImpersonatorID and CtxImpersonationID are only set when the access
+	// token was issued to an admin impersonating the user.
+	CtxImpersonatorID  = "impersonator_id"
+	CtxImpersonationID = "impersonation_id"
 )
 
 type AuthMiddleware struct {
@@ -48,19 +54,13 @@ func (m *AuthMiddleware) IsAuthentication() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		accessToken, err := c.Cookie(m.cfg.AccessName)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.APIResponse{
-				Code:    errors.ErrUnAuth.Code,
-				Message: errors.ErrUnAuth.Message,
-			})
+			abortWithError(c, http.StatusUnauthorized, errors.ErrUnAuth)
 			return
 		}
 
-		userID, role, tokenVersion, ttl, err := m.jwtPro.ParseToken(accessToken)
+		claims, err := m.jwtPro.ParseToken(accessToken)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.APIResponse{
-				Code:    errors.ErrUnAuth.Code,
-				Message: errors.ErrUnAuth.Message,
-			})
+			abortWithError(c, http.StatusUnauthorized, errors.ErrUnAuth)
 			return
 		}
 
@@ -70,44 +70,48 @@ func (m *AuthMiddleware) IsAuthentication() gin.HandlerFunc {
 		blackListKey := fmt.Sprintf("black_list:%s", accessToken)
 		str, err := m.cachePro.GetString(ctx, blackListKey)
 		if err != nil {
-			m.log.Error("get black list failed", zap.Error(err))
-			c.AbortWithStatusJSON(http.StatusInternalServerError, dto.APIResponse{
-				Code:    errors.ErrUnAuth.Code,
-				Message: errors.ErrUnAuth.Message,
-			})
+			logger.FromContext(ctx, m.log).Error("get black list failed", zap.Error(err))
+			abortWithError(c, http.StatusInternalServerError, errors.ErrUnAuth)
 			return
 		}
 
 		if str != "" {
-			c.AbortWithStatusJSON(http.StatusForbidden, dto.APIResponse{
-				Code:    errors.ErrInvalidUser.Code,
-				Message: errors.ErrInvalidUser.Message,
-			})
+			abortWithError(c, http.StatusForbidden, errors.ErrInvalidUser)
 			return
 		}
 
-		userVersionKey := fmt.Sprintf("user_version:%d", userID)
+		userVersionKey := fmt.Sprintf("user_version:%d", claims.UserID)
 		currentTokenVersion, err := m.cachePro.GetInt(ctx, userVersionKey)
 		if err != nil {
-			m.log.Error("get token version failed", zap.Error(err))
-			c.AbortWithStatusJSON(http.StatusInternalServerError, dto.APIResponse{
-				Code:    errors.ErrUnAuth.Code,
-				Message: errors.ErrUnAuth.Message,
-			})
+			logger.FromContext(ctx, m.log).Error("get token version failed", zap.Error(err))
+			abortWithError(c, http.StatusInternalServerError, errors.ErrUnAuth)
 			return
 		}
 
-		if tokenVersion != currentTokenVersion {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.APIResponse{
-				Code:    errors.ErrUnAuth.Code,
-				Message: errors.ErrUnAuth.Message,
-			})
+		if claims.TokenVersion != currentTokenVersion {
+			abortWithError(c, http.StatusUnauthorized, errors.ErrUnAuth)
 			return
 		}
 
-		c.Set(CtxUserID, userID)
-		c.Set(CtxRole, string(role))
-		c.Set(CtxAccessTTL, ttl)
+		if apiErr := bindTenant(c, claims); apiErr != nil {
+			abortWithError(c, http.StatusForbidden, apiErr)
+			return
+		}
+
+		c.Set(CtxUserID, claims.UserID)
+		c.Set(CtxRole, string(claims.Role))
+		c.Set(CtxAccessTTL, claims.TTL)
+
+		reqLog := logger.FromContext(c.Request.Context(), m.log).With(zap.Int64("user_id", claims.UserID))
+		if claims.Actor != nil {
+			c.Set(CtxImpersonatorID, claims.Actor.UserID)
+			c.Set(CtxImpersonationID, claims.Actor.SessionID)
+			reqLog = reqLog.With(zap.Int64("impersonator_id", claims.Actor.UserID))
+		}
+		if propertyID := c.GetInt64(CtxPropertyID); propertyID != 0 {
+			reqLog = reqLog.With(zap.Int64("property_id", propertyID))
+		}
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context(), reqLog))
 
 		c.Next()
 	}
@@ -117,19 +121,13 @@ func (m *AuthMiddleware) AttachTokens() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		accessToken, err := c.Cookie(m.cfg.AccessName)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.APIResponse{
-				Code:    errors.ErrUnAuth.Code,
-				Message: errors.ErrUnAuth.Message,
-			})
+			abortWithError(c, http.StatusUnauthorized, errors.ErrUnAuth)
 			return
 		}
 
 		refreshToken, err := c.Cookie(m.cfg.RefreshName)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusForbidden, dto.APIResponse{
-				Code:    errors.ErrInvalidUser.Code,
-				Message: errors.ErrInvalidUser.Message,
-			})
+			abortWithError(c, http.StatusForbidden, errors.ErrInvalidUser)
 			return
 		}
 
@@ -145,14 +143,33 @@ func (m *AuthMiddleware) HasRole(allowedRole model.UserRole) gin.HandlerFunc {
 		roleStr := c.GetString(CtxRole)
 		role := model.UserRole(roleStr)
 
-		if roleStr == "" || !model.IsValidRole(role) || role != allowedRole {
-			c.AbortWithStatusJSON(http.StatusForbidden, dto.APIResponse{
-				Code:    errors.ErrForbidden.Code,
-				Message: errors.ErrForbidden.Message,
-			})
+		// Chain admins hold every admin permission.
+		allowed := role == allowedRole || (allowedRole == model.RoleAdmin && role.IsAdmin())
+		if roleStr == "" || !model.IsValidRole(role) || !allowed {
+			abortWithError(c, http.StatusForbidden, errors.ErrForbidden)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// NotImpersonating blocks sensitive account actions for impersonation
+// sessions.
+func (m *AuthMiddleware) NotImpersonating() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetInt64(CtxImpersonatorID) != 0 {
+			abortWithError(c, http.StatusForbidden, errors.ErrImpersonationRestricted)
 			return
 		}
 
 		c.Next()
 	}
 }
+
+func abortWithError(c *gin.Context, status int, apiErr *errors.APIError) {
+	c.AbortWithStatusJSON(status, dto.APIResponse{
+		Code:    apiErr.Code,
+		Message: apiErr.Localize(i18n.FromContext(c.Request.Context())),
+	})
+}
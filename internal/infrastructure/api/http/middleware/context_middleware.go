@@ -5,13 +5,25 @@ import (
 	"io"
 	"net/http"
 	"runtime/debug"
+	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
 	"github.com/InstaySystem/is_v2-be/pkg/constants"
 	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
 	"github.com/InstaySystem/is_v2-be/pkg/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	HeaderRequestID = "X-Request-ID"
+	CtxRequestID    = "request_id"
+	CtxLocale       = "locale"
 )
 
 type ContextMiddleware struct {
@@ -33,7 +45,7 @@ func (m *ContextMiddleware) Recovery() gin.HandlerFunc {
 		}
 
 		stack := string(debug.Stack())
-		m.log.Error("panic recovered",
+		logger.FromContext(c.Request.Context(), m.log).Error("panic recovered",
 			zap.String("path", c.Request.URL.Path),
 			zap.String("method", c.Request.Method),
 			zap.String("ip", c.ClientIP()),
@@ -44,7 +56,7 @@ func (m *ContextMiddleware) Recovery() gin.HandlerFunc {
 
 		c.AbortWithStatusJSON(http.StatusInternalServerError, dto.APIResponse{
 			Code:    constants.CodeInternalError,
-			Message: "internal server error",
+			Message: i18n.T(i18n.FromContext(c.Request.Context()), "error.internal_server"),
 		})
 	})
 }
@@ -59,10 +71,83 @@ func (m *ContextMiddleware) ErrorHandler() gin.HandlerFunc {
 		}
 
 		if apiErr, ok := err.Err.(*errors.APIError); ok {
-			utils.APIResponse(c, apiErr.Status, apiErr.Code, apiErr.Message, apiErr.Data)
+			utils.APIResponse(c, apiErr.Status, apiErr.Code, apiErr.Localize(i18n.FromContext(c.Request.Context())), apiErr.Data)
 			return
 		}
 
 		utils.ISEResponse(c)
 	}
 }
+
+func (m *ContextMiddleware) RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(HeaderRequestID)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Set(CtxRequestID, requestID)
+		c.Header(HeaderRequestID, requestID)
+
+		fields := []zap.Field{zap.String("request_id", requestID)}
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.HasTraceID() {
+			fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+		}
+
+		reqLog := m.log.With(fields...)
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context(), reqLog))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := zapcore.InfoLevel
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = zapcore.ErrorLevel
+		case status >= http.StatusBadRequest:
+			level = zapcore.WarnLevel
+		}
+
+		logger.FromContext(c.Request.Context(), reqLog).Log(level, "http request",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("route", c.FullPath()),
+			zap.String("query", c.Request.URL.RawQuery),
+			zap.Int("status", status),
+			zap.Int("size", c.Writer.Size()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+		)
+	}
+}
+
+func (m *ContextMiddleware) Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.DefaultLocale
+		if l, ok := i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language")); ok {
+			locale = l
+		}
+
+		c.Set(CtxLocale, locale)
+		c.Header("Content-Language", locale)
+		c.Request = c.Request.WithContext(i18n.WithLocale(c.Request.Context(), locale))
+
+		c.Next()
+	}
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+
+	for _, ch := range id {
+		if ch < 0x21 || ch > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
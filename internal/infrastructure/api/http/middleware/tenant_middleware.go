@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/InstaySystem/is_v2-be/pkg/tenant"
+	"github.com/InstaySystem/is_v2-be/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderProperty         = "X-Property"
+	CtxRequestedPropertyID = "requested_property_id"
+	CtxPropertyID          = "property_id"
+)
+
+type TenantMiddleware struct {
+	log          *zap.Logger
+	propertyRepo repository.PropertyRepository
+}
+
+func NewTenantMiddleware(log *zap.Logger, propertyRepo repository.PropertyRepository) *TenantMiddleware {
+	return &TenantMiddleware{
+		log,
+		propertyRepo,
+	}
+}
+
+// ResolveProperty ignores an unknown subdomain so hosts like api.example.com
+// keep working; scoping itself happens in IsAuthentication.
+func (m *TenantMiddleware) ResolveProperty() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		code := strings.ToLower(strings.TrimSpace(c.GetHeader(HeaderProperty)))
+		fromHeader := code != ""
+		if !fromHeader {
+			code = propertySubdomain(c.Request.Host)
+		}
+		if code == "" {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+		defer cancel()
+
+		property, err := m.propertyRepo.FindByCode(ctx, code)
+		if err != nil {
+			logger.FromContext(ctx, m.log).Error("find property by code failed", zap.String("code", code), zap.Error(err))
+			c.Abort()
+			utils.ISEResponse(c)
+			return
+		}
+
+		if property == nil || !property.IsActive {
+			if fromHeader {
+				abortWithError(c, http.StatusNotFound, errors.ErrPropertyNotFound)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		c.Set(CtxRequestedPropertyID, property.ID)
+
+		c.Next()
+	}
+}
+
+// bindTenant rejects non chain admins without a property on their token,
+// since an empty scope would mean the whole chain.
+func bindTenant(c *gin.Context, claims *port.TokenClaims) *errors.APIError {
+	requested := c.GetInt64(CtxRequestedPropertyID)
+
+	t := tenant.Tenant{PropertyID: claims.PropertyID}
+	if claims.Role == model.RoleChainAdmin {
+		t = tenant.Tenant{PropertyID: requested, ChainAdmin: true}
+	} else if claims.PropertyID == 0 || (requested != 0 && requested != claims.PropertyID) {
+		return errors.ErrPropertyMismatch
+	}
+
+	c.Set(CtxPropertyID, t.PropertyID)
+	c.Request = c.Request.WithContext(tenant.WithContext(c.Request.Context(), t))
+
+	return nil
+}
+
+func propertySubdomain(host string) string {
+	if idx := strings.Index(host, ":"); idx != -1 {
+		host = host[:idx]
+	}
+
+	root := utils.ExtractRootDomain(host)
+	if !strings.HasPrefix(root, ".") {
+		return ""
+	}
+
+	sub := strings.TrimSuffix(host, root)
+	if sub == host || sub == "" {
+		return ""
+	}
+	if idx := strings.LastIndex(sub, "."); idx != -1 {
+		sub = sub[idx+1:]
+	}
+
+	return strings.ToLower(sub)
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/pkg/errors"
+	"github.com/InstaySystem/is_v2-be/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+func TestBindTenant(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		requested  int64
+		claims     port.TokenClaims
+		wantErr    *errors.APIError
+		wantTenant tenant.Tenant
+	}{
+		{
+			name:       "staff in their own property",
+			claims:     port.TokenClaims{PropertyID: 1, Role: model.RoleStaff},
+			wantTenant: tenant.Tenant{PropertyID: 1},
+		},
+		{
+			name:       "staff requesting their own property",
+			requested:  1,
+			claims:     port.TokenClaims{PropertyID: 1, Role: model.RoleAdmin},
+			wantTenant: tenant.Tenant{PropertyID: 1},
+		},
+		{
+			name:      "staff requesting another property",
+			requested: 2,
+			claims:    port.TokenClaims{PropertyID: 1, Role: model.RoleAdmin},
+			wantErr:   errors.ErrPropertyMismatch,
+		},
+		{
+			name:    "staff without a property",
+			claims:  port.TokenClaims{Role: model.RoleStaff},
+			wantErr: errors.ErrPropertyMismatch,
+		},
+		{
+			name:       "chain admin in a requested property",
+			requested:  2,
+			claims:     port.TokenClaims{PropertyID: 1, Role: model.RoleChainAdmin},
+			wantTenant: tenant.Tenant{PropertyID: 2, ChainAdmin: true},
+		},
+		{
+			name:       "chain admin across the chain",
+			claims:     port.TokenClaims{Role: model.RoleChainAdmin},
+			wantTenant: tenant.Tenant{ChainAdmin: true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.requested != 0 {
+				c.Set(CtxRequestedPropertyID, tc.requested)
+			}
+
+			err := bindTenant(c, &tc.claims)
+			if err != tc.wantErr {
+				t.Fatalf("err = %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				if _, ok := tenant.FromContext(c.Request.Context()); ok {
+					t.Fatal("tenant bound despite the error")
+				}
+				return
+			}
+
+			got, ok := tenant.FromContext(c.Request.Context())
+			if !ok || got != tc.wantTenant {
+				t.Fatalf("tenant = %+v, want %+v", got, tc.wantTenant)
+			}
+			if id := c.GetInt64(CtxPropertyID); id != tc.wantTenant.PropertyID {
+				t.Fatalf("%s = %d, want %d", CtxPropertyID, id, tc.wantTenant.PropertyID)
+			}
+		})
+	}
+}
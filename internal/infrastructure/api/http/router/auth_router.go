@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/handler"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
 	"github.com/gin-gonic/gin"
@@ -11,13 +12,21 @@ func (r *Router) setupAuthRoutes(rg *gin.RouterGroup, authMid *middleware.AuthMi
 	{
 		auth.POST("/login", hdl.Login)
 
+		auth.POST("/magic-link", hdl.RequestMagicLink)
+
+		auth.POST("/magic-link/consume", hdl.ConsumeMagicLink)
+
+		auth.GET("/oidc/login", hdl.OIDCLogin)
+
+		auth.GET("/oidc/callback", hdl.OIDCCallback)
+
 		auth.POST("/logout", authMid.IsAuthentication(), authMid.AttachTokens(), hdl.Logout)
 
 		auth.POST("/refresh-token", hdl.RefreshToken)
 
 		auth.GET("/me", authMid.IsAuthentication(), hdl.GetMe)
 
-		auth.POST("/change-password", authMid.IsAuthentication(), hdl.ChangePassword)
+		auth.POST("/change-password", authMid.IsAuthentication(), authMid.NotImpersonating(), hdl.ChangePassword)
 
 		auth.POST("/forgot-password", hdl.ForgotPassword)
 
@@ -25,6 +34,16 @@ func (r *Router) setupAuthRoutes(rg *gin.RouterGroup, authMid *middleware.AuthMi
 
 		auth.POST("/reset-password", hdl.ResetPassword)
 
-		auth.POST("/update-info", authMid.IsAuthentication(), hdl.UpdateInfo)
+		auth.POST("/update-info", authMid.IsAuthentication(), authMid.NotImpersonating(), hdl.UpdateInfo)
+
+		auth.POST("/impersonation/stop", authMid.IsAuthentication(), authMid.AttachTokens(), hdl.StopImpersonation)
+
+		auth.POST("/impersonation/:id", authMid.IsAuthentication(), authMid.HasRole(model.RoleAdmin), hdl.StartImpersonation)
+
+		auth.GET("/impersonation-sessions", authMid.IsAuthentication(), authMid.HasRole(model.RoleAdmin), hdl.GetImpersonationSessions)
+
+		auth.GET("/role-settings", authMid.IsAuthentication(), authMid.HasRole(model.RoleAdmin), hdl.GetRoleSettings)
+
+		auth.PUT("/role-settings/:role", authMid.IsAuthentication(), authMid.HasRole(model.RoleChainAdmin), hdl.UpdateRoleSetting)
 	}
 }
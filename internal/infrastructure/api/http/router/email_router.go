@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/handler"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Router) setupEmailRoutes(rg *gin.RouterGroup, authMid *middleware.AuthMiddleware, hdl *handler.EmailHandler) {
+	email := rg.Group("/emails", authMid.IsAuthentication(), authMid.HasRole(model.RoleAdmin))
+	{
+		email.GET("/logs", hdl.GetEmailLogs)
+
+		email.GET("/suppressions", hdl.GetSuppressions)
+
+		email.DELETE("/suppressions/:id", hdl.DeleteSuppression)
+	}
+}
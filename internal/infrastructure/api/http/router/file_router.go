@@ -2,14 +2,29 @@ package router
 
 import (
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/handler"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func (r *Router) setupFileRoutes(rg *gin.RouterGroup, hdl *handler.FileHandler) {
-	file := rg.Group("/files")
+func (r *Router) setupFileRoutes(rg *gin.RouterGroup, authMid *middleware.AuthMiddleware, hdl *handler.FileHandler) {
+	file := rg.Group("/files", authMid.IsAuthentication())
 	{
 		file.POST("/presigned-urls/uploads", hdl.UploadPresignedURLs)
 
+		file.POST("/confirm", hdl.ConfirmUploads)
+
+		file.POST("/multipart", hdl.CreateMultipartUpload)
+
+		file.GET("/multipart", hdl.GetMultipartUploads)
+
+		file.GET("/multipart/:key", hdl.GetMultipartUpload)
+
+		file.POST("/multipart/:key/parts", hdl.UploadPartURLs)
+
+		file.POST("/multipart/:key/complete", hdl.CompleteMultipartUpload)
+
+		file.DELETE("/multipart/:key", hdl.AbortMultipartUpload)
+
 		file.POST("/presigned-urls/views", hdl.ViewPresignedURLs)
 	}
 }
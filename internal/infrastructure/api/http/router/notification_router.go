@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/handler"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Router) setupNotificationRoutes(rg *gin.RouterGroup, authMid *middleware.AuthMiddleware, hdl *handler.NotificationHandler) {
+	notification := rg.Group("/notifications", authMid.IsAuthentication())
+	{
+		notification.GET("", hdl.GetNotifications)
+
+		notification.GET("/unread-count", hdl.CountUnread)
+
+		notification.POST("/read-all", hdl.MarkAllRead)
+
+		notification.POST("/on-duty", authMid.HasRole(model.RoleAdmin), hdl.NotifyOnDuty)
+
+		notification.POST("/:id/read", hdl.MarkRead)
+	}
+}
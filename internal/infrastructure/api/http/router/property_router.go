@@ -0,0 +1,21 @@
+package router
+
+import (
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/handler"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Router) setupPropertyRoutes(rg *gin.RouterGroup, authMid *middleware.AuthMiddleware, hdl *handler.PropertyHandler) {
+	property := rg.Group("/properties", authMid.IsAuthentication(), authMid.HasRole(model.RoleChainAdmin))
+	{
+		property.POST("", hdl.CreateProperty)
+
+		property.GET("", hdl.GetProperties)
+
+		property.GET("/:id", hdl.GetPropertyByID)
+
+		property.PUT("/:id", hdl.UpdateProperty)
+	}
+}
@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/handler"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Router) setupRealtimeRoutes(rg *gin.RouterGroup, authMid *middleware.AuthMiddleware, hdl *handler.RealtimeHandler) {
+	realtime := rg.Group("/realtime", authMid.IsAuthentication())
+	{
+		realtime.GET("/events", hdl.Events)
+	}
+}
@@ -23,11 +23,25 @@ func (r *Router) Setup(cfg config.ServerConfig, ctn *container.Container) {
 		c.JSON(http.StatusOK, "pong")
 	})
 
-	r.setupFileRoutes(v2, ctn.FileHTTPHdl)
+	r.setupFileRoutes(v2, ctn.AuthHTTPMid, ctn.FileHTTPHdl)
+
+	if ctn.StorageHTTPHdl != nil {
+		r.setupStorageRoutes(v2, ctn.StorageHTTPHdl)
+	}
 
 	r.setupAuthRoutes(v2, ctn.AuthHTTPMid, ctn.AuthHTTPHdl)
 
 	r.setupUserRoutes(v2, ctn.AuthHTTPMid, ctn.UserHTTPHdl)
 
 	r.setupDepartmentRoutes(v2, ctn.AuthHTTPMid, ctn.DepartmentHTTPHdl)
+
+	r.setupPropertyRoutes(v2, ctn.AuthHTTPMid, ctn.PropertyHTTPHdl)
+
+	r.setupShiftRoutes(v2, ctn.AuthHTTPMid, ctn.ShiftHTTPHdl)
+
+	r.setupRealtimeRoutes(v2, ctn.AuthHTTPMid, ctn.RealtimeHTTPHdl)
+
+	r.setupNotificationRoutes(v2, ctn.AuthHTTPMid, ctn.NotificationHTTPHdl)
+
+	r.setupEmailRoutes(v2, ctn.AuthHTTPMid, ctn.EmailHTTPHdl)
 }
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/handler"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Router) setupShiftRoutes(rg *gin.RouterGroup, authMid *middleware.AuthMiddleware, hdl *handler.ShiftHandler) {
+	shift := rg.Group("/shifts", authMid.IsAuthentication())
+	{
+		shift.GET("/roster", hdl.GetRoster)
+
+		shift.GET("/on-duty", hdl.GetOnDuty)
+
+		shift.GET("", hdl.GetShifts)
+
+		shift.GET("/:id", hdl.GetShiftByID)
+
+		shift.POST("", authMid.HasRole(model.RoleAdmin), hdl.CreateShift)
+
+		shift.PUT("/:id", authMid.HasRole(model.RoleAdmin), hdl.UpdateShift)
+
+		shift.DELETE("/:id", authMid.HasRole(model.RoleAdmin), hdl.DeleteShift)
+
+		shift.POST("/:id/assignments", authMid.HasRole(model.RoleAdmin), hdl.AssignShift)
+
+		shift.DELETE("/:id/assignments/:assignment_id", authMid.HasRole(model.RoleAdmin), hdl.UnassignShift)
+	}
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/api/http/handler"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Router) setupStorageRoutes(rg *gin.RouterGroup, hdl *handler.StorageHandler) {
+	storage := rg.Group("/storage")
+	{
+		storage.POST("/upload", hdl.Upload)
+
+		storage.PUT("/parts", hdl.UploadPart)
+
+		storage.GET("/download", hdl.Download)
+	}
+}
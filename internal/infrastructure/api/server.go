@@ -14,6 +14,7 @@ import (
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 type Server struct {
@@ -35,11 +36,14 @@ func NewServer(cfg *config.Config, ctn *container.Container) *Server {
 	}
 
 	r.Use(
-		gin.Logger(),
+		otelgin.Middleware(cfg.OTel.ServiceName),
+		ctn.CtxHTTPMid.RequestLogger(),
+		ctn.CtxHTTPMid.Locale(),
 		gin.Recovery(),
 		cors.New(corsConfig),
 		ctn.CtxHTTPMid.ErrorHandler(),
 		ctn.CtxHTTPMid.Recovery(),
+		ctn.TenantHTTPMid.ResolveProperty(),
 	)
 
 	api := router.NewRouter(r)
@@ -2,27 +2,52 @@ package consumer
 
 import (
 	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/sony/sonyflake/v2"
 	"go.uber.org/zap"
 )
 
 type Consumer struct {
-	log     *zap.Logger
-	mqPro   port.MessageQueueProvider
-	smtpPro port.SMTPProvider
+	log                  *zap.Logger
+	mqPro                port.MessageQueueProvider
+	smtpPro              port.SMTPProvider
+	smsPro               port.SMSProvider
+	storPro              port.StorageProvider
+	idGen                *sonyflake.Sonyflake
+	fileRepo             repository.FileRepository
+	fileVariantRepo      repository.FileVariantRepository
+	emailLogRepo         repository.EmailLogRepository
+	emailSuppressionRepo repository.EmailSuppressionRepository
 }
 
 func NewConsumer(
 	log *zap.Logger,
 	mqPro port.MessageQueueProvider,
 	smtpPro port.SMTPProvider,
+	smsPro port.SMSProvider,
+	storPro port.StorageProvider,
+	idGen *sonyflake.Sonyflake,
+	fileRepo repository.FileRepository,
+	fileVariantRepo repository.FileVariantRepository,
+	emailLogRepo repository.EmailLogRepository,
+	emailSuppressionRepo repository.EmailSuppressionRepository,
 ) *Consumer {
 	return &Consumer{
 		log,
 		mqPro,
 		smtpPro,
+		smsPro,
+		storPro,
+		idGen,
+		fileRepo,
+		fileVariantRepo,
+		emailLogRepo,
+		emailSuppressionRepo,
 	}
 }
 
 func (c *Consumer) Start() {
 	c.startEmailConsumer()
+	c.startSMSConsumer()
+	c.startImageConsumer()
 }
@@ -1,32 +1,186 @@
 package consumer
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
 	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
 	"go.uber.org/zap"
 )
 
+// maxAttachmentsSize keeps a message under the common 25MB relay limit once
+// base64 encoding is accounted for.
+const maxAttachmentsSize = 18 << 20
+
 func (c *Consumer) startEmailConsumer() {
 	go c.startSendAuthEmail()
+	go c.startSendNotificationEmail()
+	go c.startSendTemplateEmail()
 }
 
 func (c *Consumer) startSendAuthEmail() {
-	if err := c.mqPro.ConsumeMessage(constants.QueueNameAuthEmail, constants.ExchangeEmail, constants.RoutingKeyAuthEmail, func(body []byte) error {
+	if err := c.mqPro.ConsumeMessage(constants.QueueNameAuthEmail, constants.ExchangeEmail, constants.RoutingKeyAuthEmail, func(ctx context.Context, body []byte) error {
 		var emailMsg dto.AuthEmailMessage
 		if err := json.Unmarshal(body, &emailMsg); err != nil {
-			c.log.Error("json unmarshal auth email message failed", zap.Error(err))
+			logger.FromContext(ctx, c.log).Error("json unmarshal auth email message failed", zap.Error(err))
 			return err
 		}
 
-		if err := c.smtpPro.AuthEmail(emailMsg.To, emailMsg.Subject, emailMsg.Otp); err != nil {
-			c.log.Error("send auth email failed", zap.Error(err))
+		return c.deliverEmail(ctx, emailMsg.MessageID, emailMsg.PropertyID, constants.EmailTemplateOTP, []string{emailMsg.To}, func([]string) error {
+			if err := c.smtpPro.SendTemplate(ctx, &port.TemplateMail{
+				MessageID: emailMsg.MessageID,
+				Template:  constants.EmailTemplateOTP,
+				Locale:    emailMsg.Locale,
+				To:        []string{emailMsg.To},
+				Subject:   emailMsg.Subject,
+				Data:      map[string]any{"Otp": emailMsg.Otp},
+			}); err != nil {
+				logger.FromContext(ctx, c.log).Error("send auth email failed", zap.Error(err))
+				return err
+			}
+			return nil
+		})
+	}); err != nil {
+		c.log.Error("start consumer send auth email failed", zap.Error(err))
+	}
+}
+
+func (c *Consumer) startSendNotificationEmail() {
+	if err := c.mqPro.ConsumeMessage(constants.QueueNameNotificationEmail, constants.ExchangeEmail, constants.RoutingKeyNotificationEmail, func(ctx context.Context, body []byte) error {
+		var emailMsg dto.NotificationEmailMessage
+		if err := json.Unmarshal(body, &emailMsg); err != nil {
+			logger.FromContext(ctx, c.log).Error("json unmarshal notification email message failed", zap.Error(err))
 			return err
 		}
 
-		return nil
+		data := map[string]any{"Body": emailMsg.Body}
+		if emailMsg.Link != nil {
+			data["Link"] = *emailMsg.Link
+		}
+
+		return c.deliverEmail(ctx, emailMsg.MessageID, emailMsg.PropertyID, constants.EmailTemplateNotification, []string{emailMsg.To}, func([]string) error {
+			if err := c.smtpPro.SendTemplate(ctx, &port.TemplateMail{
+				MessageID: emailMsg.MessageID,
+				Template:  constants.EmailTemplateNotification,
+				Locale:    emailMsg.Locale,
+				To:        []string{emailMsg.To},
+				Subject:   emailMsg.Title,
+				Data:      data,
+			}); err != nil {
+				logger.FromContext(ctx, c.log).Error("send notification email failed", zap.Error(err))
+				return err
+			}
+			return nil
+		})
 	}); err != nil {
-		c.log.Error("start consumer send auth email failed", zap.Error(err))
+		c.log.Error("start consumer send notification email failed", zap.Error(err))
 	}
 }
+
+func (c *Consumer) startSendTemplateEmail() {
+	routingKeys := []string{
+		constants.RoutingKeyWelcomeEmail,
+		constants.RoutingKeyPasswordChangedEmail,
+		constants.RoutingKeyAccountDeactivatedEmail,
+		constants.RoutingKeyBookingConfirmationEmail,
+		constants.RoutingKeyInvoiceEmail,
+		constants.RoutingKeyNewLoginEmail,
+		constants.RoutingKeyEmailChangedEmail,
+		constants.RoutingKeyMagicLinkEmail,
+	}
+
+	if err := c.mqPro.ConsumeMessages(constants.QueueNameTemplateEmail, constants.ExchangeEmail, routingKeys, func(ctx context.Context, body []byte) error {
+		var emailMsg dto.EmailMessage
+		if err := json.Unmarshal(body, &emailMsg); err != nil {
+			logger.FromContext(ctx, c.log).Error("json unmarshal email message failed", zap.Error(err))
+			return err
+		}
+
+		recipients := slices.Concat(emailMsg.To, emailMsg.Cc, emailMsg.Bcc)
+
+		return c.deliverEmail(ctx, emailMsg.MessageID, emailMsg.PropertyID, emailMsg.Template, recipients, func(suppressed []string) error {
+			to := withoutSuppressed(emailMsg.To, suppressed)
+			cc := withoutSuppressed(emailMsg.Cc, suppressed)
+			if len(to) == 0 {
+				to, cc = cc, nil
+			}
+			bcc := withoutSuppressed(emailMsg.Bcc, suppressed)
+
+			attachments, err := c.loadAttachments(ctx, emailMsg.Attachments)
+			if err != nil {
+				logger.FromContext(ctx, c.log).Error("load email attachments failed", zap.String("template", emailMsg.Template), zap.Error(err))
+				return err
+			}
+
+			if err = c.smtpPro.SendTemplate(ctx, &port.TemplateMail{
+				MessageID:   emailMsg.MessageID,
+				Template:    emailMsg.Template,
+				Locale:      emailMsg.Locale,
+				To:          to,
+				Cc:          cc,
+				Bcc:         bcc,
+				Data:        emailMsg.Data,
+				Attachments: attachments,
+			}); err != nil {
+				logger.FromContext(ctx, c.log).Error("send template email failed", zap.String("template", emailMsg.Template), zap.Error(err))
+				return err
+			}
+			return nil
+		})
+	}); err != nil {
+		c.log.Error("start consumer send template email failed", zap.Error(err))
+	}
+}
+
+func (c *Consumer) loadAttachments(ctx context.Context, refs []dto.EmailAttachment) ([]*port.MailAttachment, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	attachments := make([]*port.MailAttachment, 0, len(refs))
+	var total int64
+	for _, ref := range refs {
+		info, err := c.storPro.Head(ctx, ref.Key)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			return nil, fmt.Errorf("email attachment %q not found", ref.Key)
+		}
+
+		total += info.Size
+		if total > maxAttachmentsSize {
+			return nil, fmt.Errorf("email attachments exceed %d bytes", maxAttachmentsSize)
+		}
+
+		rc, err := c.storPro.Get(ctx, ref.Key)
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, info.Size+1))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		fileName := ref.FileName
+		if fileName == "" {
+			fileName = path.Base(ref.Key)
+		}
+
+		attachments = append(attachments, &port.MailAttachment{
+			FileName:    fileName,
+			ContentType: info.ContentType,
+			Content:     content,
+		})
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,182 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// hardBounceCodes are permanent recipient rejections; retrying them only hurts
+// the sender reputation, so the address is suppressed instead.
+var hardBounceCodes = []int{550, 551, 553}
+
+// deliverEmail runs one send attempt and records it in the email log keyed by
+// message ID. send receives the suppressed recipients so it can leave them
+// out; hard bounces are acknowledged so the queue stops retrying them.
+func (c *Consumer) deliverEmail(ctx context.Context, messageID string, propertyID *int64, template string, recipients []string, send func(suppressed []string) error) error {
+	if messageID == "" {
+		messageID = uuid.NewString()
+	}
+
+	emailLog, err := c.emailLogRepo.FindByMessageID(ctx, messageID)
+	if err != nil {
+		logger.FromContext(ctx, c.log).Error("find email log by message id failed", zap.String("message_id", messageID), zap.Error(err))
+		return err
+	}
+	if emailLog != nil && emailLog.Status != model.EmailStatusRetrying {
+		return nil
+	}
+
+	attempt, maxAttempts := 1, 1
+	var status model.EmailStatus
+	var response string
+	var sentAt *time.Time
+
+	suppressed, err := c.emailSuppressionRepo.FindAllEmailsByEmails(ctx, normalizeEmails(recipients))
+	if err != nil {
+		logger.FromContext(ctx, c.log).Error("find suppressed emails failed", zap.Error(err))
+		return err
+	}
+
+	var sendErr error
+	if allSuppressed(recipients, suppressed) {
+		status = model.EmailStatusSuppressed
+		response = "all recipients are suppressed"
+	} else {
+		attempt, maxAttempts = port.DeliveryAttempt(ctx)
+		sendErr = send(suppressed)
+
+		var rejected *port.RecipientsRejectedError
+		switch {
+		case sendErr == nil:
+			now := time.Now()
+			status, sentAt = model.EmailStatusSent, &now
+		case errors.As(sendErr, &rejected):
+			response = sendErr.Error()
+			for rcpt, rcptErr := range rejected.Rejected {
+				if isHardBounce(rcptErr) {
+					c.suppressEmail(ctx, propertyID, rcpt, rcptErr.Error())
+				}
+			}
+			if rejected.Delivered {
+				now := time.Now()
+				status, sentAt = model.EmailStatusSent, &now
+			} else {
+				status = model.EmailStatusBounced
+			}
+		case isHardBounce(sendErr):
+			status, response = model.EmailStatusBounced, sendErr.Error()
+			if len(recipients) == 1 {
+				c.suppressEmail(ctx, propertyID, recipients[0], response)
+			}
+		case attempt >= maxAttempts:
+			status, response = model.EmailStatusFailed, sendErr.Error()
+		default:
+			status, response = model.EmailStatusRetrying, sendErr.Error()
+		}
+	}
+
+	if emailLog == nil {
+		id, err := c.idGen.NextID()
+		if err != nil {
+			logger.FromContext(ctx, c.log).Error("generate email log id failed", zap.Error(err))
+			return err
+		}
+
+		if err = c.emailLogRepo.Create(ctx, &model.EmailLog{
+			ID:         id,
+			MessageID:  messageID,
+			PropertyID: propertyID,
+			Recipient:  truncate(strings.Join(recipients, ", "), 500),
+			Template:   template,
+			Status:     status,
+			Attempts:   attempt,
+			Response:   response,
+			SentAt:     sentAt,
+		}); err != nil {
+			logger.FromContext(ctx, c.log).Error("create email log failed", zap.String("message_id", messageID), zap.Error(err))
+		}
+	} else {
+		if err = c.emailLogRepo.Update(ctx, emailLog.ID, map[string]any{
+			"status":   status,
+			"attempts": attempt,
+			"response": response,
+			"sent_at":  sentAt,
+		}); err != nil {
+			logger.FromContext(ctx, c.log).Error("update email log failed", zap.String("message_id", messageID), zap.Error(err))
+		}
+	}
+
+	if status == model.EmailStatusRetrying || status == model.EmailStatusFailed {
+		return sendErr
+	}
+	return nil
+}
+
+func (c *Consumer) suppressEmail(ctx context.Context, propertyID *int64, email, reason string) {
+	id, err := c.idGen.NextID()
+	if err != nil {
+		logger.FromContext(ctx, c.log).Error("generate email suppression id failed", zap.Error(err))
+		return
+	}
+
+	if err = c.emailSuppressionRepo.Create(ctx, &model.EmailSuppression{
+		ID:         id,
+		PropertyID: propertyID,
+		Email:      strings.ToLower(email),
+		Reason:     reason,
+	}); err != nil {
+		logger.FromContext(ctx, c.log).Error("create email suppression failed", zap.String("email", email), zap.Error(err))
+	}
+}
+
+func isHardBounce(err error) bool {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return slices.Contains(hardBounceCodes, protoErr.Code)
+	}
+	return false
+}
+
+func normalizeEmails(emails []string) []string {
+	normalized := make([]string, 0, len(emails))
+	for _, email := range emails {
+		normalized = append(normalized, strings.ToLower(email))
+	}
+	return normalized
+}
+
+func allSuppressed(recipients, suppressed []string) bool {
+	for _, email := range recipients {
+		if !isSuppressed(suppressed, email) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSuppressed(suppressed []string, email string) bool {
+	return slices.Contains(suppressed, strings.ToLower(email))
+}
+
+func withoutSuppressed(emails, suppressed []string) []string {
+	return slices.DeleteFunc(slices.Clone(emails), func(email string) bool {
+		return isSuppressed(suppressed, email)
+	})
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
@@ -0,0 +1,171 @@
+package consumer
+
+import (
+	"context"
+	"net/textproto"
+	"slices"
+	"testing"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/sony/sonyflake/v2"
+	"go.uber.org/zap"
+)
+
+type fakeEmailLogRepo struct {
+	repository.EmailLogRepository
+	logs []*model.EmailLog
+}
+
+func (r *fakeEmailLogRepo) FindByMessageID(context.Context, string) (*model.EmailLog, error) {
+	return nil, nil
+}
+
+func (r *fakeEmailLogRepo) Create(_ context.Context, emailLog *model.EmailLog) error {
+	r.logs = append(r.logs, emailLog)
+	return nil
+}
+
+type fakeSuppressionRepo struct {
+	repository.EmailSuppressionRepository
+	emails []string
+}
+
+func (r *fakeSuppressionRepo) FindAllEmailsByEmails(_ context.Context, emails []string) ([]string, error) {
+	var found []string
+	for _, email := range emails {
+		if slices.Contains(r.emails, email) {
+			found = append(found, email)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeSuppressionRepo) Create(_ context.Context, suppression *model.EmailSuppression) error {
+	r.emails = append(r.emails, suppression.Email)
+	return nil
+}
+
+func newDeliveryConsumer(t *testing.T, suppressed ...string) (*Consumer, *fakeEmailLogRepo, *fakeSuppressionRepo) {
+	t.Helper()
+
+	idGen, err := sonyflake.New(sonyflake.Settings{
+		MachineID: func() (int, error) { return 1, nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs := &fakeEmailLogRepo{}
+	suppressions := &fakeSuppressionRepo{emails: suppressed}
+	return &Consumer{
+		log:                  zap.NewNop(),
+		idGen:                idGen,
+		emailLogRepo:         logs,
+		emailSuppressionRepo: suppressions,
+	}, logs, suppressions
+}
+
+func hardBounce() error {
+	return &textproto.Error{Code: 550, Msg: "mailbox unavailable"}
+}
+
+func TestDeliverEmailSkipsSuppressedRecipients(t *testing.T) {
+	c, logs, _ := newDeliveryConsumer(t, "gone@instay.test")
+
+	called := false
+	err := c.deliverEmail(context.Background(), "m1", nil, "otp", []string{"Gone@instay.test"}, func([]string) error {
+		called = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Fatal("email sent to a suppressed address")
+	}
+	if logs.logs[0].Status != model.EmailStatusSuppressed {
+		t.Fatalf("status = %s, want suppressed", logs.logs[0].Status)
+	}
+}
+
+func TestDeliverEmailPassesSuppressedToSend(t *testing.T) {
+	c, logs, _ := newDeliveryConsumer(t, "gone@instay.test")
+
+	var got []string
+	err := c.deliverEmail(context.Background(), "m1", nil, "notification", []string{"ok@instay.test", "gone@instay.test"}, func(suppressed []string) error {
+		got = suppressed
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, []string{"gone@instay.test"}) {
+		t.Fatalf("suppressed = %v, want [gone@instay.test]", got)
+	}
+	if logs.logs[0].Status != model.EmailStatusSent {
+		t.Fatalf("status = %s, want sent", logs.logs[0].Status)
+	}
+}
+
+func TestDeliverEmailSuppressesHardBounces(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		recipients     []string
+		sendErr        error
+		wantStatus     model.EmailStatus
+		wantSuppressed []string
+	}{
+		{
+			name:           "single recipient bounced",
+			recipients:     []string{"Gone@instay.test"},
+			sendErr:        hardBounce(),
+			wantStatus:     model.EmailStatusBounced,
+			wantSuppressed: []string{"gone@instay.test"},
+		},
+		{
+			name:       "one recipient rejected, the rest delivered",
+			recipients: []string{"ok@instay.test", "gone@instay.test"},
+			sendErr: &port.RecipientsRejectedError{
+				Rejected:  map[string]error{"gone@instay.test": hardBounce()},
+				Delivered: true,
+			},
+			wantStatus:     model.EmailStatusSent,
+			wantSuppressed: []string{"gone@instay.test"},
+		},
+		{
+			name:       "temporary rejection is not suppressed",
+			recipients: []string{"ok@instay.test", "busy@instay.test"},
+			sendErr: &port.RecipientsRejectedError{
+				Rejected:  map[string]error{"busy@instay.test": &textproto.Error{Code: 552, Msg: "mailbox full"}},
+				Delivered: true,
+			},
+			wantStatus: model.EmailStatusSent,
+		},
+		{
+			name:           "bounce on a multi-recipient message suppresses nobody",
+			recipients:     []string{"a@instay.test", "b@instay.test"},
+			sendErr:        hardBounce(),
+			wantStatus:     model.EmailStatusBounced,
+			wantSuppressed: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, logs, suppressions := newDeliveryConsumer(t)
+
+			err := c.deliverEmail(context.Background(), "m1", nil, "otp", tc.recipients, func([]string) error {
+				return tc.sendErr
+			})
+			if err != nil {
+				t.Fatalf("err = %v, want the bounce acknowledged", err)
+			}
+			if logs.logs[0].Status != tc.wantStatus {
+				t.Fatalf("status = %s, want %s", logs.logs[0].Status, tc.wantStatus)
+			}
+			if !slices.Equal(suppressions.emails, tc.wantSuppressed) {
+				t.Fatalf("suppressed = %v, want %v", suppressions.emails, tc.wantSuppressed)
+			}
+		})
+	}
+}
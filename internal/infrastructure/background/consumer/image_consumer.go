@@ -0,0 +1,167 @@
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/imaging"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var imageVariants = []struct {
+	size    model.FileVariantSize
+	maxSize int
+}{
+	{model.FileVariantThumbnail, 200},
+	{model.FileVariantMedium, 800},
+	{model.FileVariantLarge, 1600},
+}
+
+func (c *Consumer) startImageConsumer() {
+	go c.startProcessImage()
+}
+
+func (c *Consumer) startProcessImage() {
+	if err := c.mqPro.ConsumeMessage(constants.QueueNameImageVariants, constants.ExchangeFile, constants.RoutingKeyImageVariants, func(ctx context.Context, body []byte) error {
+		var imageMsg dto.ImageProcessMessage
+		if err := json.Unmarshal(body, &imageMsg); err != nil {
+			logger.FromContext(ctx, c.log).Error("json unmarshal image process message failed", zap.Error(err))
+			return err
+		}
+
+		if err := c.processImage(ctx, imageMsg); err != nil {
+			logger.FromContext(ctx, c.log).Error("process image failed", zap.String("key", imageMsg.Key), zap.Error(err))
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		c.log.Error("start consumer process image failed", zap.Error(err))
+	}
+}
+
+func (c *Consumer) processImage(ctx context.Context, msg dto.ImageProcessMessage) error {
+	body, err := c.storPro.Get(ctx, msg.Key)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		return err
+	}
+
+	orientation := 1
+	if msg.ContentType == "image/jpeg" {
+		orientation = imaging.Orientation(data)
+
+		if stripped, ok := imaging.StripGPS(data); ok {
+			if err = c.putObject(ctx, msg.Key, msg.ContentType, stripped); err != nil {
+				return err
+			}
+			data = stripped
+		}
+	}
+
+	img, format, err := imaging.Decode(data)
+	if err != nil {
+		return err
+	}
+
+	fallbackFormat := imaging.FormatJPEG
+	if format == imaging.FormatPNG {
+		fallbackFormat = imaging.FormatPNG
+	}
+
+	variants := make([]*model.FileVariant, 0, len(imageVariants)*2)
+	for _, v := range imageVariants {
+		resized := imaging.Orient(imaging.Fit(img, v.maxSize), orientation)
+
+		for _, f := range []string{fallbackFormat, imaging.FormatWebP} {
+			variant, err := c.storeVariant(ctx, msg, resized, v.size, f)
+			if err != nil {
+				return err
+			}
+			variants = append(variants, variant)
+		}
+	}
+
+	return c.fileVariantRepo.CreateAll(ctx, variants)
+}
+
+func (c *Consumer) storeVariant(ctx context.Context, msg dto.ImageProcessMessage, img image.Image, size model.FileVariantSize, format string) (*model.FileVariant, error) {
+	encoded, err := imaging.Encode(img, format)
+	if err != nil {
+		return nil, err
+	}
+
+	key := variantKey(msg.Key, size, format)
+	contentType := imaging.ContentType(format)
+	if err = c.putObject(ctx, key, contentType, encoded); err != nil {
+		return nil, err
+	}
+
+	id, err := c.idGen.NextID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.FileVariant{
+		ID:          id,
+		FileID:      msg.FileID,
+		Size:        size,
+		Format:      format,
+		Key:         key,
+		ContentType: contentType,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		Bytes:       int64(len(encoded)),
+	}, nil
+}
+
+// replaceOriginal overwrites the uploaded object and syncs the file record's
+// size and checksum with what is now stored under its key.
+func (c *Consumer) replaceOriginal(ctx context.Context, msg dto.ImageProcessMessage, data []byte) error {
+	if err := c.putObject(ctx, msg.Key, msg.ContentType, data); err != nil {
+		return err
+	}
+
+	head, err := c.storPro.Head(ctx, msg.Key)
+	if err != nil {
+		return err
+	}
+	if head == nil {
+		return fmt.Errorf("object %s missing after rewrite", msg.Key)
+	}
+
+	return c.fileRepo.Update(ctx, msg.FileID, map[string]any{
+		"size":     head.Size,
+		"checksum": head.Checksum,
+	})
+}
+
+func (c *Consumer) putObject(ctx context.Context, key, contentType string, data []byte) error {
+	return c.storPro.Put(ctx, key, contentType, bytes.NewReader(data), int64(len(data)))
+}
+
+func variantKey(key string, size model.FileVariantSize, format string) string {
+	ext := ".jpg"
+	switch format {
+	case imaging.FormatPNG:
+		ext = ".png"
+	case imaging.FormatWebP:
+		ext = ".webp"
+	}
+
+	return fmt.Sprintf("%s_%s%s", strings.TrimSuffix(key, filepath.Ext(key)), size, ext)
+}
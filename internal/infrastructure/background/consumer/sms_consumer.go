@@ -0,0 +1,33 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (c *Consumer) startSMSConsumer() {
+	go c.startSendOTPSMS()
+}
+
+func (c *Consumer) startSendOTPSMS() {
+	if err := c.mqPro.ConsumeMessage(constants.QueueNameOTPSMS, constants.ExchangeSMS, constants.RoutingKeyOTPSMS, func(ctx context.Context, body []byte) error {
+		var smsMsg dto.SMSOTPMessage
+		if err := json.Unmarshal(body, &smsMsg); err != nil {
+			logger.FromContext(ctx, c.log).Error("json unmarshal sms otp message failed", zap.Error(err))
+			return err
+		}
+
+		if err := c.smsPro.SendOTP(ctx, smsMsg.Phone, smsMsg.Otp, smsMsg.Locale); err != nil {
+			logger.FromContext(ctx, c.log).Error("send sms otp failed", zap.String("message_id", smsMsg.MessageID), zap.Error(err))
+			return err
+		}
+		return nil
+	}); err != nil {
+		c.log.Error("start consumer send sms otp failed", zap.Error(err))
+	}
+}
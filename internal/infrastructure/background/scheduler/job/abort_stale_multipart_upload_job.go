@@ -0,0 +1,121 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	fileUC "github.com/InstaySystem/is_v2-be/internal/application/usecase/file"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"go.uber.org/zap"
+)
+
+const defaultMultipartCleanupBatchSize = 100
+
+type abortStaleMultipartUploadJob struct {
+	cfg           config.MultipartCleanupConfig
+	log           *zap.Logger
+	storPro       port.StorageProvider
+	fileRepo      repository.FileRepository
+	multipartRepo repository.MultipartUploadRepository
+	quota         *fileUC.UploadQuota
+}
+
+func NewAbortStaleMultipartUploadJob(
+	cfg config.MultipartCleanupConfig,
+	log *zap.Logger,
+	storPro port.StorageProvider,
+	fileRepo repository.FileRepository,
+	multipartRepo repository.MultipartUploadRepository,
+	quota *fileUC.UploadQuota,
+) Job {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultMultipartCleanupBatchSize
+	}
+
+	return &abortStaleMultipartUploadJob{
+		cfg,
+		log,
+		storPro,
+		fileRepo,
+		multipartRepo,
+		quota,
+	}
+}
+
+func (j *abortStaleMultipartUploadJob) Name() string {
+	return "abort_stale_multipart_uploads"
+}
+
+func (j *abortStaleMultipartUploadJob) Run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	startTime := time.Now()
+
+	var afterID, abortedCount, failedCount int64
+	for {
+		uploads, err := j.multipartRepo.FindAllExpiredWithFile(ctx, startTime, afterID, j.cfg.BatchSize)
+		if err != nil {
+			j.log.Error("find all expired multipart uploads failed", zap.Error(err))
+			break
+		}
+		if len(uploads) == 0 {
+			break
+		}
+		afterID = uploads[len(uploads)-1].ID
+
+		aborted, err := j.abortBatch(ctx, uploads)
+		abortedCount += aborted
+		failedCount += int64(len(uploads)) - aborted
+		if err != nil {
+			j.log.Error("abort stale multipart uploads batch failed", zap.Int64("after_id", afterID), zap.Error(err))
+			break
+		}
+
+		if len(uploads) < j.cfg.BatchSize {
+			break
+		}
+	}
+
+	j.log.Info(
+		"Abort stale multipart uploads completed",
+		zap.Int64("aborted_count", abortedCount),
+		zap.Int64("failed_count", failedCount),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+}
+
+// abortBatch drops the upload at the storage first; the file row (and with it
+// the multipart row) is only removed once the parts are gone, so a failed abort
+// is retried on the next run. The quota goes back with the row that held it.
+func (j *abortStaleMultipartUploadJob) abortBatch(ctx context.Context, uploads []*model.MultipartUpload) (int64, error) {
+	var aborted int64
+	for _, upload := range uploads {
+		if upload.File == nil {
+			continue
+		}
+
+		if err := j.storPro.AbortMultipartUpload(ctx, upload.File.Key, upload.UploadID); err != nil {
+			j.log.Warn("abort multipart upload failed", zap.String("key", upload.File.Key), zap.Error(err))
+			continue
+		}
+
+		deleted, err := j.fileRepo.DeleteAllByIDs(ctx, []int64{upload.FileID})
+		if err != nil {
+			return aborted, err
+		}
+		if deleted == 0 {
+			continue
+		}
+		aborted++
+
+		if upload.File.UploadedByID != nil {
+			j.quota.Release(ctx, *upload.File.UploadedByID, upload.File.Size, 1, upload.File.CreatedAt)
+		}
+	}
+
+	return aborted, nil
+}
@@ -0,0 +1,200 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCleanupGracePeriod = 24 * time.Hour
+	defaultCleanupBatchSize   = 100
+	maxCleanupBatchSize       = 1000
+)
+
+type cleanOrphanedFileJob struct {
+	cfg            config.FileCleanupConfig
+	log            *zap.Logger
+	storPro        port.StorageProvider
+	fileRepo       repository.FileRepository
+	deletedFiles   metric.Int64Counter
+	reclaimedBytes metric.Int64Counter
+}
+
+func NewCleanOrphanedFileJob(
+	cfg config.FileCleanupConfig,
+	log *zap.Logger,
+	storPro port.StorageProvider,
+	fileRepo repository.FileRepository,
+) (Job, error) {
+	if cfg.GracePeriod <= 0 {
+		cfg.GracePeriod = defaultCleanupGracePeriod
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultCleanupBatchSize
+	}
+	cfg.BatchSize = min(cfg.BatchSize, maxCleanupBatchSize)
+
+	meter := otel.Meter("github.com/InstaySystem/is_v2-be/job")
+
+	deletedFiles, err := meter.Int64Counter(
+		"file_cleanup.deleted_files",
+		metric.WithDescription("Number of orphaned uploads removed"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reclaimedBytes, err := meter.Int64Counter(
+		"file_cleanup.reclaimed_bytes",
+		metric.WithDescription("Storage reclaimed by removing orphaned uploads"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cleanOrphanedFileJob{
+		cfg,
+		log,
+		storPro,
+		fileRepo,
+		deletedFiles,
+		reclaimedBytes,
+	}, nil
+}
+
+func (j *cleanOrphanedFileJob) Name() string {
+	return "cleanup_orphaned_files"
+}
+
+func (j *cleanOrphanedFileJob) Run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	startTime := time.Now()
+	before := startTime.Add(-j.cfg.GracePeriod)
+
+	var afterID, deletedCount, reclaimed int64
+	for {
+		files, err := j.fileRepo.FindAllUnlinkedBefore(ctx, before, afterID, j.cfg.BatchSize)
+		if err != nil {
+			j.log.Error("find all unlinked files failed", zap.Error(err))
+			break
+		}
+		if len(files) == 0 {
+			break
+		}
+		afterID = files[len(files)-1].ID
+
+		count, size, err := j.cleanBatch(ctx, files)
+		deletedCount += count
+		reclaimed += size
+		if err != nil {
+			j.log.Error("clean orphaned files batch failed", zap.Int64("after_id", afterID), zap.Error(err))
+			break
+		}
+
+		if len(files) < j.cfg.BatchSize {
+			break
+		}
+	}
+
+	attrs := metric.WithAttributes(attribute.Bool("dry_run", j.cfg.DryRun))
+	j.deletedFiles.Add(ctx, deletedCount, attrs)
+	j.reclaimedBytes.Add(ctx, reclaimed, attrs)
+
+	j.log.Info(
+		"Cleanup orphaned files completed",
+		zap.Bool("dry_run", j.cfg.DryRun),
+		zap.Int64("deleted_count", deletedCount),
+		zap.Int64("reclaimed_bytes", reclaimed),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+}
+
+func (j *cleanOrphanedFileJob) cleanBatch(ctx context.Context, files []*model.File) (int64, int64, error) {
+	sizes := make(map[int64]int64, len(files))
+	for _, file := range files {
+		size, err := j.objectSize(ctx, file)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, variant := range file.Variants {
+			size += variant.Bytes
+		}
+		sizes[file.ID] = size
+	}
+
+	if j.cfg.DryRun {
+		var total int64
+		for _, file := range files {
+			total += sizes[file.ID]
+			j.log.Info("Orphaned file would be deleted", zap.String("key", file.Key), zap.Int("variants", len(file.Variants)), zap.Int64("size", sizes[file.ID]))
+		}
+		return int64(len(files)), total, nil
+	}
+
+	keys := make([]string, 0, len(files))
+	owners := make(map[string]int64, len(files))
+	for _, file := range files {
+		keys = append(keys, file.Key)
+		owners[file.Key] = file.ID
+		for _, variant := range file.Variants {
+			keys = append(keys, variant.Key)
+			owners[variant.Key] = file.ID
+		}
+	}
+
+	failedKeys, err := j.storPro.Delete(ctx, keys)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	failed := make(map[int64]struct{}, len(failedKeys))
+	for _, key := range failedKeys {
+		failed[owners[key]] = struct{}{}
+		j.log.Warn("delete orphaned object failed", zap.String("key", key))
+	}
+
+	ids := make([]int64, 0, len(files))
+	var total int64
+	for _, file := range files {
+		if _, ok := failed[file.ID]; ok {
+			continue
+		}
+		ids = append(ids, file.ID)
+		total += sizes[file.ID]
+	}
+	if len(ids) == 0 {
+		return 0, 0, nil
+	}
+
+	rowsDeleted, err := j.fileRepo.DeleteAllByIDs(ctx, ids)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return rowsDeleted, total, nil
+}
+
+func (j *cleanOrphanedFileJob) objectSize(ctx context.Context, file *model.File) (int64, error) {
+	if file.Status == model.FileStatusConfirmed {
+		return file.Size, nil
+	}
+
+	head, err := j.storPro.Head(ctx, file.Key)
+	if err != nil || head == nil {
+		return 0, err
+	}
+
+	return head.Size, nil
+}
@@ -0,0 +1,111 @@
+package job
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"go.uber.org/zap"
+)
+
+type fakeFileRepo struct {
+	repository.FileRepository
+	files   []*model.File
+	deleted []int64
+}
+
+func (r *fakeFileRepo) FindAllUnlinkedBefore(_ context.Context, _ time.Time, afterID int64, limit int) ([]*model.File, error) {
+	var files []*model.File
+	for _, file := range r.files {
+		if file.ID > afterID && len(files) < limit {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+func (r *fakeFileRepo) DeleteAllByIDs(_ context.Context, ids []int64) (int64, error) {
+	r.deleted = append(r.deleted, ids...)
+	return int64(len(ids)), nil
+}
+
+type fakeStorage struct {
+	port.StorageProvider
+	failing []string
+	deleted []string
+}
+
+func (s *fakeStorage) Head(context.Context, string) (*port.ObjectInfo, error) {
+	return &port.ObjectInfo{Size: 10}, nil
+}
+
+func (s *fakeStorage) Delete(_ context.Context, keys []string) ([]string, error) {
+	var failed []string
+	for _, key := range keys {
+		if slices.Contains(s.failing, key) {
+			failed = append(failed, key)
+			continue
+		}
+		s.deleted = append(s.deleted, key)
+	}
+	return failed, nil
+}
+
+func orphanedFiles() []*model.File {
+	return []*model.File{
+		{ID: 1, Key: "uploads/a.pdf", Status: model.FileStatusPending},
+		{ID: 2, Key: "public/avatars/b.jpg", Status: model.FileStatusConfirmed, Size: 100, Variants: []*model.FileVariant{
+			{Key: "public/avatars/b_thumb.webp", Bytes: 20},
+		}},
+		{ID: 3, Key: "uploads/c.pdf", Status: model.FileStatusPending},
+	}
+}
+
+func TestCleanOrphanedFileJob(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		dryRun      bool
+		failing     []string
+		wantRows    []int64
+		wantObjects []string
+	}{
+		{
+			name:        "deletes objects, variants and rows",
+			wantRows:    []int64{1, 2, 3},
+			wantObjects: []string{"uploads/a.pdf", "public/avatars/b.jpg", "public/avatars/b_thumb.webp", "uploads/c.pdf"},
+		},
+		{
+			name:        "keeps the row when a variant could not be deleted",
+			failing:     []string{"public/avatars/b_thumb.webp"},
+			wantRows:    []int64{1, 3},
+			wantObjects: []string{"uploads/a.pdf", "public/avatars/b.jpg", "uploads/c.pdf"},
+		},
+		{
+			name:   "dry run deletes nothing",
+			dryRun: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fileRepo := &fakeFileRepo{files: orphanedFiles()}
+			storage := &fakeStorage{failing: tc.failing}
+
+			j, err := NewCleanOrphanedFileJob(config.FileCleanupConfig{DryRun: tc.dryRun, BatchSize: 2}, zap.NewNop(), storage, fileRepo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			j.Run()
+
+			if !slices.Equal(fileRepo.deleted, tc.wantRows) {
+				t.Fatalf("deleted rows %v, want %v", fileRepo.deleted, tc.wantRows)
+			}
+			if !slices.Equal(storage.deleted, tc.wantObjects) {
+				t.Fatalf("deleted objects %v, want %v", storage.deleted, tc.wantObjects)
+			}
+		})
+	}
+}
@@ -39,7 +39,7 @@ func (s *Seeder) adminSeeder(username, password string) error {
 		ID:        id,
 		Username:  username,
 		Email:     "admin@gmail.com",
-		Role:      model.RoleAdmin,
+		Role:      model.RoleChainAdmin,
 		FirstName: "Main",
 		LastName:  "Administrator",
 		Phone:     "0123456789",
@@ -24,6 +24,9 @@ type JWTConfig struct {
 	SecretKey        string        `mapstructure:"secret_key"`
 	AccessExpiresIn  time.Duration `mapstructure:"access_expires_in"`
 	RefreshExpiresIn time.Duration `mapstructure:"refresh_expires_in"`
+	// ImpersonationExpiresIn bounds an impersonation session; it cannot be
+	// refreshed.
+	ImpersonationExpiresIn time.Duration `mapstructure:"impersonation_expires_in"`
 }
 
 type LogConfig struct {
@@ -53,13 +56,15 @@ type RedisConfig struct {
 }
 
 type MinIOConfig struct {
-	Endpoint        string `mapstructure:"endpoint"`
-	AccessKeyID     string `mapstructure:"access_key_id"`
-	SecretAccessKey string `mapstructure:"secret_access_key"`
-	Region          string `mapstructure:"region"`
-	Bucket          string `mapstructure:"bucket"`
-	PublicRead      bool   `mapstructure:"public_read"`
-	UseSSL          bool   `mapstructure:"use_ssl"`
+	Endpoint        string   `mapstructure:"endpoint"`
+	AccessKeyID     string   `mapstructure:"access_key_id"`
+	SecretAccessKey string   `mapstructure:"secret_access_key"`
+	Region          string   `mapstructure:"region"`
+	Bucket          string   `mapstructure:"bucket"`
+	PublicRead      bool     `mapstructure:"public_read"`
+	PublicURL       string   `mapstructure:"public_url"`
+	CORSOrigins     []string `mapstructure:"cors_origins"`
+	UseSSL          bool     `mapstructure:"use_ssl"`
 }
 
 type SuperUserConfig struct {
@@ -77,20 +82,128 @@ type RabbitMQ struct {
 }
 
 type SMTPConfig struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	User     string `mapstructure:"user"`
-	Password string `mapstructure:"password"`
+	Driver      string        `mapstructure:"driver"`
+	Host        string        `mapstructure:"host"`
+	Port        int           `mapstructure:"port"`
+	User        string        `mapstructure:"user"`
+	Password    string        `mapstructure:"password"`
+	From        string        `mapstructure:"from"`
+	FromName    string        `mapstructure:"from_name"`
+	TLSMode     string        `mapstructure:"tls_mode"`
+	Timeout     time.Duration `mapstructure:"timeout"`
+	PoolSize    int           `mapstructure:"pool_size"`
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+	APIURL      string        `mapstructure:"api_url"`
+	APIKey      string        `mapstructure:"api_key"`
+	OutputDir   string        `mapstructure:"output_dir"`
+}
+
+type OTelConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	ServiceName string  `mapstructure:"service_name"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+type PasswordPolicyConfig struct {
+	MinLength     int           `mapstructure:"min_length"`
+	RequireUpper  bool          `mapstructure:"require_upper"`
+	RequireLower  bool          `mapstructure:"require_lower"`
+	RequireDigit  bool          `mapstructure:"require_digit"`
+	RequireSymbol bool          `mapstructure:"require_symbol"`
+	CheckCommon   bool          `mapstructure:"check_common"`
+	HistorySize   int           `mapstructure:"history_size"`
+	MaxAge        time.Duration `mapstructure:"max_age"`
+}
+
+type UploadConfig struct {
+	URLExpiresIn    time.Duration `mapstructure:"url_expires_in"`
+	DailyQuotaBytes int64         `mapstructure:"daily_quota_bytes"`
+	DailyQuotaFiles int64         `mapstructure:"daily_quota_files"`
+	PartSize        int64         `mapstructure:"part_size"`
+	MultipartTTL    time.Duration `mapstructure:"multipart_ttl"`
+}
+
+type FileCleanupConfig struct {
+	Schedule    string        `mapstructure:"schedule"`
+	GracePeriod time.Duration `mapstructure:"grace_period"`
+	BatchSize   int           `mapstructure:"batch_size"`
+	DryRun      bool          `mapstructure:"dry_run"`
+}
+
+type StorageConfig struct {
+	Driver       string `mapstructure:"driver"`
+	LocalRoot    string `mapstructure:"local_root"`
+	LocalBaseURL string `mapstructure:"local_base_url"`
+	SigningKey   string `mapstructure:"signing_key"`
+}
+
+type MultipartCleanupConfig struct {
+	Schedule  string `mapstructure:"schedule"`
+	BatchSize int    `mapstructure:"batch_size"`
+}
+
+type SMSConfig struct {
+	Driver     string        `mapstructure:"driver"`
+	APIURL     string        `mapstructure:"api_url"`
+	APIKey     string        `mapstructure:"api_key"`
+	Sender     string        `mapstructure:"sender"`
+	TemplateID string        `mapstructure:"template_id"`
+	Timeout    time.Duration `mapstructure:"timeout"`
+}
+
+type MagicLinkConfig struct {
+	URL string        `mapstructure:"url"`
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+type OIDCGroupMapping struct {
+	Group        string `mapstructure:"group"`
+	Role         string `mapstructure:"role"`
+	DepartmentID int64  `mapstructure:"department_id"`
+	// PropertyID is required for every role but chain_admin, and the
+	// department must belong to it.
+	PropertyID int64 `mapstructure:"property_id"`
+}
+
+type OIDCConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	IssuerURL    string        `mapstructure:"issuer_url"`
+	ClientID     string        `mapstructure:"client_id"`
+	ClientSecret string        `mapstructure:"client_secret"`
+	RedirectURL  string        `mapstructure:"redirect_url"`
+	FrontendURL  string        `mapstructure:"frontend_url"`
+	Scopes       string        `mapstructure:"scopes"`
+	GroupsClaim  string        `mapstructure:"groups_claim"`
+	JITEnabled   bool          `mapstructure:"jit_enabled"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+	// LinkAdmins lets an admin or chain admin account be linked to an IdP
+	// identity by matching verified email. Off by default, since whoever
+	// controls that address at the IdP would take over the account.
+	LinkAdmins bool `mapstructure:"link_admins"`
+	// GroupMappings are checked in order; the first group the user belongs
+	// to decides the role and department of a just-in-time account.
+	GroupMappings []OIDCGroupMapping `mapstructure:"group_mappings"`
 }
 
 type Config struct {
-	Server     ServerConfig     `mapstructure:"server"`
-	JWT        JWTConfig        `mapstructure:"jwt"`
-	Log        LogConfig        `mapstructure:"log"`
-	PostgreSQL PostgreSQLConfig `mapstructure:"postgresql"`
-	Redis      RedisConfig      `mapstructure:"redis"`
-	MinIO      MinIOConfig      `mapstructure:"minio"`
-	SuperUser  SuperUserConfig  `mapstructure:"super_user"`
-	RabbitMQ   RabbitMQ         `mapstructure:"rabbitmq"`
-	SMTPConfig SMTPConfig       `mapstructure:"smtp"`
+	Server           ServerConfig           `mapstructure:"server"`
+	JWT              JWTConfig              `mapstructure:"jwt"`
+	Log              LogConfig              `mapstructure:"log"`
+	PostgreSQL       PostgreSQLConfig       `mapstructure:"postgresql"`
+	Redis            RedisConfig            `mapstructure:"redis"`
+	MinIO            MinIOConfig            `mapstructure:"minio"`
+	SuperUser        SuperUserConfig        `mapstructure:"super_user"`
+	RabbitMQ         RabbitMQ               `mapstructure:"rabbitmq"`
+	SMTPConfig       SMTPConfig             `mapstructure:"smtp"`
+	OTel             OTelConfig             `mapstructure:"otel"`
+	Password         PasswordPolicyConfig   `mapstructure:"password_policy"`
+	Upload           UploadConfig           `mapstructure:"upload"`
+	FileCleanup      FileCleanupConfig      `mapstructure:"file_cleanup"`
+	Storage          StorageConfig          `mapstructure:"storage"`
+	MultipartCleanup MultipartCleanupConfig `mapstructure:"multipart_cleanup"`
+	SMSConfig        SMSConfig              `mapstructure:"sms"`
+	MagicLink        MagicLinkConfig        `mapstructure:"magic_link"`
+	OIDC             OIDCConfig             `mapstructure:"oidc"`
 }
@@ -43,6 +43,7 @@ func LoadConfig() (*Config, error) {
 	viper.BindEnv("jwt.guest_name", "JWT_GUEST_NAME")
 	viper.BindEnv("jwt.access_expires_in", "JWT_ACCESS_EXPIRES_IN")
 	viper.BindEnv("jwt.refresh_expires_in", "JWT_REFRESH_EXPIRES_IN")
+	viper.BindEnv("jwt.impersonation_expires_in", "JWT_IMPERSONATION_EXPIRES_IN")
 	viper.BindEnv("jwt.secret_key", "JWT_SECRET_KEY")
 
 	viper.BindEnv("minio.endpoint", "MIN_ENDPOINT")
@@ -51,15 +52,27 @@ func LoadConfig() (*Config, error) {
 	viper.BindEnv("minio.bucket", "MIN_BUCKET")
 	viper.BindEnv("minio.region", "MIN_REGION")
 	viper.BindEnv("minio.public_read", "MIN_PUBLIC_READ")
+	viper.BindEnv("minio.public_url", "MIN_PUBLIC_URL")
+	viper.BindEnv("minio.cors_origins", "MIN_CORS_ORIGINS")
 	viper.BindEnv("minio.use_ssl", "MIN_USE_SSL")
 
 	viper.BindEnv("super_user.password", "SU_PASSWORD")
 	viper.BindEnv("super_user.username", "SU_USERNAME")
 
+	viper.BindEnv("smtp.driver", "SMTP_DRIVER")
 	viper.BindEnv("smtp.host", "SMTP_HOST")
 	viper.BindEnv("smtp.port", "SMTP_PORT")
 	viper.BindEnv("smtp.user", "SMTP_USER")
 	viper.BindEnv("smtp.password", "SMTP_PASSWORD")
+	viper.BindEnv("smtp.from", "SMTP_FROM")
+	viper.BindEnv("smtp.from_name", "SMTP_FROM_NAME")
+	viper.BindEnv("smtp.tls_mode", "SMTP_TLS_MODE")
+	viper.BindEnv("smtp.timeout", "SMTP_TIMEOUT")
+	viper.BindEnv("smtp.pool_size", "SMTP_POOL_SIZE")
+	viper.BindEnv("smtp.idle_timeout", "SMTP_IDLE_TIMEOUT")
+	viper.BindEnv("smtp.api_url", "SMTP_API_URL")
+	viper.BindEnv("smtp.api_key", "SMTP_API_KEY")
+	viper.BindEnv("smtp.output_dir", "SMTP_OUTPUT_DIR")
 
 	viper.BindEnv("rabbitmq.host", "RMQ_HOST")
 	viper.BindEnv("rabbitmq.port", "RMQ_PORT")
@@ -68,6 +81,62 @@ func LoadConfig() (*Config, error) {
 	viper.BindEnv("rabbitmq.vhost", "RMQ_VHOST")
 	viper.BindEnv("rabbitmq.use_ssl", "RMQ_USE_SSL")
 
+	viper.BindEnv("otel.enabled", "OTEL_ENABLED")
+	viper.BindEnv("otel.service_name", "OTEL_SERVICE_NAME")
+	viper.BindEnv("otel.endpoint", "OTEL_ENDPOINT")
+	viper.BindEnv("otel.insecure", "OTEL_INSECURE")
+	viper.BindEnv("otel.sample_ratio", "OTEL_SAMPLE_RATIO")
+
+	viper.BindEnv("password_policy.min_length", "PWD_MIN_LENGTH")
+	viper.BindEnv("password_policy.require_upper", "PWD_REQUIRE_UPPER")
+	viper.BindEnv("password_policy.require_lower", "PWD_REQUIRE_LOWER")
+	viper.BindEnv("password_policy.require_digit", "PWD_REQUIRE_DIGIT")
+	viper.BindEnv("password_policy.require_symbol", "PWD_REQUIRE_SYMBOL")
+	viper.BindEnv("password_policy.check_common", "PWD_CHECK_COMMON")
+	viper.BindEnv("password_policy.history_size", "PWD_HISTORY_SIZE")
+	viper.BindEnv("password_policy.max_age", "PWD_MAX_AGE")
+
+	viper.BindEnv("upload.url_expires_in", "UPL_URL_EXPIRES_IN")
+	viper.BindEnv("upload.daily_quota_bytes", "UPL_DAILY_QUOTA_BYTES")
+	viper.BindEnv("upload.daily_quota_files", "UPL_DAILY_QUOTA_FILES")
+	viper.BindEnv("upload.part_size", "UPL_PART_SIZE")
+	viper.BindEnv("upload.multipart_ttl", "UPL_MULTIPART_TTL")
+
+	viper.BindEnv("file_cleanup.schedule", "FCL_SCHEDULE")
+	viper.BindEnv("file_cleanup.grace_period", "FCL_GRACE_PERIOD")
+	viper.BindEnv("file_cleanup.batch_size", "FCL_BATCH_SIZE")
+	viper.BindEnv("file_cleanup.dry_run", "FCL_DRY_RUN")
+
+	viper.BindEnv("storage.driver", "STG_DRIVER")
+	viper.BindEnv("storage.local_root", "STG_LOCAL_ROOT")
+	viper.BindEnv("storage.local_base_url", "STG_LOCAL_BASE_URL")
+	viper.BindEnv("storage.signing_key", "STG_SIGNING_KEY")
+
+	viper.BindEnv("multipart_cleanup.schedule", "MPC_SCHEDULE")
+	viper.BindEnv("multipart_cleanup.batch_size", "MPC_BATCH_SIZE")
+
+	viper.BindEnv("sms.driver", "SMS_DRIVER")
+	viper.BindEnv("sms.api_url", "SMS_API_URL")
+	viper.BindEnv("sms.api_key", "SMS_API_KEY")
+	viper.BindEnv("sms.sender", "SMS_SENDER")
+	viper.BindEnv("sms.template_id", "SMS_TEMPLATE_ID")
+	viper.BindEnv("sms.timeout", "SMS_TIMEOUT")
+
+	viper.BindEnv("magic_link.url", "MAGIC_LINK_URL")
+	viper.BindEnv("magic_link.ttl", "MAGIC_LINK_TTL")
+
+	viper.BindEnv("oidc.enabled", "OIDC_ENABLED")
+	viper.BindEnv("oidc.issuer_url", "OIDC_ISSUER_URL")
+	viper.BindEnv("oidc.client_id", "OIDC_CLIENT_ID")
+	viper.BindEnv("oidc.client_secret", "OIDC_CLIENT_SECRET")
+	viper.BindEnv("oidc.redirect_url", "OIDC_REDIRECT_URL")
+	viper.BindEnv("oidc.frontend_url", "OIDC_FRONTEND_URL")
+	viper.BindEnv("oidc.scopes", "OIDC_SCOPES")
+	viper.BindEnv("oidc.groups_claim", "OIDC_GROUPS_CLAIM")
+	viper.BindEnv("oidc.jit_enabled", "OIDC_JIT_ENABLED")
+	viper.BindEnv("oidc.timeout", "OIDC_TIMEOUT")
+	viper.BindEnv("oidc.link_admins", "OIDC_LINK_ADMINS")
+
 	viper.AddConfigPath("./configs")
 	viper.SetConfigName("config")
 	viper.SetConfigType("yml")
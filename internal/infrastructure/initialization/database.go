@@ -9,9 +9,11 @@ import (
 
 	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/persistence/orm"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
 type Database struct {
@@ -49,6 +51,14 @@ func InitDatabase(cfg config.PostgreSQLConfig) (*Database, error) {
 		return nil, err
 	}
 
+	if err := pg.Use(tracing.NewPlugin(tracing.WithoutMetrics())); err != nil {
+		return nil, err
+	}
+
+	if err := pg.Use(orm.NewTenantPlugin()); err != nil {
+		return nil, err
+	}
+
 	if err := runAutoMigrations(pg); err != nil {
 		return nil, err
 	}
@@ -69,11 +79,154 @@ func (d *Database) Close() {
 }
 
 var allModels = []any{
+	&model.Property{},
 	&model.Department{},
 	&model.User{},
 	&model.Token{},
+	&model.PasswordHistory{},
+	&model.File{},
+	&model.FileVariant{},
+	&model.MultipartUpload{},
+	&model.Notification{},
+	&model.EmailLog{},
+	&model.EmailSuppression{},
+	&model.RoleSetting{},
+	&model.UserIdentity{},
+	&model.ImpersonationSession{},
+	&model.Shift{},
+	&model.ShiftAssignment{},
+}
+
+// AutoMigrate never alters an existing check, so renamed ones are dropped.
+var legacyConstraints = []struct {
+	model any
+	name  string
+}{
+	{&model.File{}, "chk_files_purpose"},
+	{&model.User{}, "chk_users_role"},
+	{&model.RoleSetting{}, "chk_role_settings_role"},
+}
+
+var legacyIndexes = []struct {
+	model any
+	name  string
+}{
+	{&model.Department{}, "departments_name_key"},
+	{&model.Department{}, "departments_phone_key"},
 }
 
+type propertyBackfill struct {
+	model any
+	query string
+}
+
+// propertyBackfills run once, on the migration that adds property_id.
+var propertyBackfills = []propertyBackfill{
+	{&model.Token{}, `UPDATE tokens SET property_id = users.property_id
+		FROM users WHERE users.id = tokens.user_id`},
+	{&model.File{}, `UPDATE files SET property_id = users.property_id
+		FROM users WHERE users.id = files.uploaded_by_id`},
+	{&model.MultipartUpload{}, `UPDATE multipart_uploads SET property_id = files.property_id
+		FROM files WHERE files.id = multipart_uploads.file_id`},
+	{&model.EmailLog{}, `UPDATE email_logs SET property_id = users.property_id
+		FROM users WHERE lower(users.email) = lower(email_logs.recipient)`},
+	{&model.EmailSuppression{}, `UPDATE email_suppressions SET property_id = users.property_id
+		FROM users WHERE lower(users.email) = email_suppressions.email`},
+}
+
+// Rows created before properties existed are moved into this property.
+const (
+	legacyPropertyID   int64 = 1
+	legacyPropertyCode       = "default"
+	legacyPropertyName       = "Default property"
+)
+
 func runAutoMigrations(db *gorm.DB) error {
-	return db.AutoMigrate(allModels...)
+	for _, c := range legacyConstraints {
+		if db.Migrator().HasTable(c.model) && db.Migrator().HasConstraint(c.model, c.name) {
+			if err := db.Migrator().DropConstraint(c.model, c.name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, i := range legacyIndexes {
+		if db.Migrator().HasTable(i.model) && db.Migrator().HasIndex(i.model, i.name) {
+			if err := db.Migrator().DropIndex(i.model, i.name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := backfillLegacyProperty(db); err != nil {
+		return err
+	}
+
+	var pendingBackfills []propertyBackfill
+	for _, b := range propertyBackfills {
+		if db.Migrator().HasTable(b.model) && !db.Migrator().HasColumn(b.model, "PropertyID") {
+			pendingBackfills = append(pendingBackfills, b)
+		}
+	}
+
+	if err := db.AutoMigrate(allModels...); err != nil {
+		return err
+	}
+
+	for _, b := range pendingBackfills {
+		if err := db.Exec(b.query).Error; err != nil {
+			return err
+		}
+	}
+
+	// Accounts from before password expiry start aging from now.
+	return db.Exec("UPDATE users SET password_changed_at = now() WHERE password_changed_at IS NULL").Error
+}
+
+// backfillLegacyProperty must run before AutoMigrate makes
+// departments.property_id NOT NULL.
+func backfillLegacyProperty(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&model.Department{}) {
+		return nil
+	}
+
+	if err := db.AutoMigrate(&model.Property{}); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"departments", "users"} {
+			if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS property_id bigint", table)).Error; err != nil {
+				return err
+			}
+		}
+
+		var pending int64
+		if err := tx.Raw(`SELECT
+			(SELECT count(*) FROM departments WHERE property_id IS NULL) +
+			(SELECT count(*) FROM users WHERE property_id IS NULL AND role <> ?)`, model.RoleChainAdmin).
+			Scan(&pending).Error; err != nil {
+			return err
+		}
+		if pending == 0 {
+			return nil
+		}
+
+		property := model.Property{
+			ID:       legacyPropertyID,
+			Code:     legacyPropertyCode,
+			Name:     legacyPropertyName,
+			IsActive: true,
+		}
+		if err := tx.Where("code = ?", legacyPropertyCode).
+			FirstOrCreate(&property).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("UPDATE departments SET property_id = ? WHERE property_id IS NULL", property.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec("UPDATE users SET property_id = ? WHERE property_id IS NULL AND role <> ?", property.ID, model.RoleChainAdmin).Error
+	})
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/redis/go-redis/v9/maintnotifications"
 )
@@ -29,6 +30,9 @@ func InitRedis(cfg config.RedisConfig) (*redis.Client, error) {
 	}
 
 	rdb := redis.NewClient(options)
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, err
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
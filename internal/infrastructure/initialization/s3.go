@@ -2,15 +2,22 @@ package initialization
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsCfg "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 )
 
 func InitS3(cfg config.MinIOConfig) (*s3.Client, error) {
@@ -39,6 +46,8 @@ func InitS3(cfg config.MinIOConfig) (*s3.Client, error) {
 		return nil, err
 	}
 
+	otelaws.AppendMiddlewares(&aCfg.APIOptions)
+
 	protocol := "http"
 	if cfg.UseSSL {
 		protocol = protocol + "s"
@@ -48,5 +57,139 @@ func InitS3(cfg config.MinIOConfig) (*s3.Client, error) {
 		o.BaseEndpoint = aws.String(fmt.Sprintf("%s://%s", protocol, cfg.Endpoint))
 	})
 
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err = ensureBucket(ctx, client, cfg); err != nil {
+		return nil, err
+	}
+
 	return client, nil
 }
+
+func ensureBucket(ctx context.Context, client *s3.Client, cfg config.MinIOConfig) error {
+	if _, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(cfg.Bucket),
+	}); err != nil {
+		var notFound *types.NotFound
+		if !errors.As(err, &notFound) {
+			return err
+		}
+
+		input := &s3.CreateBucketInput{
+			Bucket: aws.String(cfg.Bucket),
+		}
+		if cfg.Region != "" && cfg.Region != "us-east-1" {
+			input.CreateBucketConfiguration = &types.CreateBucketConfiguration{
+				LocationConstraint: types.BucketLocationConstraint(cfg.Region),
+			}
+		}
+
+		if _, err = client.CreateBucket(ctx, input); err != nil {
+			return err
+		}
+	}
+
+	if err := ensureBucketPolicy(ctx, client, cfg); err != nil {
+		return err
+	}
+
+	return ensureBucketCORS(ctx, client, cfg)
+}
+
+// publicReadSid names the statement ensureBucketPolicy manages; any other
+// statement in the bucket policy belongs to ops and is kept as is.
+const publicReadSid = "PublicRead"
+
+func ensureBucketPolicy(ctx context.Context, client *s3.Client, cfg config.MinIOConfig) error {
+	policy := map[string]any{"Version": "2012-10-17"}
+
+	out, err := client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(cfg.Bucket),
+	})
+	if err = ignoreErrorCodes(err, "NoSuchBucketPolicy", "NotImplemented"); err != nil {
+		return err
+	}
+	if out != nil && out.Policy != nil {
+		if err = json.Unmarshal([]byte(*out.Policy), &policy); err != nil {
+			return fmt.Errorf("parse bucket policy: %w", err)
+		}
+	}
+
+	var statements []any
+	switch stmt := policy["Statement"].(type) {
+	case []any:
+		statements = stmt
+	case map[string]any:
+		statements = []any{stmt}
+	}
+
+	kept := slices.DeleteFunc(slices.Clone(statements), func(stmt any) bool {
+		m, ok := stmt.(map[string]any)
+		return ok && m["Sid"] == publicReadSid
+	})
+
+	if !cfg.PublicRead {
+		if len(kept) == len(statements) {
+			return nil
+		}
+		if len(kept) == 0 {
+			_, err = client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
+				Bucket: aws.String(cfg.Bucket),
+			})
+			return ignoreErrorCodes(err, "NoSuchBucketPolicy", "NotImplemented")
+		}
+	} else {
+		kept = append(kept, map[string]any{
+			"Sid":       publicReadSid,
+			"Effect":    "Allow",
+			"Principal": map[string]any{"AWS": []string{"*"}},
+			"Action":    []string{"s3:GetObject"},
+			"Resource":  []string{fmt.Sprintf("arn:aws:s3:::%s/%s*", cfg.Bucket, constants.StoragePublicPrefix)},
+		})
+	}
+	policy["Statement"] = kept
+
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+		Bucket: aws.String(cfg.Bucket),
+		Policy: aws.String(string(body)),
+	})
+	return err
+}
+
+func ensureBucketCORS(ctx context.Context, client *s3.Client, cfg config.MinIOConfig) error {
+	if len(cfg.CORSOrigins) == 0 {
+		return nil
+	}
+
+	_, err := client.PutBucketCors(ctx, &s3.PutBucketCorsInput{
+		Bucket: aws.String(cfg.Bucket),
+		CORSConfiguration: &types.CORSConfiguration{
+			CORSRules: []types.CORSRule{
+				{
+					AllowedOrigins: cfg.CORSOrigins,
+					AllowedMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost},
+					AllowedHeaders: []string{"*"},
+					ExposeHeaders:  []string{"ETag"},
+					MaxAgeSeconds:  aws.Int32(3600),
+				},
+			},
+		},
+	})
+	return ignoreErrorCodes(err, "NotImplemented")
+}
+
+// ignoreErrorCodes tolerates servers (e.g. single-node MinIO) that do not
+// implement every bucket sub-resource API.
+func ignoreErrorCodes(err error, codes ...string) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && slices.Contains(codes, apiErr.ErrorCode()) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package orm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type emailLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEmailLogRepository(db *gorm.DB) repository.EmailLogRepository {
+	return &emailLogRepositoryImpl{db}
+}
+
+func (r *emailLogRepositoryImpl) Create(ctx context.Context, emailLog *model.EmailLog) error {
+	return r.db.WithContext(ctx).Create(emailLog).Error
+}
+
+func (r *emailLogRepositoryImpl) FindByMessageID(ctx context.Context, messageID string) (*model.EmailLog, error) {
+	var emailLog model.EmailLog
+	if err := r.db.WithContext(ctx).
+		Where("message_id = ?", messageID).
+		First(&emailLog).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &emailLog, nil
+}
+
+func (r *emailLogRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
+	return r.db.WithContext(ctx).
+		Model(&model.EmailLog{}).
+		Where("id = ?", id).
+		Updates(updateData).Error
+}
+
+func (r *emailLogRepositoryImpl) FindAllPaginated(ctx context.Context, query dto.EmailLogPaginationQuery) ([]*model.EmailLog, int64, error) {
+	var emailLogs []*model.EmailLog
+	var total int64
+
+	db := r.db.WithContext(ctx).
+		Model(&model.EmailLog{})
+
+	if query.Recipient != "" {
+		db = db.Where("recipient ILIKE ?", "%"+query.Recipient+"%")
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.Template != "" {
+		db = db.Where("template = ?", query.Template)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return []*model.EmailLog{}, 0, nil
+	}
+
+	offset := (query.Page - 1) * query.Limit
+
+	if err := db.Session(&gorm.Session{}).
+		Order("created_at DESC").
+		Offset(int(offset)).
+		Limit(int(query.Limit)).
+		Find(&emailLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return emailLogs, total, nil
+}
@@ -0,0 +1,97 @@
+package orm
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type emailSuppressionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEmailSuppressionRepository(db *gorm.DB) repository.EmailSuppressionRepository {
+	return &emailSuppressionRepositoryImpl{db}
+}
+
+func (r *emailSuppressionRepositoryImpl) Create(ctx context.Context, suppression *model.EmailSuppression) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "email"}}, DoNothing: true}).
+		Create(suppression).Error
+}
+
+func (r *emailSuppressionRepositoryImpl) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.EmailSuppression{}).
+		Where("email = LOWER(?)", email).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *emailSuppressionRepositoryImpl) FindAllEmailsByEmails(ctx context.Context, emails []string) ([]string, error) {
+	var suppressed []string
+	if err := r.db.WithContext(ctx).
+		Model(&model.EmailSuppression{}).
+		Where("email IN ?", emails).
+		Pluck("email", &suppressed).Error; err != nil {
+		return nil, err
+	}
+
+	return suppressed, nil
+}
+
+func (r *emailSuppressionRepositoryImpl) FindAllPaginated(ctx context.Context, query dto.EmailSuppressionPaginationQuery) ([]*model.EmailSuppression, int64, error) {
+	var suppressions []*model.EmailSuppression
+	var total int64
+
+	db := r.db.WithContext(ctx).
+		Model(&model.EmailSuppression{})
+
+	if query.Search != "" {
+		db = db.Where("email ILIKE ?", "%"+query.Search+"%")
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return []*model.EmailSuppression{}, 0, nil
+	}
+
+	offset := (query.Page - 1) * query.Limit
+
+	if err := db.Session(&gorm.Session{}).
+		Order("created_at DESC").
+		Offset(int(offset)).
+		Limit(int(query.Limit)).
+		Find(&suppressions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return suppressions, total, nil
+}
+
+func (r *emailSuppressionRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&model.EmailSuppression{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrEmailSuppressionNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package orm
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type fileRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewFileRepository(db *gorm.DB) repository.FileRepository {
+	return &fileRepositoryImpl{db}
+}
+
+func (r *fileRepositoryImpl) CreateAll(ctx context.Context, files []*model.File) error {
+	return r.db.WithContext(ctx).Create(files).Error
+}
+
+func (r *fileRepositoryImpl) FindAllByKeys(ctx context.Context, keys []string) ([]*model.File, error) {
+	var files []*model.File
+	if err := r.db.WithContext(ctx).
+		Where("key IN ?", keys).
+		Find(&files).Error; err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (r *fileRepositoryImpl) FindAllByKeysWithVariants(ctx context.Context, keys []string) ([]*model.File, error) {
+	var files []*model.File
+	if err := r.db.WithContext(ctx).
+		Preload("Variants").
+		Where("key IN ?", keys).
+		Find(&files).Error; err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (r *fileRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
+	return r.UpdateTx(r.db.WithContext(ctx), id, updateData)
+}
+
+func (r *fileRepositoryImpl) UpdateTx(tx *gorm.DB, id int64, updateData map[string]any) error {
+	result := tx.Model(&model.File{}).Where("id = ?", id).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return customErr.ErrFileNotFound
+	}
+
+	return nil
+}
+
+func (r *fileRepositoryImpl) UpdateAllByEntityTx(tx *gorm.DB, entityType string, entityID int64, purpose model.FilePurpose, updateData map[string]any) error {
+	return tx.Model(&model.File{}).
+		Where("entity_type = ? AND entity_id = ? AND purpose = ?", entityType, entityID, purpose).
+		Updates(updateData).Error
+}
+
+func (r *fileRepositoryImpl) FindAllUnlinkedBefore(ctx context.Context, before time.Time, afterID int64, limit int) ([]*model.File, error) {
+	var files []*model.File
+	if err := r.db.WithContext(ctx).
+		Preload("Variants").
+		Where("entity_type IS NULL AND created_at < ? AND id > ?", before, afterID).
+		// Only avatars are ever linked to an entity, so other confirmed
+		// uploads are kept; a detached avatar is one that was replaced.
+		Where("status = ? OR purpose = ?", model.FileStatusPending, model.FilePurposeAvatar).
+		Where("NOT EXISTS (SELECT 1 FROM multipart_uploads WHERE multipart_uploads.file_id = files.id)").
+		Order("id ASC").
+		Limit(limit).
+		Find(&files).Error; err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (r *fileRepositoryImpl) DeleteAllByIDs(ctx context.Context, ids []int64) (int64, error) {
+	result := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&model.File{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
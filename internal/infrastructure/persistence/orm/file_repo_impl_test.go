@@ -0,0 +1,61 @@
+package orm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB builds statements without a database and hands the first query's
+// SQL and bound values to capture.
+func dryRunDB(t *testing.T, capture func(sql string, vars []any)) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	captured := false
+	if err = db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		if !captured {
+			captured = true
+			capture(tx.Statement.SQL.String(), tx.Statement.Vars)
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestFindAllUnlinkedBeforeOnlyTargetsPendingUploadsAndAvatars(t *testing.T) {
+	var query string
+	var vars []any
+	db := dryRunDB(t, func(sql string, v []any) { query, vars = sql, v })
+
+	if _, err := NewFileRepository(db).FindAllUnlinkedBefore(context.Background(), time.Now(), 0, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"entity_type IS NULL",
+		"(status = $3 OR purpose = $4)",
+		"NOT EXISTS (SELECT 1 FROM multipart_uploads",
+	} {
+		if !strings.Contains(query, want) {
+			t.Fatalf("query %q does not contain %q", query, want)
+		}
+	}
+	if len(vars) < 4 || vars[2] != model.FileStatusPending || vars[3] != model.FilePurposeAvatar {
+		t.Fatalf("vars = %v, want pending status and avatar purpose", vars)
+	}
+}
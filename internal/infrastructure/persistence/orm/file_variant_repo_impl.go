@@ -0,0 +1,27 @@
+package orm
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type fileVariantRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewFileVariantRepository(db *gorm.DB) repository.FileVariantRepository {
+	return &fileVariantRepositoryImpl{db}
+}
+
+func (r *fileVariantRepositoryImpl) CreateAll(ctx context.Context, variants []*model.FileVariant) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "file_id"}, {Name: "size"}, {Name: "format"}},
+			DoUpdates: clause.AssignmentColumns([]string{"key", "content_type", "width", "height", "bytes"}),
+		}).
+		Create(variants).Error
+}
@@ -0,0 +1,81 @@
+package orm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type impersonationSessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewImpersonationSessionRepository(db *gorm.DB) repository.ImpersonationSessionRepository {
+	return &impersonationSessionRepositoryImpl{db}
+}
+
+func (r *impersonationSessionRepositoryImpl) Create(ctx context.Context, session *model.ImpersonationSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *impersonationSessionRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.ImpersonationSession, error) {
+	var session model.ImpersonationSession
+	if err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *impersonationSessionRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
+	return r.db.WithContext(ctx).
+		Model(&model.ImpersonationSession{}).
+		Where("id = ?", id).
+		Updates(updateData).Error
+}
+
+func (r *impersonationSessionRepositoryImpl) FindAllPaginated(ctx context.Context, query dto.ImpersonationSessionPaginationQuery) ([]*model.ImpersonationSession, int64, error) {
+	var sessions []*model.ImpersonationSession
+	var total int64
+
+	db := r.db.WithContext(ctx).
+		Model(&model.ImpersonationSession{})
+
+	if query.AdminID != 0 {
+		db = db.Where("admin_id = ?", query.AdminID)
+	}
+	if query.UserID != 0 {
+		db = db.Where("user_id = ?", query.UserID)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return []*model.ImpersonationSession{}, 0, nil
+	}
+
+	offset := (query.Page - 1) * query.Limit
+
+	if err := db.Session(&gorm.Session{}).
+		Preload("Admin").
+		Preload("User").
+		Order("created_at DESC").
+		Offset(int(offset)).
+		Limit(int(query.Limit)).
+		Find(&sessions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return sessions, total, nil
+}
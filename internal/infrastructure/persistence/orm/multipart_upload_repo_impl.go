@@ -0,0 +1,71 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type multipartUploadRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMultipartUploadRepository(db *gorm.DB) repository.MultipartUploadRepository {
+	return &multipartUploadRepositoryImpl{db}
+}
+
+// Create also inserts upload.File; GORM saves the association in the same
+// transaction.
+func (r *multipartUploadRepositoryImpl) Create(ctx context.Context, upload *model.MultipartUpload) error {
+	return r.db.WithContext(ctx).Create(upload).Error
+}
+
+func (r *multipartUploadRepositoryImpl) FindByFileKeyWithFile(ctx context.Context, key string) (*model.MultipartUpload, error) {
+	var upload model.MultipartUpload
+	if err := r.db.WithContext(ctx).
+		Joins("File").
+		Where(`"File".key = ?`, key).
+		First(&upload).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+func (r *multipartUploadRepositoryImpl) FindAllByUploaderIDWithFile(ctx context.Context, userID int64) ([]*model.MultipartUpload, error) {
+	var uploads []*model.MultipartUpload
+	if err := r.db.WithContext(ctx).
+		Joins("File").
+		Where(`"File".uploaded_by_id = ? AND multipart_uploads.expires_at > ?`, userID, time.Now()).
+		Order("multipart_uploads.created_at DESC").
+		Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
+func (r *multipartUploadRepositoryImpl) FindAllExpiredWithFile(ctx context.Context, before time.Time, afterID int64, limit int) ([]*model.MultipartUpload, error) {
+	var uploads []*model.MultipartUpload
+	if err := r.db.WithContext(ctx).
+		Joins("File").
+		Where("multipart_uploads.expires_at < ? AND multipart_uploads.id > ?", before, afterID).
+		Order("multipart_uploads.id ASC").
+		Limit(limit).
+		Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
+func (r *multipartUploadRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.MultipartUpload{}).Error
+}
@@ -0,0 +1,102 @@
+package orm
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type notificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) repository.NotificationRepository {
+	return &notificationRepositoryImpl{db}
+}
+
+func (r *notificationRepositoryImpl) CreateAll(ctx context.Context, notifications []*model.Notification) error {
+	return r.db.WithContext(ctx).CreateInBatches(notifications, 500).Error
+}
+
+func (r *notificationRepositoryImpl) FindAllByRecipientIDPaginated(ctx context.Context, recipientID int64, query dto.NotificationPaginationQuery) ([]*model.Notification, int64, error) {
+	var notifications []*model.Notification
+	var total int64
+
+	db := r.db.WithContext(ctx).
+		Model(&model.Notification{}).
+		Where("recipient_id = ?", recipientID)
+
+	if query.Unread != nil {
+		if *query.Unread {
+			db = db.Where("read_at IS NULL")
+		} else {
+			db = db.Where("read_at IS NOT NULL")
+		}
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return []*model.Notification{}, 0, nil
+	}
+
+	offset := (query.Page - 1) * query.Limit
+
+	if err := db.Session(&gorm.Session{}).
+		Order("created_at DESC").
+		Order("id DESC").
+		Offset(int(offset)).
+		Limit(int(query.Limit)).
+		Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, total, nil
+}
+
+func (r *notificationRepositoryImpl) CountUnreadByRecipientID(ctx context.Context, recipientID int64) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.Notification{}).
+		Where("recipient_id = ? AND read_at IS NULL", recipientID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *notificationRepositoryImpl) MarkReadByIDAndRecipientID(ctx context.Context, id, recipientID int64) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.Notification{}).
+		Where("id = ? AND recipient_id = ?", id, recipientID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now()))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (r *notificationRepositoryImpl) MarkAllReadByRecipientID(ctx context.Context, recipientID int64) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&model.Notification{}).
+		Where("recipient_id = ? AND read_at IS NULL", recipientID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,45 @@
+package orm
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type passwordHistoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPasswordHistoryRepository(db *gorm.DB) repository.PasswordHistoryRepository {
+	return &passwordHistoryRepositoryImpl{db}
+}
+
+func (r *passwordHistoryRepositoryImpl) CreateTx(tx *gorm.DB, history *model.PasswordHistory) error {
+	return tx.Create(history).Error
+}
+
+func (r *passwordHistoryRepositoryImpl) FindRecentByUserID(ctx context.Context, userID int64, limit int) ([]*model.PasswordHistory, error) {
+	var histories []*model.PasswordHistory
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&histories).Error; err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+func (r *passwordHistoryRepositoryImpl) DeleteAllExceptRecentTx(tx *gorm.DB, userID int64, keep int) error {
+	recent := tx.Model(&model.PasswordHistory{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(keep)
+
+	return tx.Where("user_id = ? AND id NOT IN (?)", userID, recent).
+		Delete(&model.PasswordHistory{}).Error
+}
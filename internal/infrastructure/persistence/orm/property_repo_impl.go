@@ -0,0 +1,78 @@
+package orm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type propertyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPropertyRepository(db *gorm.DB) repository.PropertyRepository {
+	return &propertyRepositoryImpl{db}
+}
+
+func (r *propertyRepositoryImpl) Create(ctx context.Context, property *model.Property) error {
+	return r.db.WithContext(ctx).Create(property).Error
+}
+
+func (r *propertyRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.Property, error) {
+	var property model.Property
+	if err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&property).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &property, nil
+}
+
+func (r *propertyRepositoryImpl) FindByCode(ctx context.Context, code string) (*model.Property, error) {
+	var property model.Property
+	if err := r.db.WithContext(ctx).
+		Where("code = ?", code).
+		First(&property).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &property, nil
+}
+
+func (r *propertyRepositoryImpl) FindAll(ctx context.Context) ([]*model.Property, error) {
+	var properties []*model.Property
+	if err := r.db.WithContext(ctx).
+		Order("name ASC").
+		Find(&properties).Error; err != nil {
+		return nil, err
+	}
+
+	return properties, nil
+}
+
+func (r *propertyRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.Property{}).
+		Where("id = ?", id).
+		Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrPropertyNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package orm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type roleSettingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRoleSettingRepository(db *gorm.DB) repository.RoleSettingRepository {
+	return &roleSettingRepositoryImpl{db}
+}
+
+func (r *roleSettingRepositoryImpl) FindAll(ctx context.Context) ([]*model.RoleSetting, error) {
+	var settings []*model.RoleSetting
+	if err := r.db.WithContext(ctx).
+		Order("role ASC").
+		Find(&settings).Error; err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (r *roleSettingRepositoryImpl) FindByRole(ctx context.Context, role model.UserRole) (*model.RoleSetting, error) {
+	var setting model.RoleSetting
+	if err := r.db.WithContext(ctx).
+		Where("role = ?", role).
+		First(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &setting, nil
+}
+
+func (r *roleSettingRepositoryImpl) Upsert(ctx context.Context, setting *model.RoleSetting) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "role"}},
+			DoUpdates: clause.AssignmentColumns([]string{"magic_link_enabled", "updated_by_id", "updated_at"}),
+		}).
+		Create(setting).Error
+}
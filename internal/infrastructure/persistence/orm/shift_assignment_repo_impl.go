@@ -0,0 +1,76 @@
+package orm
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type shiftAssignmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewShiftAssignmentRepository(db *gorm.DB) repository.ShiftAssignmentRepository {
+	return &shiftAssignmentRepositoryImpl{db}
+}
+
+func (r *shiftAssignmentRepositoryImpl) CreateTx(tx *gorm.DB, assignment *model.ShiftAssignment) error {
+	return tx.Create(assignment).Error
+}
+
+func (r *shiftAssignmentRepositoryImpl) FindAllByShiftIDTx(tx *gorm.DB, shiftID int64) ([]*model.ShiftAssignment, error) {
+	var assignments []*model.ShiftAssignment
+	if err := tx.Where("shift_id = ?", shiftID).
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+func (r *shiftAssignmentRepositoryImpl) FindAllOverlappingTx(tx *gorm.DB, userIDs []int64, startsAt, endsAt time.Time, excludeShiftID int64) ([]*model.ShiftAssignment, error) {
+	var assignments []*model.ShiftAssignment
+	if err := tx.InnerJoins("Shift").
+		Where("shift_assignments.user_id IN ?", userIDs).
+		Where(`"Shift".id <> ? AND "Shift".starts_at < ? AND "Shift".ends_at > ?`, excludeShiftID, endsAt, startsAt).
+		Order(`"Shift".starts_at ASC`).
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+func (r *shiftAssignmentRepositoryImpl) FindAllOnDuty(ctx context.Context, departmentID int64, at time.Time) ([]*model.ShiftAssignment, error) {
+	var assignments []*model.ShiftAssignment
+	if err := r.db.WithContext(ctx).
+		InnerJoins("Shift").
+		InnerJoins("User").
+		Where(`"Shift".department_id = ? AND "Shift".starts_at <= ? AND "Shift".ends_at > ?`, departmentID, at, at).
+		Where(`"User".is_active = true`).
+		Order(`"Shift".ends_at ASC`).
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+func (r *shiftAssignmentRepositoryImpl) DeleteByIDAndShiftID(ctx context.Context, id, shiftID int64) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND shift_id = ?", id, shiftID).
+		Delete(&model.ShiftAssignment{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrShiftAssignmentNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/dto"
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type shiftRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewShiftRepository(db *gorm.DB) repository.ShiftRepository {
+	return &shiftRepositoryImpl{db}
+}
+
+func (r *shiftRepositoryImpl) Create(ctx context.Context, shift *model.Shift) error {
+	return r.db.WithContext(ctx).Create(shift).Error
+}
+
+func (r *shiftRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.Shift, error) {
+	return r.findByIDBase(r.db.WithContext(ctx), id)
+}
+
+func (r *shiftRepositoryImpl) FindByIDWithDetails(ctx context.Context, id int64) (*model.Shift, error) {
+	return r.findByIDBase(r.db.WithContext(ctx), id,
+		Preload{Relation: "Department", Scope: func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name")
+		}},
+		Preload{Relation: "Assignments", Scope: func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}},
+		Preload{Relation: "Assignments.User"},
+	)
+}
+
+func (r *shiftRepositoryImpl) FindByIDForUpdateTx(tx *gorm.DB, id int64) (*model.Shift, error) {
+	return r.findByIDBase(tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), id)
+}
+
+func (r *shiftRepositoryImpl) FindAllPaginated(ctx context.Context, query dto.ShiftPaginationQuery) ([]*model.Shift, int64, error) {
+	var shifts []*model.Shift
+	var total int64
+
+	db := r.db.WithContext(ctx).
+		Model(&model.Shift{})
+
+	if query.DepartmentID != 0 {
+		db = db.Where("department_id = ?", query.DepartmentID)
+	}
+	if query.UserID != 0 {
+		db = db.Where("EXISTS (SELECT 1 FROM shift_assignments WHERE shift_assignments.shift_id = shifts.id AND shift_assignments.user_id = ?)", query.UserID)
+	}
+	if !query.From.IsZero() {
+		db = db.Where("ends_at > ?", query.From)
+	}
+	if !query.To.IsZero() {
+		db = db.Where("starts_at < ?", query.To)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return []*model.Shift{}, 0, nil
+	}
+
+	offset := (query.Page - 1) * query.Limit
+
+	if err := db.Session(&gorm.Session{}).
+		Preload("Department", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name")
+		}).
+		Order("starts_at ASC").
+		Offset(int(offset)).
+		Limit(int(query.Limit)).
+		Find(&shifts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return shifts, total, nil
+}
+
+func (r *shiftRepositoryImpl) FindAllWithAssignmentsBetween(ctx context.Context, departmentID int64, from, to time.Time) ([]*model.Shift, error) {
+	var shifts []*model.Shift
+
+	db := r.db.WithContext(ctx).
+		Where("starts_at < ? AND ends_at > ?", to, from)
+
+	if departmentID != 0 {
+		db = db.Where("department_id = ?", departmentID)
+	}
+
+	if err := db.Preload("Department", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "name")
+	}).
+		Preload("Assignments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Assignments.User").
+		Order("starts_at ASC").
+		Find(&shifts).Error; err != nil {
+		return nil, err
+	}
+
+	return shifts, nil
+}
+
+func (r *shiftRepositoryImpl) UpdateTx(tx *gorm.DB, id int64, updateData map[string]any) error {
+	result := tx.Model(&model.Shift{}).
+		Where("id = ?", id).
+		Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrShiftNotFound
+	}
+
+	return nil
+}
+
+func (r *shiftRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&model.Shift{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrShiftNotFound
+	}
+
+	return nil
+}
+
+func (r *shiftRepositoryImpl) findByIDBase(tx *gorm.DB, id int64, preloads ...Preload) (*model.Shift, error) {
+	var shift model.Shift
+
+	for _, preload := range preloads {
+		if preload.Scope != nil {
+			tx = tx.Preload(preload.Relation, preload.Scope)
+		} else {
+			tx = tx.Preload(preload.Relation)
+		}
+	}
+
+	if err := tx.Where("id = ?", id).
+		First(&shift).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &shift, nil
+}
@@ -0,0 +1,101 @@
+package orm
+
+import (
+	"reflect"
+
+	"github.com/InstaySystem/is_v2-be/pkg/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const tenantField = "PropertyID"
+
+type tenantPlugin struct{}
+
+// NewTenantPlugin scopes every model with a PropertyID field to the property
+// carried by the statement context: reads, updates and deletes get a
+// property_id filter, and creates are stamped with the property. Statements
+// whose context has no property (see tenant.PropertyID) are left untouched,
+// as are raw SQL statements.
+func NewTenantPlugin() gorm.Plugin {
+	return &tenantPlugin{}
+}
+
+func (p *tenantPlugin) Name() string {
+	return "tenant"
+}
+
+func (p *tenantPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", assignTenant); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", TenantScope); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", TenantScope); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant:delete", TenantScope); err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("tenant:row", TenantScope)
+}
+
+// TenantScope adds the property filter to db when its model is property
+// scoped. It is registered as a callback by NewTenantPlugin, so repositories
+// never call it directly.
+func TenantScope(db *gorm.DB) {
+	field, propertyID := tenantTarget(db)
+	if field == nil {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: propertyID},
+	}})
+}
+
+func assignTenant(db *gorm.DB) {
+	field, propertyID := tenantTarget(db)
+	if field == nil {
+		return
+	}
+
+	ctx := db.Statement.Context
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elem := reflect.Indirect(rv.Index(i))
+			if err := field.Set(ctx, elem, &propertyID); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(ctx, rv, &propertyID); err != nil {
+			db.AddError(err)
+		}
+	}
+}
+
+func tenantTarget(db *gorm.DB) (*schema.Field, int64) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil, 0
+	}
+
+	propertyID := tenant.PropertyID(db.Statement.Context)
+	if propertyID == 0 {
+		return nil, 0
+	}
+
+	field := db.Statement.Schema.LookUpField(tenantField)
+	if field == nil {
+		return nil, 0
+	}
+
+	return field, propertyID
+}
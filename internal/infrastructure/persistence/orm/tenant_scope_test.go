@@ -0,0 +1,67 @@
+package orm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+func TestTenantPluginScopesQueries(t *testing.T) {
+	scoped := tenant.WithContext(context.Background(), tenant.Tenant{PropertyID: 7})
+
+	for _, tc := range []struct {
+		name       string
+		ctx        context.Context
+		model      any
+		wantFilter bool
+	}{
+		{"property scoped model", scoped, &[]*model.Shift{}, true},
+		{"model without property", scoped, &[]*model.Property{}, false},
+		{"unscoped context", tenant.Unscoped(scoped), &[]*model.Shift{}, false},
+		{"chain admin without a property", tenant.WithContext(context.Background(), tenant.Tenant{ChainAdmin: true}), &[]*model.Shift{}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var query string
+			var vars []any
+			db := dryRunDB(t, func(sql string, v []any) { query, vars = sql, v })
+			if err := db.Use(NewTenantPlugin()); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := db.WithContext(tc.ctx).Find(tc.model).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			hasFilter := strings.Contains(query, `"property_id" = $1`)
+			if hasFilter != tc.wantFilter {
+				t.Fatalf("query %q: property filter = %v, want %v", query, hasFilter, tc.wantFilter)
+			}
+			if tc.wantFilter && (len(vars) != 1 || vars[0] != int64(7)) {
+				t.Fatalf("vars = %v, want [7]", vars)
+			}
+		})
+	}
+}
+
+func TestTenantPluginStampsCreates(t *testing.T) {
+	db := dryRunDB(t, func(string, []any) {})
+	if err := db.Use(NewTenantPlugin()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := tenant.WithContext(context.Background(), tenant.Tenant{PropertyID: 7})
+	shifts := []*model.Shift{{ID: 1}, {ID: 2}}
+	if err := db.Session(&gorm.Session{SkipDefaultTransaction: true}).WithContext(ctx).Create(shifts).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, shift := range shifts {
+		if shift.PropertyID == nil || *shift.PropertyID != 7 {
+			t.Fatalf("shift %d PropertyID = %v, want 7", shift.ID, shift.PropertyID)
+		}
+	}
+}
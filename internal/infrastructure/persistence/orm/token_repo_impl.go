@@ -59,6 +59,19 @@ func (r *tokenRepositoryImpl) FindByToken(ctx context.Context, hashedToken strin
 	return &token, nil
 }
 
+func (r *tokenRepositoryImpl) FindAllUserAgentsByUserID(ctx context.Context, userID int64) ([]string, error) {
+	var userAgents []string
+	if err := r.db.WithContext(ctx).
+		Model(&model.Token{}).
+		Where("user_id = ?", userID).
+		Distinct().
+		Pluck("user_agent", &userAgents).Error; err != nil {
+		return nil, err
+	}
+
+	return userAgents, nil
+}
+
 func (r *tokenRepositoryImpl) DeleteAllByUserIDTx(tx *gorm.DB, userID int64) error {
 	return tx.Where("user_id = ?", userID).
 		Delete(&model.Token{}).Error
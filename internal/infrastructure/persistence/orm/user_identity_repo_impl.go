@@ -0,0 +1,47 @@
+package orm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/InstaySystem/is_v2-be/internal/domain/model"
+	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type userIdentityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) repository.UserIdentityRepository {
+	return &userIdentityRepositoryImpl{db}
+}
+
+func (r *userIdentityRepositoryImpl) FindByIssuerAndSubject(ctx context.Context, issuer, subject string) (*model.UserIdentity, error) {
+	var identity model.UserIdentity
+	if err := r.db.WithContext(ctx).
+		Where("issuer = ? AND subject = ?", issuer, subject).
+		First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+func (r *userIdentityRepositoryImpl) Create(ctx context.Context, identity *model.UserIdentity) error {
+	return r.db.WithContext(ctx).Create(identity).Error
+}
+
+func (r *userIdentityRepositoryImpl) CreateTx(tx *gorm.DB, identity *model.UserIdentity) error {
+	return tx.Create(identity).Error
+}
+
+func (r *userIdentityRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
+	return r.db.WithContext(ctx).
+		Model(&model.UserIdentity{}).
+		Where("id = ?", id).
+		Updates(updateData).Error
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
 	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 	"github.com/InstaySystem/is_v2-be/internal/domain/repository"
 	customErr "github.com/InstaySystem/is_v2-be/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Preload struct {
@@ -17,6 +19,8 @@ type Preload struct {
 	Scope    func(*gorm.DB) *gorm.DB
 }
 
+var adminRoles = []model.UserRole{model.RoleAdmin, model.RoleChainAdmin}
+
 type userRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -29,6 +33,10 @@ func (r *userRepositoryImpl) Create(ctx context.Context, user *model.User) error
 	return r.db.WithContext(ctx).Create(user).Error
 }
 
+func (r *userRepositoryImpl) CreateTx(tx *gorm.DB, user *model.User) error {
+	return tx.Create(user).Error
+}
+
 func (r *userRepositoryImpl) FindByUsernameWithDepartment(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
 	if err := r.db.WithContext(ctx).
@@ -74,6 +82,20 @@ func (r *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*mo
 	return &user, nil
 }
 
+func (r *userRepositoryImpl) FindByPhone(ctx context.Context, phone string) (*model.User, error) {
+	var user model.User
+	if err := r.db.WithContext(ctx).
+		Where("phone = ?", phone).
+		First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 func (r *userRepositoryImpl) UpdateTx(tx *gorm.DB, id int64, updateData map[string]any) error {
 	result := tx.Model(&model.User{}).
 		Where("id = ?", id).
@@ -121,7 +143,7 @@ func (r *userRepositoryImpl) ExistsActiveAdminExceptID(ctx context.Context, id i
 	var count int64
 	if err := r.db.WithContext(ctx).
 		Model(&model.User{}).
-		Where("role = ? AND is_active = true AND id <> ?", model.RoleAdmin, id).
+		Where("role IN ? AND is_active = true AND id <> ?", adminRoles, id).
 		Count(&count).Error; err != nil {
 		return false, err
 	}
@@ -133,7 +155,7 @@ func (r *userRepositoryImpl) ExistsActiveAdmin(ctx context.Context) (bool, error
 	var count int64
 	if err := r.db.WithContext(ctx).
 		Model(&model.User{}).
-		Where("role = ? AND is_active = true", model.RoleAdmin).
+		Where("role IN ? AND is_active = true", adminRoles).
 		Count(&count).Error; err != nil {
 		return false, err
 	}
@@ -155,14 +177,20 @@ func (r *userRepositoryImpl) DeleteTx(tx *gorm.DB, id int64) error {
 	return nil
 }
 
-func (r *userRepositoryImpl) DeleteAllByIDsTx(tx *gorm.DB, ids []int64) (int64, error) {
-	result := tx.Where("id IN ?", ids).
-		Delete(&model.User{})
-	if result.Error != nil {
-		return 0, result.Error
+func (r *userRepositoryImpl) DeleteAllByIDsTx(tx *gorm.DB, ids []int64) ([]int64, error) {
+	var users []*model.User
+	if err := tx.Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("id IN ?", ids).
+		Delete(&users).Error; err != nil {
+		return nil, err
 	}
 
-	return result.RowsAffected, nil
+	deletedIDs := make([]int64, 0, len(users))
+	for _, user := range users {
+		deletedIDs = append(deletedIDs, user.ID)
+	}
+
+	return deletedIDs, nil
 }
 
 func (r *userRepositoryImpl) FindAllWithDepartmentPaginated(ctx context.Context, query dto.UserPaginationQuery) ([]*model.User, int64, error) {
@@ -267,3 +295,55 @@ func (r *userRepositoryImpl) applySorting(db *gorm.DB, query dto.UserPaginationQ
 
 	return db.Order(sortField + " " + order)
 }
+
+func (r *userRepositoryImpl) FindAllActiveByIDs(ctx context.Context, ids []int64) ([]*model.User, error) {
+	var users []*model.User
+	if err := r.db.WithContext(ctx).
+		Select("id", "email", "locale", "email_notifications", "property_id").
+		Where("id IN ? AND is_active = true", ids).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *userRepositoryImpl) FindAllActiveByDepartmentID(ctx context.Context, departmentID int64) ([]*model.User, error) {
+	var users []*model.User
+	if err := r.db.WithContext(ctx).
+		Select("id", "email", "locale", "email_notifications", "property_id").
+		Where("department_id = ? AND is_active = true", departmentID).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *userRepositoryImpl) FindAllActiveOnDutyByDepartmentID(ctx context.Context, departmentID int64, at time.Time) ([]*model.User, error) {
+	var users []*model.User
+	if err := r.db.WithContext(ctx).
+		Select("users.id", "users.email", "users.locale", "users.email_notifications", "users.property_id").
+		Joins("JOIN shift_assignments ON shift_assignments.user_id = users.id").
+		Joins("JOIN shifts ON shifts.id = shift_assignments.shift_id").
+		Where("shifts.department_id = ? AND shifts.starts_at <= ? AND shifts.ends_at > ?", departmentID, at, at).
+		Where("users.is_active = true").
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *userRepositoryImpl) FindAllByIDsForUpdateTx(tx *gorm.DB, ids []int64) ([]*model.User, error) {
+	var users []*model.User
+	if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Select("id", "department_id", "is_active").
+		Where("id IN ?", ids).
+		Order("id ASC").
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
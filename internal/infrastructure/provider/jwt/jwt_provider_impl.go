@@ -14,8 +14,17 @@ import (
 
 type CustomClaims struct {
 	jwt.RegisteredClaims
+	PropertyID   int64          `json:"property_id,omitempty"`
 	Role         model.UserRole `json:"role"`
 	TokenVersion int            `json:"token_version"`
+	Act          *ActorClaims   `json:"act,omitempty"`
+}
+
+// ActorClaims follows the RFC 8693 "act" claim: Subject is the admin the
+// token was issued to, and SessionID points at the impersonation audit row.
+type ActorClaims struct {
+	Subject   string `json:"sub"`
+	SessionID string `json:"sid"`
 }
 
 type jwtProviderImpl struct {
@@ -26,22 +35,35 @@ func NewJWTProvider(cfg config.JWTConfig) port.JWTProvider {
 	return &jwtProviderImpl{cfg}
 }
 
-func (p *jwtProviderImpl) GenerateToken(userID int64, role model.UserRole, tokenVersion int, ttl time.Duration) (string, error) {
+func (p *jwtProviderImpl) GenerateToken(userID, propertyID int64, role model.UserRole, tokenVersion int, ttl time.Duration) (string, error) {
+	return p.signToken(userID, propertyID, role, tokenVersion, nil, ttl)
+}
+
+func (p *jwtProviderImpl) GenerateImpersonationToken(userID, propertyID int64, role model.UserRole, tokenVersion int, actor port.TokenActor, ttl time.Duration) (string, error) {
+	return p.signToken(userID, propertyID, role, tokenVersion, &ActorClaims{
+		Subject:   strconv.FormatInt(actor.UserID, 10),
+		SessionID: strconv.FormatInt(actor.SessionID, 10),
+	}, ttl)
+}
+
+func (p *jwtProviderImpl) signToken(userID, propertyID int64, role model.UserRole, tokenVersion int, act *ActorClaims, ttl time.Duration) (string, error) {
 	claims := CustomClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   strconv.FormatInt(userID, 10),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
+		PropertyID:   propertyID,
 		Role:         role,
 		TokenVersion: tokenVersion,
+		Act:          act,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(p.cfg.SecretKey))
 }
 
-func (p *jwtProviderImpl) ParseToken(tokenStr string) (int64, model.UserRole, int, time.Duration, error) {
+func (p *jwtProviderImpl) ParseToken(tokenStr string) (*port.TokenClaims, error) {
 	claims := &CustomClaims{}
 
 	token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (any, error) {
@@ -52,15 +74,33 @@ func (p *jwtProviderImpl) ParseToken(tokenStr string) (int64, model.UserRole, in
 	})
 
 	if err != nil || !token.Valid {
-		return 0, "", 0, 0, errors.ErrInvalidToken
+		return nil, errors.ErrInvalidToken
 	}
 
 	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
 	if err != nil {
-		return 0, "", 0, 0, errors.ErrInvalidToken
+		return nil, errors.ErrInvalidToken
 	}
 
-	ttl := time.Until(claims.ExpiresAt.Time)
+	var actor *port.TokenActor
+	if claims.Act != nil {
+		actorID, err := strconv.ParseInt(claims.Act.Subject, 10, 64)
+		if err != nil {
+			return nil, errors.ErrInvalidToken
+		}
+		sessionID, err := strconv.ParseInt(claims.Act.SessionID, 10, 64)
+		if err != nil {
+			return nil, errors.ErrInvalidToken
+		}
+		actor = &port.TokenActor{UserID: actorID, SessionID: sessionID}
+	}
 
-	return userID, claims.Role, claims.TokenVersion, ttl, nil
+	return &port.TokenClaims{
+		UserID:       userID,
+		PropertyID:   claims.PropertyID,
+		Role:         claims.Role,
+		TokenVersion: claims.TokenVersion,
+		TTL:          time.Until(claims.ExpiresAt.Time),
+		Actor:        actor,
+	}, nil
 }
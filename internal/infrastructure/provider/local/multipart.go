@@ -0,0 +1,245 @@
+package local
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+)
+
+const (
+	multipartDir     = ".multipart"
+	multipartInfo    = "upload.json"
+	maxPartNumber    = 10000
+	uploadIDByteSize = 16
+)
+
+type multipartUpload struct {
+	Key         string `json:"key"`
+	ContentType string `json:"content_type"`
+}
+
+func (s *Storage) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+
+	raw := make([]byte, uploadIDByteSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	uploadID := hex.EncodeToString(raw)
+
+	dir := s.uploadDir(uploadID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	info, err := json.Marshal(multipartUpload{key, contentType})
+	if err != nil {
+		return "", err
+	}
+	if err = os.WriteFile(filepath.Join(dir, multipartInfo), info, 0o644); err != nil {
+		return "", err
+	}
+
+	return uploadID, nil
+}
+
+func (s *Storage) PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, size int64, ttl time.Duration) (string, error) {
+	if _, err := s.loadUpload(key, uploadID); err != nil {
+		return "", err
+	}
+
+	token, err := s.signToken(Claims{
+		Key:        key,
+		Op:         OpUploadPart,
+		MaxSize:    size,
+		UploadID:   uploadID,
+		PartNumber: partNumber,
+		ExpiresAt:  time.Now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/storage/parts?token=%s", s.baseURL, url.QueryEscape(token)), nil
+}
+
+// WritePart stores one part of a multipart upload and returns its ETag,
+// quoted like S3 does.
+func (s *Storage) WritePart(claims *Claims, body io.Reader) (string, error) {
+	if claims.PartNumber < 1 || claims.PartNumber > maxPartNumber {
+		return "", port.ErrInvalidUploadPart
+	}
+	if _, err := s.loadUpload(claims.Key, claims.UploadID); err != nil {
+		return "", err
+	}
+
+	dir := s.uploadDir(claims.UploadID)
+	tmp, err := os.CreateTemp(dir, ".part-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := md5.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(body, claims.MaxSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	if written > claims.MaxSize {
+		return "", ErrObjectLarge
+	}
+
+	if err = os.Rename(tmp.Name(), filepath.Join(dir, strconv.Itoa(int(claims.PartNumber)))); err != nil {
+		return "", err
+	}
+
+	return `"` + hex.EncodeToString(hash.Sum(nil)) + `"`, nil
+}
+
+func (s *Storage) ListParts(ctx context.Context, key, uploadID string) ([]*port.UploadedPart, error) {
+	if _, err := s.loadUpload(key, uploadID); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(s.uploadDir(uploadID))
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []*port.UploadedPart
+	for _, entry := range entries {
+		partNumber, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		part, err := s.readPart(uploadID, int32(partNumber))
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	slices.SortFunc(parts, func(a, b *port.UploadedPart) int {
+		return int(a.PartNumber - b.PartNumber)
+	})
+
+	return parts, nil
+}
+
+func (s *Storage) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []*port.UploadedPart) error {
+	upload, err := s.loadUpload(key, uploadID)
+	if err != nil {
+		return err
+	}
+
+	readers := make([]io.Reader, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return port.ErrInvalidUploadPart
+		}
+
+		stored, err := s.readPart(uploadID, part.PartNumber)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return port.ErrInvalidUploadPart
+			}
+			return err
+		}
+		if strings.Trim(stored.ETag, `"`) != strings.Trim(part.ETag, `"`) {
+			return port.ErrInvalidUploadPart
+		}
+
+		file, err := os.Open(filepath.Join(s.uploadDir(uploadID), strconv.Itoa(int(part.PartNumber))))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		readers = append(readers, file)
+	}
+
+	if _, err = s.Write(upload.Key, upload.ContentType, io.MultiReader(readers...), -1); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(s.uploadDir(uploadID))
+}
+
+func (s *Storage) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	if _, err := s.loadUpload(key, uploadID); err != nil {
+		if errors.Is(err, port.ErrMultipartUploadNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return os.RemoveAll(s.uploadDir(uploadID))
+}
+
+func (s *Storage) loadUpload(key, uploadID string) (*multipartUpload, error) {
+	if _, err := hex.DecodeString(uploadID); err != nil || len(uploadID) != uploadIDByteSize*2 {
+		return nil, port.ErrMultipartUploadNotFound
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.uploadDir(uploadID), multipartInfo))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, port.ErrMultipartUploadNotFound
+		}
+		return nil, err
+	}
+
+	var upload multipartUpload
+	if err = json.Unmarshal(data, &upload); err != nil {
+		return nil, err
+	}
+	if upload.Key != key {
+		return nil, port.ErrMultipartUploadNotFound
+	}
+
+	return &upload, nil
+}
+
+func (s *Storage) readPart(uploadID string, partNumber int32) (*port.UploadedPart, error) {
+	file, err := os.Open(filepath.Join(s.uploadDir(uploadID), strconv.Itoa(int(partNumber))))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &port.UploadedPart{
+		PartNumber: partNumber,
+		ETag:       `"` + hex.EncodeToString(hash.Sum(nil)) + `"`,
+		Size:       size,
+	}, nil
+}
+
+func (s *Storage) uploadDir(uploadID string) string {
+	return filepath.Join(s.root, multipartDir, uploadID)
+}
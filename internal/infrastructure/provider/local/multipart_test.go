@@ -0,0 +1,49 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+)
+
+func TestWritePartIsCappedAtPresignedSize(t *testing.T) {
+	s, err := NewStorageProvider(config.StorageConfig{
+		LocalRoot:    t.TempDir(),
+		LocalBaseURL: "http://app.test",
+		SigningKey:   "test-key",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	uploadID, err := s.CreateMultipartUpload(ctx, "uploads/video.mp4", "video/mp4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	partURL, err := s.PresignUploadPart(ctx, "uploads/video.mp4", uploadID, 1, 4, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := url.Parse(partURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := s.VerifyToken(parsed.Query().Get("token"), OpUploadPart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.WritePart(claims, strings.NewReader("12345")); !errors.Is(err, ErrObjectLarge) {
+		t.Fatalf("oversized part err = %v, want ErrObjectLarge", err)
+	}
+	if _, err = s.WritePart(claims, strings.NewReader("1234")); err != nil {
+		t.Fatalf("part at the signed size: %v", err)
+	}
+}
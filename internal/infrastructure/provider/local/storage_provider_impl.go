@@ -0,0 +1,310 @@
+package local
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+)
+
+const metaSuffix = ".meta"
+
+var (
+	ErrInvalidKey  = errors.New("invalid object key")
+	ErrObjectLarge = errors.New("object exceeds the allowed size")
+)
+
+type metadata struct {
+	ContentType string `json:"content_type"`
+	Checksum    string `json:"checksum"`
+}
+
+// Storage keeps objects on local disk and hands out URLs to our own
+// /storage routes, signed with an HMAC token instead of S3 credentials.
+type Storage struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+}
+
+var _ port.StorageProvider = (*Storage)(nil)
+
+func NewStorageProvider(cfg config.StorageConfig) (*Storage, error) {
+	if cfg.SigningKey == "" {
+		return nil, errors.New("storage signing key is required for the local driver")
+	}
+
+	root := cfg.LocalRoot
+	if root == "" {
+		root = "./storage"
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &Storage{
+		root,
+		strings.TrimRight(cfg.LocalBaseURL, "/"),
+		[]byte(cfg.SigningKey),
+	}, nil
+}
+
+func (s *Storage) PresignUpload(ctx context.Context, key, contentType string, maxSize int64, ttl time.Duration) (*port.PresignedUpload, error) {
+	if _, err := s.path(key); err != nil {
+		return nil, err
+	}
+
+	token, err := s.signToken(Claims{
+		Key:         key,
+		Op:          OpUpload,
+		ContentType: contentType,
+		MaxSize:     maxSize,
+		Nonce:       rand.Text(),
+		ExpiresAt:   time.Now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &port.PresignedUpload{
+		URL: fmt.Sprintf("%s/storage/upload?token=%s", s.baseURL, url.QueryEscape(token)),
+		Fields: map[string]string{
+			"Content-Type": contentType,
+		},
+	}, nil
+}
+
+func (s *Storage) PresignDownload(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+
+	token, err := s.signToken(Claims{
+		Key:       key,
+		Op:        OpDownload,
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/storage/download?token=%s", s.baseURL, url.QueryEscape(token)), nil
+}
+
+func (s *Storage) PublicURL(key string) (string, bool) {
+	return "", false
+}
+
+func (s *Storage) Head(ctx context.Context, key string) (*port.ObjectInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	meta, err := readMetadata(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &port.ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  meta.ContentType,
+		Checksum:     meta.Checksum,
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+func (s *Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+func (s *Storage) Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	_, err := s.Write(key, contentType, body, -1)
+	return err
+}
+
+func (s *Storage) Delete(ctx context.Context, keys []string) ([]string, error) {
+	var failed []string
+	for _, key := range keys {
+		path, err := s.path(key)
+		if err != nil {
+			failed = append(failed, key)
+			continue
+		}
+
+		for _, p := range []string{path, path + metaSuffix} {
+			if err = os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				failed = append(failed, key)
+				break
+			}
+		}
+	}
+
+	return failed, nil
+}
+
+func (s *Storage) List(ctx context.Context, prefix, startAfter string, limit int) ([]*port.ObjectInfo, error) {
+	var objects []*port.ObjectInfo
+
+	errStop := errors.New("stop")
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != s.root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || strings.HasSuffix(path, metaSuffix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) || key <= startAfter {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, &port.ObjectInfo{
+			Key:          key,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		if limit > 0 && len(objects) >= limit {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// Write stores body under key, rejecting it when it grows past maxSize
+// (a negative maxSize disables the limit).
+func (s *Storage) Write(key, contentType string, body io.Reader, maxSize int64) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if maxSize >= 0 {
+		body = io.LimitReader(body, maxSize+1)
+	}
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if maxSize >= 0 && written > maxSize {
+		return 0, ErrObjectLarge
+	}
+
+	meta, err := json.Marshal(metadata{contentType, hex.EncodeToString(hash.Sum(nil))})
+	if err != nil {
+		return 0, err
+	}
+	if err = os.WriteFile(path+metaSuffix, meta, 0o644); err != nil {
+		return 0, err
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return written, nil
+}
+
+func (s *Storage) Open(key string) (*os.File, *port.ObjectInfo, error) {
+	info, err := s.Head(context.Background(), key)
+	if err != nil || info == nil {
+		return nil, nil, err
+	}
+
+	path, _ := s.path(key)
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return file, info, nil
+}
+
+func (s *Storage) path(key string) (string, error) {
+	native := filepath.FromSlash(key)
+	if key == "" || !filepath.IsLocal(native) || strings.HasPrefix(key, ".") || strings.HasSuffix(key, metaSuffix) {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(s.root, native), nil
+}
+
+func readMetadata(path string) (*metadata, error) {
+	data, err := os.ReadFile(path + metaSuffix)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &metadata{}, nil
+		}
+		return nil, err
+	}
+
+	var meta metadata
+	if err = json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+
+	return &meta, nil
+}
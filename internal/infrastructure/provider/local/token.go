@@ -0,0 +1,75 @@
+package local
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	OpUpload     = "put"
+	OpUploadPart = "part"
+	OpDownload   = "get"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired storage token")
+
+type Claims struct {
+	Key         string `json:"k"`
+	Op          string `json:"op"`
+	ContentType string `json:"ct,omitempty"`
+	MaxSize     int64  `json:"max,omitempty"`
+	UploadID    string `json:"u,omitempty"`
+	PartNumber  int32  `json:"n,omitempty"`
+	// Nonce identifies an upload token so the handler can accept it once.
+	Nonce     string `json:"jti,omitempty"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+func (s *Storage) signToken(claims Claims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), nil
+}
+
+func (s *Storage) VerifyToken(token, op string) (*Claims, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	rawSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(rawSig, s.mac(encoded)) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Op != op || time.Now().Unix() > claims.ExpiresAt {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
+
+func (s *Storage) mac(data string) []byte {
+	h := hmac.New(sha256.New, s.signingKey)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,133 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// jwksRefreshInterval stops tokens with made-up key IDs from making us hit
+// the issuer on every request.
+const jwksRefreshInterval = time.Minute
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type keySet struct {
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// key returns the issuer's signing key by ID, reloading the key set when the
+// ID is unknown so rotated keys are picked up without a restart.
+func (p *oidcProviderImpl) key(ctx context.Context, meta *discovery, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.keys != nil {
+		if key, ok := p.lookup(kid); ok {
+			return key, nil
+		}
+		if time.Since(p.keys.fetchedAt) < jwksRefreshInterval {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+	}
+
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, meta.JWKSURI, &doc); err != nil {
+		return nil, fmt.Errorf("fetch jwks failed: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = &keySet{keys, time.Now()}
+
+	if key, ok := p.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookup accepts a token without a key ID only when the issuer publishes a
+// single key.
+func (p *oidcProviderImpl) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys.keys) == 1 {
+		for _, key := range p.keys.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys.keys[kid]
+	return key, ok
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,244 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const defaultScopes = "openid email profile"
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// oidcProviderImpl talks to a single OpenID Connect issuer. The discovery
+// document is fetched on first use and kept for the process lifetime; the
+// signing keys are cached and refreshed when a token uses an unknown key.
+type oidcProviderImpl struct {
+	cfg    config.OIDCConfig
+	client *http.Client
+
+	mu   sync.Mutex
+	meta *discovery
+	keys *keySet
+}
+
+func NewOIDCProvider(cfg config.OIDCConfig) port.OIDCProvider {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Scopes == "" {
+		cfg.Scopes = defaultScopes
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+
+	return &oidcProviderImpl{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (p *oidcProviderImpl) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", p.cfg.Scopes)
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+func (p *oidcProviderImpl) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*port.OIDCIdentity, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return nil, fmt.Errorf("decode token response failed (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("token endpoint responded %d: %s %s", resp.StatusCode, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	return p.verify(ctx, meta, token.IDToken, nonce)
+}
+
+func (p *oidcProviderImpl) verify(ctx context.Context, meta *discovery, rawIDToken, nonce string) (*port.OIDCIdentity, error) {
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, meta, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	); err != nil {
+		return nil, fmt.Errorf("verify id token failed: %w", err)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	identity := &port.OIDCIdentity{
+		Issuer:            meta.Issuer,
+		Subject:           stringClaim(claims, "sub"),
+		Email:             strings.ToLower(stringClaim(claims, "email")),
+		Name:              stringClaim(claims, "name"),
+		GivenName:         stringClaim(claims, "given_name"),
+		FamilyName:        stringClaim(claims, "family_name"),
+		PreferredUsername: stringClaim(claims, "preferred_username"),
+		PhoneNumber:       stringClaim(claims, "phone_number"),
+		Groups:            stringsClaim(claims, p.cfg.GroupsClaim),
+	}
+	if identity.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+
+	// Some providers send email_verified as a string.
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = v
+	case string:
+		identity.EmailVerified = v == "true"
+	}
+
+	return identity, nil
+}
+
+func (p *oidcProviderImpl) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	if p.cfg.IssuerURL == "" {
+		return nil, errors.New("oidc issuer url is not configured")
+	}
+
+	var meta discovery
+	if err := p.getJSON(ctx, strings.TrimRight(p.cfg.IssuerURL, "/")+"/.well-known/openid-configuration", &meta); err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+
+	if strings.TrimRight(meta.Issuer, "/") != strings.TrimRight(p.cfg.IssuerURL, "/") {
+		return nil, fmt.Errorf("oidc discovery issuer %q does not match %q", meta.Issuer, p.cfg.IssuerURL)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is incomplete")
+	}
+
+	p.meta = &meta
+	return p.meta, nil
+}
+
+func (p *oidcProviderImpl) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	v, _ := claims[name].(string)
+	return v
+}
+
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	switch v := claims[name].(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	case string:
+		return strings.Fields(v)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,284 @@
+// Package oidctest is a minimal OpenID Connect identity provider for local
+// development and tests. It signs in a single configurable user without a
+// login page and supports the authorization code flow with PKCE only.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyID = "oidctest"
+
+type User struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	GivenName         string
+	FamilyName        string
+	PreferredUsername string
+	PhoneNumber       string
+	Groups            []string
+}
+
+type authCode struct {
+	user        User
+	redirectURI string
+	nonce       string
+	challenge   string
+	expiresAt   time.Time
+}
+
+type IdP struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+	mux *http.ServeMux
+
+	mu        sync.Mutex
+	user      *User
+	overrides map[string]any
+	codes     map[string]authCode
+}
+
+func New(issuer, clientID, clientSecret string) (*IdP, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &IdP{
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		mux:          http.NewServeMux(),
+		codes:        make(map[string]authCode),
+	}
+
+	p.mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	p.mux.HandleFunc("GET /jwks", p.jwks)
+	p.mux.HandleFunc("GET /authorize", p.authorize)
+	p.mux.HandleFunc("POST /token", p.token)
+
+	return p, nil
+}
+
+// NewServer starts the provider on a random local port. The caller closes
+// the returned server.
+func NewServer(clientID, clientSecret string) (*IdP, *httptest.Server, error) {
+	var p *IdP
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.ServeHTTP(w, r)
+	}))
+
+	p, err := New(srv.URL, clientID, clientSecret)
+	if err != nil {
+		srv.Close()
+		return nil, nil, err
+	}
+
+	return p, srv, nil
+}
+
+// SetUser chooses who is signed in by the next authorization request. With
+// no user set the provider answers access_denied.
+func (p *IdP) SetUser(user User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.user = &user
+}
+
+// OverrideClaims replaces claims in every ID token issued from now on, so
+// tests can hand out tokens with a wrong issuer, audience, nonce or expiry.
+func (p *IdP) OverrideClaims(claims map[string]any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.overrides = claims
+}
+
+func (p *IdP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mux.ServeHTTP(w, r)
+}
+
+func (p *IdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.Issuer,
+		"authorization_endpoint":                p.Issuer + "/authorize",
+		"token_endpoint":                        p.Issuer + "/token",
+		"jwks_uri":                              p.Issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *IdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" || query.Get("client_id") != p.ClientID {
+		http.Error(w, "invalid client or redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	params := url.Values{"state": {query.Get("state")}}
+
+	p.mu.Lock()
+	user := p.user
+	p.mu.Unlock()
+
+	switch {
+	case query.Get("response_type") != "code":
+		params.Set("error", "unsupported_response_type")
+	case query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256":
+		params.Set("error", "invalid_request")
+	case user == nil:
+		params.Set("error", "access_denied")
+	default:
+		code := rand.Text()
+
+		p.mu.Lock()
+		p.codes[code] = authCode{
+			user:        *user,
+			redirectURI: redirectURI.String(),
+			nonce:       query.Get("nonce"),
+			challenge:   query.Get("code_challenge"),
+			expiresAt:   time.Now().Add(time.Minute),
+		}
+		p.mu.Unlock()
+
+		params.Set("code", code)
+	}
+
+	redirectQuery := redirectURI.Query()
+	for key, values := range params {
+		redirectQuery[key] = values
+	}
+	redirectURI.RawQuery = redirectQuery.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (p *IdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != p.ClientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(p.ClientSecret)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeTokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	p.mu.Lock()
+	grant, ok := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if !ok || time.Now().After(grant.expiresAt) || grant.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":                p.Issuer,
+		"sub":                grant.user.Subject,
+		"aud":                p.ClientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(5 * time.Minute).Unix(),
+		"nonce":              grant.nonce,
+		"email":              grant.user.Email,
+		"email_verified":     grant.user.EmailVerified,
+		"name":               grant.user.Name,
+		"given_name":         grant.user.GivenName,
+		"family_name":        grant.user.FamilyName,
+		"preferred_username": grant.user.PreferredUsername,
+		"phone_number":       grant.user.PhoneNumber,
+		"groups":             grant.user.Groups,
+	}
+
+	p.mu.Lock()
+	for name, value := range p.overrides {
+		claims[name] = value
+	}
+	p.mu.Unlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+
+	idToken, err := token.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func writeTokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,24 @@
+package rabbitmq
+
+import "github.com/rabbitmq/amqp091-go"
+
+type headerCarrier amqp091.Table
+
+func (c headerCarrier) Get(key string) string {
+	if v, ok := c[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func (c headerCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
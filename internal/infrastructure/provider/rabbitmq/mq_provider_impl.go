@@ -7,10 +7,17 @@ import (
 	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
 	"github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+const tracerName = "github.com/InstaySystem/is_v2-be/internal/infrastructure/provider/rabbitmq"
+
 type messageQueueProviderImpl struct {
 	conn *amqp091.Connection
 	ch   *amqp091.Channel
@@ -29,22 +36,42 @@ func NewMessageQueueProvider(
 	}
 }
 
-func (m *messageQueueProviderImpl) PublishMessage(exchange, routingKey string, body []byte) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (m *messageQueueProviderImpl) PublishMessage(ctx context.Context, exchange, routingKey string, body []byte) error {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s publish", routingKey),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", exchange),
+			attribute.String("messaging.rabbitmq.destination.routing_key", routingKey),
+		),
+	)
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	headers := amqp091.Table{}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+
 	if err := m.ch.PublishWithContext(ctx, exchange, routingKey, false, false, amqp091.Publishing{
+		Headers:      headers,
 		ContentType:  "application/json",
 		DeliveryMode: amqp091.Persistent,
 		Body:         body,
 	}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 
 	return nil
 }
 
-func (m *messageQueueProviderImpl) ConsumeMessage(queueName, exchange, routingKey string, handler func([]byte) error) error {
+func (m *messageQueueProviderImpl) ConsumeMessage(queueName, exchange, routingKey string, handler func(context.Context, []byte) error) error {
+	return m.ConsumeMessages(queueName, exchange, []string{routingKey}, handler)
+}
+
+func (m *messageQueueProviderImpl) ConsumeMessages(queueName, exchange string, routingKeys []string, handler func(context.Context, []byte) error) error {
 	if _, err := m.ch.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
 		return err
 	}
@@ -53,8 +80,10 @@ func (m *messageQueueProviderImpl) ConsumeMessage(queueName, exchange, routingKe
 		return err
 	}
 
-	if err := m.ch.QueueBind(queueName, routingKey, exchange, false, nil); err != nil {
-		return err
+	for _, routingKey := range routingKeys {
+		if err := m.ch.QueueBind(queueName, routingKey, exchange, false, nil); err != nil {
+			return err
+		}
 	}
 
 	if err := m.ch.Qos(5, 0, false); err != nil {
@@ -69,7 +98,7 @@ func (m *messageQueueProviderImpl) ConsumeMessage(queueName, exchange, routingKe
 	for i := range 5 {
 		go func(workerID int) {
 			for msg := range msgs {
-				m.processWithRetry(msg.Body, handler, workerID)
+				m.processDelivery(msg, queueName, handler, workerID)
 			}
 		}(i)
 	}
@@ -77,18 +106,42 @@ func (m *messageQueueProviderImpl) ConsumeMessage(queueName, exchange, routingKe
 	return nil
 }
 
-func (m *messageQueueProviderImpl) processWithRetry(body []byte, handler func([]byte) error, workerID int) {
+func (m *messageQueueProviderImpl) processDelivery(msg amqp091.Delivery, queueName string, handler func(context.Context, []byte) error, workerID int) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier(msg.Headers))
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s process", queueName),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", msg.Exchange),
+			attribute.String("messaging.rabbitmq.destination.routing_key", msg.RoutingKey),
+			attribute.Int("messaging.consumer.worker_id", workerID),
+		),
+	)
+	defer span.End()
+
+	if sc := span.SpanContext(); sc.HasTraceID() {
+		ctx = logger.WithContext(ctx, m.log.With(zap.String("trace_id", sc.TraceID().String())))
+	}
+
+	if err := m.processWithRetry(ctx, msg.Body, handler, workerID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+func (m *messageQueueProviderImpl) processWithRetry(ctx context.Context, body []byte, handler func(context.Context, []byte) error, workerID int) error {
 	maxAttempts := 5
 	initialInterval := 1000 * time.Millisecond
 	multiplier := 2.0
 	maxInterval := 10000 * time.Millisecond
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		err := handler(body)
+		err := handler(port.WithDeliveryAttempt(ctx, attempt, maxAttempts), body)
 		if err == nil {
-			return
+			return nil
 		}
-		m.log.Error(fmt.Sprintf("work %d (%d/%d) failed", workerID, attempt, maxAttempts), zap.Error(err))
+		logger.FromContext(ctx, m.log).Error(fmt.Sprintf("work %d (%d/%d) failed", workerID, attempt, maxAttempts), zap.Error(err))
 
 		if attempt < maxAttempts {
 			delay := float64(initialInterval) * math.Pow(multiplier, float64(attempt-1))
@@ -99,5 +152,8 @@ func (m *messageQueueProviderImpl) processWithRetry(body []byte, handler func([]
 		}
 	}
 
-	m.log.Error(fmt.Sprintf("work %d", workerID), zap.Error(fmt.Errorf("message sending failed after %d attempts", maxAttempts)))
+	err := fmt.Errorf("message sending failed after %d attempts", maxAttempts)
+	logger.FromContext(ctx, m.log).Error(fmt.Sprintf("work %d", workerID), zap.Error(err))
+
+	return err
 }
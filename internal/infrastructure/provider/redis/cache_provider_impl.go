@@ -31,6 +31,19 @@ func (p *cacheProviderImpl) GetString(ctx context.Context, key string) (string,
 	return str, nil
 }
 
+// GetDelString reads and removes the key in one command, so a value can only
+// be consumed once even under concurrent requests.
+func (p *cacheProviderImpl) GetDelString(ctx context.Context, key string) (string, error) {
+	str, err := p.rdb.GetDel(ctx, key).Result()
+	if err == redis.Nil {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return str, nil
+}
+
 func (p *cacheProviderImpl) SetObject(ctx context.Context, key string, data []byte, ttl time.Duration) error {
 	return p.rdb.Set(ctx, key, data, ttl).Err()
 }
@@ -64,3 +77,17 @@ func (p *cacheProviderImpl) GetInt(ctx context.Context, key string) (int, error)
 func (p *cacheProviderImpl) Increment(ctx context.Context, key string) error {
 	return p.rdb.Incr(ctx, key).Err()
 }
+
+func (p *cacheProviderImpl) IncrementBy(ctx context.Context, key string, value int64, ttl time.Duration) (int64, error) {
+	pipe := p.rdb.TxPipeline()
+	incr := pipe.IncrBy(ctx, key, value)
+	if ttl > 0 {
+		pipe.ExpireNX(ctx, key, ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
@@ -0,0 +1,307 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsS3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+const maxDeleteBatchSize = 1000
+
+type storageProviderImpl struct {
+	cfg     config.MinIOConfig
+	client  *awsS3.Client
+	pClient *awsS3.PresignClient
+}
+
+func NewStorageProvider(cfg config.MinIOConfig, client *awsS3.Client) port.StorageProvider {
+	pClient := awsS3.NewPresignClient(client)
+	return &storageProviderImpl{
+		cfg,
+		client,
+		pClient,
+	}
+}
+
+func (p *storageProviderImpl) PresignUpload(ctx context.Context, key, contentType string, maxSize int64, ttl time.Duration) (*port.PresignedUpload, error) {
+	presignedRes, err := p.pClient.PresignPostObject(ctx, &awsS3.PutObjectInput{
+		Bucket:      aws.String(p.cfg.Bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	}, func(opts *awsS3.PresignPostOptions) {
+		opts.Expires = ttl
+		opts.Conditions = []any{
+			[]any{"content-length-range", 1, maxSize},
+			map[string]string{"Content-Type": contentType},
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	presignedRes.Values["Content-Type"] = contentType
+
+	return &port.PresignedUpload{
+		URL:    presignedRes.URL,
+		Fields: presignedRes.Values,
+	}, nil
+}
+
+func (p *storageProviderImpl) PresignDownload(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	presignedReq, err := p.pClient.PresignGetObject(ctx, &awsS3.GetObjectInput{
+		Bucket: aws.String(p.cfg.Bucket),
+		Key:    aws.String(key),
+	}, func(opts *awsS3.PresignOptions) {
+		opts.Expires = ttl
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return presignedReq.URL, nil
+}
+
+func (p *storageProviderImpl) PublicURL(key string) (string, bool) {
+	if !p.cfg.PublicRead || !strings.HasPrefix(key, constants.StoragePublicPrefix) {
+		return "", false
+	}
+
+	baseURL := strings.TrimRight(p.cfg.PublicURL, "/")
+	if baseURL == "" {
+		protocol := "http"
+		if p.cfg.UseSSL {
+			protocol = protocol + "s"
+		}
+		baseURL = fmt.Sprintf("%s://%s/%s", protocol, p.cfg.Endpoint, p.cfg.Bucket)
+	}
+
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return baseURL + "/" + strings.Join(segments, "/"), true
+}
+
+func (p *storageProviderImpl) Head(ctx context.Context, key string) (*port.ObjectInfo, error) {
+	head, err := p.client.HeadObject(ctx, &awsS3.HeadObjectInput{
+		Bucket:       aws.String(p.cfg.Bucket),
+		Key:          aws.String(key),
+		ChecksumMode: types.ChecksumModeEnabled,
+	})
+	if err != nil {
+		var keyNotFound *types.NotFound
+		if errors.As(err, &keyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	checksum := aws.ToString(head.ChecksumSHA256)
+	if checksum == "" {
+		checksum = strings.Trim(aws.ToString(head.ETag), `"`)
+	}
+
+	return &port.ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(head.ContentLength),
+		ContentType:  aws.ToString(head.ContentType),
+		Checksum:     checksum,
+		LastModified: aws.ToTime(head.LastModified),
+	}, nil
+}
+
+func (p *storageProviderImpl) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := p.client.GetObject(ctx, &awsS3.GetObjectInput{
+		Bucket: aws.String(p.cfg.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Body, nil
+}
+
+func (p *storageProviderImpl) Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	_, err := p.client.PutObject(ctx, &awsS3.PutObjectInput{
+		Bucket:        aws.String(p.cfg.Bucket),
+		Key:           aws.String(key),
+		Body:          body,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String(contentType),
+	})
+	return err
+}
+
+func (p *storageProviderImpl) Delete(ctx context.Context, keys []string) ([]string, error) {
+	var failed []string
+	for chunk := range slices.Chunk(keys, maxDeleteBatchSize) {
+		objects := make([]types.ObjectIdentifier, 0, len(chunk))
+		for _, key := range chunk {
+			objects = append(objects, types.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		out, err := p.client.DeleteObjects(ctx, &awsS3.DeleteObjectsInput{
+			Bucket: aws.String(p.cfg.Bucket),
+			Delete: &types.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range out.Errors {
+			failed = append(failed, aws.ToString(e.Key))
+		}
+	}
+
+	return failed, nil
+}
+
+func (p *storageProviderImpl) List(ctx context.Context, prefix, startAfter string, limit int) ([]*port.ObjectInfo, error) {
+	input := &awsS3.ListObjectsV2Input{
+		Bucket:  aws.String(p.cfg.Bucket),
+		MaxKeys: aws.Int32(int32(limit)),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if startAfter != "" {
+		input.StartAfter = aws.String(startAfter)
+	}
+
+	out, err := p.client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]*port.ObjectInfo, 0, len(out.Contents))
+	for _, obj := range out.Contents {
+		objects = append(objects, &port.ObjectInfo{
+			Key:          aws.ToString(obj.Key),
+			Size:         aws.ToInt64(obj.Size),
+			Checksum:     strings.Trim(aws.ToString(obj.ETag), `"`),
+			LastModified: aws.ToTime(obj.LastModified),
+		})
+	}
+
+	return objects, nil
+}
+
+func (p *storageProviderImpl) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	out, err := p.client.CreateMultipartUpload(ctx, &awsS3.CreateMultipartUploadInput{
+		Bucket:      aws.String(p.cfg.Bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.ToString(out.UploadId), nil
+}
+
+func (p *storageProviderImpl) PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, size int64, ttl time.Duration) (string, error) {
+	presignedReq, err := p.pClient.PresignUploadPart(ctx, &awsS3.UploadPartInput{
+		Bucket:        aws.String(p.cfg.Bucket),
+		Key:           aws.String(key),
+		UploadId:      aws.String(uploadID),
+		PartNumber:    aws.Int32(partNumber),
+		ContentLength: aws.Int64(size),
+	}, func(opts *awsS3.PresignOptions) {
+		opts.Expires = ttl
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return presignedReq.URL, nil
+}
+
+func (p *storageProviderImpl) ListParts(ctx context.Context, key, uploadID string) ([]*port.UploadedPart, error) {
+	paginator := awsS3.NewListPartsPaginator(p.client, &awsS3.ListPartsInput{
+		Bucket:   aws.String(p.cfg.Bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+
+	var parts []*port.UploadedPart
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, multipartError(err)
+		}
+
+		for _, part := range out.Parts {
+			parts = append(parts, &port.UploadedPart{
+				PartNumber: aws.ToInt32(part.PartNumber),
+				ETag:       aws.ToString(part.ETag),
+				Size:       aws.ToInt64(part.Size),
+			})
+		}
+	}
+
+	return parts, nil
+}
+
+func (p *storageProviderImpl) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []*port.UploadedPart) error {
+	completed := make([]types.CompletedPart, 0, len(parts))
+	for _, part := range parts {
+		completed = append(completed, types.CompletedPart{
+			PartNumber: aws.Int32(part.PartNumber),
+			ETag:       aws.String(part.ETag),
+		})
+	}
+
+	_, err := p.client.CompleteMultipartUpload(ctx, &awsS3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(p.cfg.Bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: completed,
+		},
+	})
+	return multipartError(err)
+}
+
+func (p *storageProviderImpl) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	_, err := p.client.AbortMultipartUpload(ctx, &awsS3.AbortMultipartUploadInput{
+		Bucket:   aws.String(p.cfg.Bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err = multipartError(err); errors.Is(err, port.ErrMultipartUploadNotFound) {
+		return nil
+	}
+	return err
+}
+
+func multipartError(err error) error {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch apiErr.ErrorCode() {
+	case "NoSuchUpload":
+		return port.ErrMultipartUploadNotFound
+	case "InvalidPart", "InvalidPartOrder", "EntityTooSmall":
+		return port.ErrInvalidUploadPart
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+)
+
+// httpProvider sends a plain text message through a JSON SMS gateway
+// authenticated with a bearer key.
+type httpProvider struct {
+	url    string
+	key    string
+	sender string
+	client *http.Client
+}
+
+type httpSMSRequest struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to"`
+	Text string `json:"text"`
+}
+
+func newHTTPProvider(cfg config.SMSConfig) (*httpProvider, error) {
+	if cfg.APIURL == "" || cfg.APIKey == "" {
+		return nil, errors.New("sms api url and key are required")
+	}
+
+	return &httpProvider{
+		cfg.APIURL,
+		cfg.APIKey,
+		cfg.Sender,
+		&http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (p *httpProvider) SendOTP(ctx context.Context, phone, otp, locale string) error {
+	body, err := json.Marshal(httpSMSRequest{
+		From: p.sender,
+		To:   internationalPhone(phone),
+		Text: i18n.T(locale, "sms.otp", otp),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.key)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sms api responded %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package sms
+
+import (
+	"context"
+
+	"github.com/InstaySystem/is_v2-be/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// logProvider only logs the OTP, for local development where no gateway is
+// configured.
+type logProvider struct {
+	log *zap.Logger
+}
+
+func newLogProvider(log *zap.Logger) *logProvider {
+	return &logProvider{log}
+}
+
+func (p *logProvider) SendOTP(ctx context.Context, phone, otp, locale string) error {
+	logger.FromContext(ctx, p.log).Info("sms otp", zap.String("phone", phone), zap.String("otp", otp), zap.String("locale", locale))
+	return nil
+}
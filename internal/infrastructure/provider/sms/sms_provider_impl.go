@@ -0,0 +1,37 @@
+package sms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"go.uber.org/zap"
+)
+
+func NewSMSProvider(cfg config.SMSConfig, log *zap.Logger) (port.SMSProvider, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	switch cfg.Driver {
+	case "", constants.SMSDriverLog:
+		return newLogProvider(log), nil
+	case constants.SMSDriverHTTP:
+		return newHTTPProvider(cfg)
+	case constants.SMSDriverZalo:
+		return newZaloProvider(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported sms driver %q", cfg.Driver)
+	}
+}
+
+// internationalPhone converts a local Vietnamese number (0912345678) to the
+// 84912345678 form expected by gateways.
+func internationalPhone(phone string) string {
+	if len(phone) > 1 && phone[0] == '0' {
+		return "84" + phone[1:]
+	}
+	return phone
+}
@@ -0,0 +1,87 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+)
+
+const zaloDefaultURL = "https://business.openapi.zalo.me/message/template"
+
+// zaloProvider sends the OTP as a Zalo Notification Service template
+// message. The approved template must take a single "otp" parameter.
+type zaloProvider struct {
+	url        string
+	token      string
+	templateID string
+	client     *http.Client
+}
+
+type zaloRequest struct {
+	Phone        string            `json:"phone"`
+	TemplateID   string            `json:"template_id"`
+	TemplateData map[string]string `json:"template_data"`
+}
+
+type zaloResponse struct {
+	Error   int    `json:"error"`
+	Message string `json:"message"`
+}
+
+func newZaloProvider(cfg config.SMSConfig) (*zaloProvider, error) {
+	if cfg.APIKey == "" || cfg.TemplateID == "" {
+		return nil, errors.New("zalo access token and template id are required")
+	}
+
+	url := cfg.APIURL
+	if url == "" {
+		url = zaloDefaultURL
+	}
+
+	return &zaloProvider{
+		url,
+		cfg.APIKey,
+		cfg.TemplateID,
+		&http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (p *zaloProvider) SendOTP(ctx context.Context, phone, otp, _ string) error {
+	body, err := json.Marshal(zaloRequest{
+		Phone:        internationalPhone(phone),
+		TemplateID:   p.templateID,
+		TemplateData: map[string]string{"otp": otp},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("access_token", p.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// ZNS reports failures in the body, usually with a 200 status.
+	var result zaloResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode zalo response failed (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices || result.Error != 0 {
+		return fmt.Errorf("zalo responded %d (error %d): %s", resp.StatusCode, result.Error, result.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+)
+
+// fileTransport writes every message as an .eml file instead of sending it,
+// for local development and tests. The envelope recipients are recorded in
+// an X-Envelope-To header so Bcc delivery can be checked too.
+type fileTransport struct {
+	dir string
+}
+
+func newFileTransport(cfg config.SMTPConfig) (*fileTransport, error) {
+	dir := cfg.OutputDir
+	if dir == "" {
+		dir = "./mail"
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &fileTransport{dir}, nil
+}
+
+func (t *fileTransport) Send(_ context.Context, msg *Message) error {
+	raw, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	content := append(fmt.Appendf(nil, "X-Envelope-To: %s\r\n", strings.Join(msg.Recipients(), ", ")), raw...)
+
+	name := fmt.Sprintf("%s-%s.eml", msg.Date.UTC().Format("20060102T150405.000000000"), filepath.Base(msg.MessageID))
+	tmp := filepath.Join(t.dir, "."+name)
+	if err = os.WriteFile(tmp, content, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, filepath.Join(t.dir, name))
+}
+
+func (t *fileTransport) Close() error {
+	return nil
+}
@@ -0,0 +1,114 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+)
+
+// httpTransport posts messages to a JSON email API using the Resend request
+// shape (from/to/cc/bcc/subject/html/text/attachments) with a bearer key.
+// The message ID doubles as the idempotency key so queue retries never send
+// twice.
+type httpTransport struct {
+	url    string
+	key    string
+	client *http.Client
+}
+
+type httpEmailRequest struct {
+	From        string                `json:"from"`
+	To          []string              `json:"to"`
+	Cc          []string              `json:"cc,omitempty"`
+	Bcc         []string              `json:"bcc,omitempty"`
+	Subject     string                `json:"subject"`
+	HTML        string                `json:"html,omitempty"`
+	Text        string                `json:"text,omitempty"`
+	Headers     map[string]string     `json:"headers,omitempty"`
+	Attachments []httpEmailAttachment `json:"attachments,omitempty"`
+}
+
+type httpEmailAttachment struct {
+	Filename    string `json:"filename"`
+	Content     string `json:"content"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+func newHTTPTransport(cfg config.SMTPConfig) (*httpTransport, error) {
+	if cfg.APIURL == "" || cfg.APIKey == "" {
+		return nil, errors.New("email api url and key are required")
+	}
+
+	return &httpTransport{
+		url:    cfg.APIURL,
+		key:    cfg.APIKey,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (t *httpTransport) Send(ctx context.Context, msg *Message) error {
+	// The API requires a To address, so a message without one is addressed to
+	// the sender rather than promoting a Bcc recipient into the headers.
+	to := msg.To
+	if len(to) == 0 {
+		to = []string{msg.From.Address}
+	}
+
+	payload := httpEmailRequest{
+		From:    msg.From.String(),
+		To:      to,
+		Cc:      msg.Cc,
+		Bcc:     msg.Bcc,
+		Subject: msg.Subject,
+		HTML:    msg.HTML,
+		Text:    msg.Text,
+		Headers: map[string]string{
+			"X-Message-ID": msg.MessageID,
+		},
+	}
+	for _, att := range msg.Attachments {
+		payload.Attachments = append(payload.Attachments, httpEmailAttachment{
+			Filename:    att.FileName,
+			Content:     base64.StdEncoding.EncodeToString(att.Content),
+			ContentType: att.ContentType,
+		})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+t.key)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", msg.MessageID)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("email api responded %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+
+	return nil
+}
+
+func (t *httpTransport) Close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,205 @@
+package smtp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+)
+
+// Message is a fully addressed email ready for a transport. Bcc recipients
+// only take part in the envelope and are never written to the headers; a
+// Bcc-only message is addressed to the undisclosed-recipients group.
+type Message struct {
+	MessageID   string
+	From        *mail.Address
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []*port.MailAttachment
+	Date        time.Time
+}
+
+func (m *Message) Recipients() []string {
+	return slices.Concat(m.To, m.Cc, m.Bcc)
+}
+
+// Bytes renders the message as RFC 5322 text. Text and HTML bodies become a
+// multipart/alternative, wrapped in multipart/mixed when there are
+// attachments.
+func (m *Message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+
+	domain := "localhost"
+	if idx := strings.LastIndex(m.From.Address, "@"); idx != -1 {
+		domain = m.From.Address[idx+1:]
+	}
+
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", m.MessageID, domain)
+	fmt.Fprintf(&buf, "Date: %s\r\n", m.Date.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "From: %s\r\n", m.From.String())
+	if len(m.To) > 0 {
+		fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(m.To, ", "))
+	} else if len(m.Cc) == 0 {
+		buf.WriteString("To: undisclosed-recipients:;\r\n")
+	}
+	if len(m.Cc) > 0 {
+		fmt.Fprintf(&buf, "Cc: %s\r\n", strings.Join(m.Cc, ", "))
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	header, body, err := m.content()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.Attachments) == 0 {
+		writeHeader(&buf, header)
+		buf.Write(body)
+		return buf.Bytes(), nil
+	}
+
+	mixed := multipart.NewWriter(&buf)
+	writeHeader(&buf, textproto.MIMEHeader{
+		"Content-Type": {mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mixed.Boundary()})},
+	})
+
+	part, err := mixed.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = part.Write(body); err != nil {
+		return nil, err
+	}
+
+	for _, att := range m.Attachments {
+		if err = writeAttachment(mixed, att); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = mixed.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (m *Message) content() (textproto.MIMEHeader, []byte, error) {
+	if m.Text == "" || m.HTML == "" {
+		contentType, content := "text/plain; charset=UTF-8", m.Text
+		if m.HTML != "" {
+			contentType, content = "text/html; charset=UTF-8", m.HTML
+		}
+
+		body, err := encodeQuotedPrintable(content)
+		if err != nil {
+			return nil, nil, err
+		}
+		return textHeader(contentType), body, nil
+	}
+
+	var body bytes.Buffer
+	alt := multipart.NewWriter(&body)
+	for _, p := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", m.Text},
+		{"text/html; charset=UTF-8", m.HTML},
+	} {
+		part, err := alt.CreatePart(textHeader(p.contentType))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		encoded, err := encodeQuotedPrintable(p.content)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err = part.Write(encoded); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := alt.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	return textproto.MIMEHeader{
+		"Content-Type": {mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": alt.Boundary()})},
+	}, body.Bytes(), nil
+}
+
+func textHeader(contentType string) textproto.MIMEHeader {
+	return textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	}
+}
+
+func encodeQuotedPrintable(content string) ([]byte, error) {
+	var buf bytes.Buffer
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		for _, value := range header[key] {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+func writeAttachment(w *multipart.Writer, att *port.MailAttachment) error {
+	contentType := att.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": att.FileName})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": att.FileName})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(att.Content)
+	for len(encoded) > 76 {
+		if _, err = fmt.Fprintf(part, "%s\r\n", encoded[:76]); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err = fmt.Fprintf(part, "%s\r\n", encoded)
+	return err
+}
@@ -0,0 +1,104 @@
+package smtp
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+)
+
+//go:embed templates/mail/*
+var mailTemplates embed.FS
+
+// mailTemplate describes how a registered template builds its subject line.
+// SubjectArgs name the Data entries substituted into the localized subject.
+type mailTemplate struct {
+	SubjectKey  string
+	SubjectArgs []string
+}
+
+var registry = map[string]mailTemplate{
+	constants.EmailTemplateOTP:                 {SubjectKey: "email.forgot_password.subject"},
+	constants.EmailTemplateNotification:        {SubjectKey: "email.notification.subject"},
+	constants.EmailTemplateWelcome:             {SubjectKey: "email.welcome.subject"},
+	constants.EmailTemplatePasswordChanged:     {SubjectKey: "email.password_changed.subject"},
+	constants.EmailTemplateAccountDeactivated:  {SubjectKey: "email.account_deactivated.subject"},
+	constants.EmailTemplateBookingConfirmation: {SubjectKey: "email.booking_confirmation.subject", SubjectArgs: []string{"BookingCode"}},
+	constants.EmailTemplateInvoice:             {SubjectKey: "email.invoice.subject", SubjectArgs: []string{"InvoiceNumber"}},
+	constants.EmailTemplateNewLogin:            {SubjectKey: "email.new_login.subject"},
+	constants.EmailTemplateEmailChanged:        {SubjectKey: "email.email_changed.subject"},
+	constants.EmailTemplateMagicLink:           {SubjectKey: "email.magic_link.subject"},
+}
+
+type MailTemplateData struct {
+	Subject string
+	Locale  string
+	Data    map[string]any
+}
+
+type renderedMail struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// renderTemplate renders both bodies of a registered template. A non-empty
+// subject overrides the localized one from the registry.
+func renderTemplate(name, locale, subject string, data map[string]any) (*renderedMail, error) {
+	tmpl, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("email template %q is not registered", name)
+	}
+
+	locale = i18n.Normalize(locale)
+
+	if subject == "" {
+		args := make([]any, 0, len(tmpl.SubjectArgs))
+		for _, key := range tmpl.SubjectArgs {
+			args = append(args, data[key])
+		}
+		subject = i18n.T(locale, tmpl.SubjectKey, args...)
+	}
+
+	tmplData := MailTemplateData{
+		Subject: subject,
+		Locale:  locale,
+		Data:    data,
+	}
+
+	funcs := localeFuncs(locale)
+
+	htmlTmpl, err := htmlTemplate.New("layout.html").
+		Funcs(funcs).
+		ParseFS(mailTemplates, "templates/mail/layout.html", "templates/mail/"+name+".html")
+	if err != nil {
+		return nil, err
+	}
+
+	var html bytes.Buffer
+	if err = htmlTmpl.ExecuteTemplate(&html, "layout", tmplData); err != nil {
+		return nil, err
+	}
+
+	textTmpl, err := textTemplate.New("layout.txt").
+		Funcs(textTemplate.FuncMap(funcs)).
+		ParseFS(mailTemplates, "templates/mail/layout.txt", "templates/mail/"+name+".txt")
+	if err != nil {
+		return nil, err
+	}
+
+	var text bytes.Buffer
+	if err = textTmpl.ExecuteTemplate(&text, "layout", tmplData); err != nil {
+		return nil, err
+	}
+
+	return &renderedMail{
+		Subject: tmplData.Subject,
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
@@ -1,57 +1,87 @@
 package smtp
 
 import (
-	"bytes"
-	"embed"
+	"context"
 	"fmt"
 	"html/template"
-	"net/smtp"
+	"net/mail"
+	"time"
 
 	"github.com/InstaySystem/is_v2-be/internal/application/port"
 	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/google/uuid"
 )
 
-//go:embed templates/auth.html
-var authTemplate embed.FS
-
-type AuthEmailData struct {
-	Subject string `json:"subject"`
-	Otp     string `json:"otp"`
-}
-
 type smtpProviderImpl struct {
-	cfg  config.SMTPConfig
-	auth smtp.Auth
+	from      *mail.Address
+	transport transport
 }
 
-func NewSMTPProvider(cfg config.SMTPConfig) port.SMTPProvider {
-	auth := smtp.PlainAuth("", cfg.User, cfg.Password, cfg.Host)
-	return &smtpProviderImpl{
-		cfg,
-		auth,
+func NewSMTPProvider(cfg config.SMTPConfig) (port.SMTPProvider, error) {
+	if cfg.From == "" {
+		cfg.From = cfg.User
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	var tr transport
+	var err error
+	switch cfg.Driver {
+	case "", constants.EmailDriverSMTP:
+		tr, err = newSMTPTransport(cfg)
+	case constants.EmailDriverHTTP:
+		tr, err = newHTTPTransport(cfg)
+	case constants.EmailDriverFile:
+		tr, err = newFileTransport(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported email driver %q", cfg.Driver)
+	}
+	if err != nil {
+		return nil, err
 	}
-}
 
-func (s *smtpProviderImpl) Send(to, subject, body string) error {
-	msg := fmt.Appendf(nil, "Subject: %s\r\nMIME-version: 1.0;\r\nContent-Type: text/html; charset=\"UTF-8\";\r\n\r\n%s", subject, body)
-	addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
-	return smtp.SendMail(addr, s.auth, s.cfg.User, []string{to}, msg)
+	return &smtpProviderImpl{
+		&mail.Address{Name: cfg.FromName, Address: cfg.From},
+		tr,
+	}, nil
 }
 
-func (s *smtpProviderImpl) AuthEmail(to, subject, otp string) error {
-	tmpl, err := template.ParseFS(authTemplate, "templates/auth.html")
+func (s *smtpProviderImpl) SendTemplate(ctx context.Context, mail *port.TemplateMail) error {
+	rendered, err := renderTemplate(mail.Template, mail.Locale, mail.Subject, mail.Data)
 	if err != nil {
 		return err
 	}
 
-	var body bytes.Buffer
-	data := AuthEmailData{
-		Subject: subject,
-		Otp:     otp,
-	}
-	if err := tmpl.Execute(&body, data); err != nil {
-		return err
+	messageID := mail.MessageID
+	if messageID == "" {
+		messageID = uuid.NewString()
 	}
 
-	return s.Send(to, subject, body.String())
+	return s.transport.Send(ctx, &Message{
+		MessageID:   messageID,
+		From:        s.from,
+		To:          mail.To,
+		Cc:          mail.Cc,
+		Bcc:         mail.Bcc,
+		Subject:     rendered.Subject,
+		Text:        rendered.Text,
+		HTML:        rendered.HTML,
+		Attachments: mail.Attachments,
+		Date:        time.Now(),
+	})
+}
+
+func (s *smtpProviderImpl) Close() error {
+	return s.transport.Close()
+}
+
+func localeFuncs(locale string) template.FuncMap {
+	return template.FuncMap{
+		"t": func(key string, args ...any) string {
+			return i18n.T(locale, key, args...)
+		},
+	}
 }
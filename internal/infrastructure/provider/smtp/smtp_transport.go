@@ -0,0 +1,264 @@
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"sync/atomic"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+)
+
+// smtpTransport keeps up to PoolSize authenticated connections open and
+// reuses them across messages. A connection that fails mid-transaction is
+// discarded, since its protocol state is unknown.
+type smtpTransport struct {
+	cfg    config.SMTPConfig
+	addr   string
+	sem    chan struct{}
+	idle   chan *smtpConn
+	closed atomic.Bool
+}
+
+type smtpConn struct {
+	client   *smtp.Client
+	conn     net.Conn
+	lastUsed time.Time
+}
+
+func newSMTPTransport(cfg config.SMTPConfig) (*smtpTransport, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("smtp host is required")
+	}
+
+	switch cfg.TLSMode {
+	case "":
+		cfg.TLSMode = constants.SMTPTLSStartTLS
+	case constants.SMTPTLSNone, constants.SMTPTLSStartTLS, constants.SMTPTLSImplicit:
+	default:
+		return nil, fmt.Errorf("unsupported smtp tls mode %q", cfg.TLSMode)
+	}
+
+	if cfg.Port == 0 {
+		cfg.Port = 587
+		if cfg.TLSMode == constants.SMTPTLSImplicit {
+			cfg.Port = 465
+		}
+	}
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 4
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = 30 * time.Second
+	}
+
+	return &smtpTransport{
+		cfg:  cfg,
+		addr: net.JoinHostPort(cfg.Host, fmt.Sprint(cfg.Port)),
+		sem:  make(chan struct{}, cfg.PoolSize),
+		idle: make(chan *smtpConn, cfg.PoolSize),
+	}, nil
+}
+
+func (t *smtpTransport) Send(ctx context.Context, msg *Message) error {
+	raw, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case t.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-t.sem }()
+
+	c, err := t.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	rejected, err := t.deliver(c, msg, raw)
+	if err != nil {
+		c.close()
+		return err
+	}
+
+	t.put(c)
+	if rejected != nil {
+		return rejected
+	}
+	return nil
+}
+
+func (t *smtpTransport) Close() error {
+	t.closed.Store(true)
+	for {
+		select {
+		case c := <-t.idle:
+			c.quit(t.cfg.Timeout)
+		default:
+			return nil
+		}
+	}
+}
+
+// deliver sends msg on c. Recipients refused with a permanent 5xx reply are
+// skipped and reported back so the rest still receive the message; any other
+// failure aborts the transaction and is returned as err.
+func (t *smtpTransport) deliver(c *smtpConn, msg *Message, raw []byte) (*port.RecipientsRejectedError, error) {
+	if err := c.conn.SetDeadline(time.Now().Add(t.cfg.Timeout)); err != nil {
+		return nil, err
+	}
+
+	if err := c.client.Mail(msg.From.Address); err != nil {
+		return nil, err
+	}
+
+	recipients := msg.Recipients()
+	var rejected *port.RecipientsRejectedError
+	for _, rcpt := range recipients {
+		err := c.client.Rcpt(rcpt)
+		if err == nil {
+			continue
+		}
+
+		var protoErr *textproto.Error
+		if !errors.As(err, &protoErr) || protoErr.Code < 500 {
+			return nil, err
+		}
+		if rejected == nil {
+			rejected = &port.RecipientsRejectedError{Rejected: make(map[string]error)}
+		}
+		rejected.Rejected[rcpt] = err
+	}
+
+	if rejected != nil && len(rejected.Rejected) == len(recipients) {
+		if err := c.client.Reset(); err != nil {
+			return nil, err
+		}
+		return rejected, nil
+	}
+
+	w, err := c.client.Data()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(raw); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	if rejected != nil {
+		rejected.Delivered = true
+	}
+	return rejected, nil
+}
+
+// get reuses an idle connection when it is still fresh and answers RSET,
+// otherwise it dials a new one.
+func (t *smtpTransport) get(ctx context.Context) (*smtpConn, error) {
+	for {
+		select {
+		case c := <-t.idle:
+			if time.Since(c.lastUsed) > t.cfg.IdleTimeout {
+				c.quit(t.cfg.Timeout)
+				continue
+			}
+			if err := c.conn.SetDeadline(time.Now().Add(t.cfg.Timeout)); err != nil {
+				c.close()
+				continue
+			}
+			if err := c.client.Reset(); err != nil {
+				c.close()
+				continue
+			}
+			return c, nil
+		default:
+			return t.dial(ctx)
+		}
+	}
+}
+
+func (t *smtpTransport) put(c *smtpConn) {
+	if t.closed.Load() {
+		c.quit(t.cfg.Timeout)
+		return
+	}
+
+	c.lastUsed = time.Now()
+	select {
+	case t.idle <- c:
+	default:
+		c.quit(t.cfg.Timeout)
+	}
+}
+
+func (t *smtpTransport) dial(ctx context.Context) (*smtpConn, error) {
+	dialer := &net.Dialer{Timeout: t.cfg.Timeout}
+	tlsConfig := &tls.Config{ServerName: t.cfg.Host, MinVersion: tls.VersionTLS12}
+
+	var conn net.Conn
+	var err error
+	if t.cfg.TLSMode == constants.SMTPTLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", t.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", t.addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = conn.SetDeadline(time.Now().Add(t.cfg.Timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, t.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if t.cfg.TLSMode == constants.SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, errors.New("smtp server does not support STARTTLS")
+		}
+		if err = client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	if t.cfg.User != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err = client.Auth(smtp.PlainAuth("", t.cfg.User, t.cfg.Password, t.cfg.Host)); err != nil {
+				client.Close()
+				return nil, err
+			}
+		}
+	}
+
+	return &smtpConn{client: client, conn: conn}, nil
+}
+
+func (c *smtpConn) quit(timeout time.Duration) {
+	c.conn.SetDeadline(time.Now().Add(timeout))
+	if err := c.client.Quit(); err != nil {
+		c.client.Close()
+	}
+}
+
+func (c *smtpConn) close() {
+	c.client.Close()
+}
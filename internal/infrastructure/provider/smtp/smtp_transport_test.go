@@ -0,0 +1,194 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/InstaySystem/is_v2-be/internal/infrastructure/config"
+	"github.com/InstaySystem/is_v2-be/pkg/constants"
+)
+
+// fakeSMTPServer answers RCPT TO with the code configured for the address
+// (250 by default) and records the commands it receives.
+type fakeSMTPServer struct {
+	ln       net.Listener
+	rcptCode map[string]int
+
+	mu       sync.Mutex
+	commands []string
+}
+
+func newFakeSMTPServer(t *testing.T, rcptCode map[string]int) *fakeSMTPServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTPServer{ln: ln, rcptCode: rcptCode}
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 fake ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		s.mu.Lock()
+		s.commands = append(s.commands, verb)
+		s.mu.Unlock()
+
+		switch verb {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 fake")
+		case "RCPT":
+			rcpt := strings.Trim(strings.TrimPrefix(strings.ToUpper(line), "RCPT TO:"), "<>")
+			code := 250
+			for addr, c := range s.rcptCode {
+				if strings.EqualFold(addr, rcpt) {
+					code = c
+				}
+			}
+			tp.PrintfLine("%d recipient", code)
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			if _, err = tp.ReadDotBytes(); err != nil {
+				return
+			}
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("250 ok")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) received(verb string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cmd := range s.commands {
+		if cmd == verb {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeSMTPServer) transport(t *testing.T) *smtpTransport {
+	t.Helper()
+
+	host, port, err := net.SplitHostPort(s.ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	portNum, _ := strconv.Atoi(port)
+
+	tr, err := newSMTPTransport(config.SMTPConfig{
+		Host:    host,
+		Port:    portNum,
+		TLSMode: constants.SMTPTLSNone,
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tr.Close() })
+	return tr
+}
+
+func testMessage(to ...string) *Message {
+	return &Message{
+		MessageID: "test",
+		From:      &mail.Address{Address: "noreply@instay.test"},
+		To:        to,
+		Subject:   "Hello",
+		Text:      "Hello",
+		Date:      time.Now(),
+	}
+}
+
+func TestSMTPTransportRecipientRejection(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		to            []string
+		wantRejected  []string
+		wantDelivered bool
+	}{
+		{"one of two rejected", []string{"ok@instay.test", "gone@instay.test"}, []string{"gone@instay.test"}, true},
+		{"every recipient rejected", []string{"gone@instay.test"}, []string{"gone@instay.test"}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newFakeSMTPServer(t, map[string]int{"gone@instay.test": 550})
+
+			err := srv.transport(t).Send(context.Background(), testMessage(tc.to...))
+
+			var rejected *port.RecipientsRejectedError
+			if !errors.As(err, &rejected) {
+				t.Fatalf("err = %v, want RecipientsRejectedError", err)
+			}
+			if len(rejected.Rejected) != len(tc.wantRejected) {
+				t.Fatalf("rejected = %v, want %v", rejected.Rejected, tc.wantRejected)
+			}
+			for _, rcpt := range tc.wantRejected {
+				var protoErr *textproto.Error
+				if !errors.As(rejected.Rejected[rcpt], &protoErr) || protoErr.Code != 550 {
+					t.Fatalf("%s: err = %v, want 550", rcpt, rejected.Rejected[rcpt])
+				}
+			}
+			if rejected.Delivered != tc.wantDelivered {
+				t.Fatalf("Delivered = %v, want %v", rejected.Delivered, tc.wantDelivered)
+			}
+			if srv.received("DATA") != tc.wantDelivered {
+				t.Fatalf("DATA sent = %v, want %v", srv.received("DATA"), tc.wantDelivered)
+			}
+		})
+	}
+}
+
+func TestSMTPTransportTemporaryRcptFailureAborts(t *testing.T) {
+	srv := newFakeSMTPServer(t, map[string]int{"busy@instay.test": 451})
+
+	err := srv.transport(t).Send(context.Background(), testMessage("ok@instay.test", "busy@instay.test"))
+
+	var rejected *port.RecipientsRejectedError
+	if errors.As(err, &rejected) {
+		t.Fatalf("temporary failure reported as rejection: %v", err)
+	}
+	var protoErr *textproto.Error
+	if !errors.As(err, &protoErr) || protoErr.Code != 451 {
+		t.Fatalf("err = %v, want 451", err)
+	}
+	if srv.received("DATA") {
+		t.Fatal("message sent despite the temporary failure")
+	}
+}
@@ -0,0 +1,11 @@
+package smtp
+
+import "context"
+
+// transport delivers a rendered message. Implementations must be safe for
+// concurrent use by the consumer workers.
+type transport interface {
+	Send(ctx context.Context, msg *Message) error
+
+	Close() error
+}
@@ -0,0 +1,169 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/InstaySystem/is_v2-be/internal/application/port"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	channelPrefix      = "realtime:"
+	subscriptionBuffer = 16
+)
+
+type Message struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Hub holds a single Redis pattern subscription per server replica and fans
+// messages out to the streams connected to that replica.
+type Hub struct {
+	rdb    *redis.Client
+	log    *zap.Logger
+	mu     sync.RWMutex
+	subs   map[string]map[*Subscription]struct{}
+	pubsub *redis.PubSub
+	closed bool
+}
+
+var _ port.RealtimeProvider = (*Hub)(nil)
+
+type Subscription struct {
+	C        <-chan *Message
+	ch       chan *Message
+	channels []string
+	hub      *Hub
+	once     sync.Once
+}
+
+func NewHub(rdb *redis.Client, log *zap.Logger) *Hub {
+	return &Hub{
+		rdb:  rdb,
+		log:  log,
+		subs: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+func UserChannel(userID int64) string {
+	return fmt.Sprintf("%suser:%d", channelPrefix, userID)
+}
+
+func DepartmentChannel(departmentID int64) string {
+	return fmt.Sprintf("%sdepartment:%d", channelPrefix, departmentID)
+}
+
+func (h *Hub) Start(ctx context.Context) error {
+	h.pubsub = h.rdb.PSubscribe(ctx, channelPrefix+"*")
+	if _, err := h.pubsub.Receive(ctx); err != nil {
+		_ = h.pubsub.Close()
+		return err
+	}
+
+	go func() {
+		for msg := range h.pubsub.Channel() {
+			h.dispatch(msg.Channel, msg.Payload)
+		}
+	}()
+
+	return nil
+}
+
+func (h *Hub) Close() {
+	if h.pubsub != nil {
+		_ = h.pubsub.Close()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, subs := range h.subs {
+		for sub := range subs {
+			sub.once.Do(func() { close(sub.ch) })
+		}
+	}
+	h.subs = make(map[string]map[*Subscription]struct{})
+}
+
+func (h *Hub) Subscribe(channels ...string) *Subscription {
+	ch := make(chan *Message, subscriptionBuffer)
+	sub := &Subscription{
+		C:        ch,
+		ch:       ch,
+		channels: channels,
+		hub:      h,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		sub.once.Do(func() { close(sub.ch) })
+		return sub
+	}
+
+	for _, channel := range channels {
+		if h.subs[channel] == nil {
+			h.subs[channel] = make(map[*Subscription]struct{})
+		}
+		h.subs[channel][sub] = struct{}{}
+	}
+
+	return sub
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	for _, channel := range s.channels {
+		delete(s.hub.subs[channel], s)
+		if len(s.hub.subs[channel]) == 0 {
+			delete(s.hub.subs, channel)
+		}
+	}
+
+	s.once.Do(func() { close(s.ch) })
+}
+
+func (h *Hub) PublishToUser(ctx context.Context, userID int64, event port.RealtimeEvent) error {
+	return h.publish(ctx, UserChannel(userID), event)
+}
+
+func (h *Hub) PublishToDepartment(ctx context.Context, departmentID int64, event port.RealtimeEvent) error {
+	return h.publish(ctx, DepartmentChannel(departmentID), event)
+}
+
+func (h *Hub) publish(ctx context.Context, channel string, event port.RealtimeEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return h.rdb.Publish(ctx, channel, payload).Err()
+}
+
+func (h *Hub) dispatch(channel, payload string) {
+	var msg Message
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		h.log.Warn("decode realtime message failed", zap.String("channel", channel), zap.Error(err))
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subs[channel] {
+		select {
+		case sub.ch <- &msg:
+		default:
+			h.log.Warn("realtime subscriber is too slow, message dropped", zap.String("channel", channel), zap.String("type", msg.Type))
+		}
+	}
+}
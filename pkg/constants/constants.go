@@ -1,46 +1,162 @@
 package constants
 
 const (
-	CodeSuccess                     = 1000
-	CodeLoginSuccess                = 1001
-	CodeLogoutSuccess               = 1002
-	CodeChangePasswordSuccess       = 1003
-	CodeForgotPasswordSuccess       = 1004
-	CodeVerifyForgotPasswordSuccess = 1005
-	CodeResetPasswordSuccess        = 1006
-	CodeUpdateInfoSuccess           = 1007
-	CodeCreateUserSuccess           = 1008
-	CodeCreateDepartmentSuccess     = 1009
-	CodeUpdateUserSuccess           = 1010
-	CodeUpdateUserPasswordSuccess   = 1011
-	CodeDeleteUserSuccess           = 1012
-	CodeDeleteUsersSuccess          = 1013
-	CodeBadRequest                  = 4000
-	CodeLoginFailed                 = 4001
-	CodeInvalidToken                = 4002
-	CodeUnAuth                      = 4003
-	CodeNoRefreshToken              = 4004
-	CodeUserNotFound                = 4005
-	CodeInvalidPassword             = 4006
-	CodeEmailDoesNotExist           = 4007
-	CodeTooManyAttempts             = 4008
-	CodeInvalidOTP                  = 4009
-	CodeEmailAlreadyExists          = 4010
-	CodePhoneAlreadyExists          = 4011
-	CodeDepartmentNotFound          = 4012
-	CodeUsernameAlreadyExists       = 4013
-	CodeForbidden                   = 4014
-	CodeNameAlreadyExists           = 4015
-	CodeInvalidID                   = 4016
-	CodeNeedAdmin                   = 4017
-	CodeProtectedRecord             = 4018
-	CodeHasUserNotFound             = 4019
-	CodeInternalError               = 5000
+	CodeSuccess                       = 1000
+	CodeLoginSuccess                  = 1001
+	CodeLogoutSuccess                 = 1002
+	CodeChangePasswordSuccess         = 1003
+	CodeForgotPasswordSuccess         = 1004
+	CodeVerifyForgotPasswordSuccess   = 1005
+	CodeResetPasswordSuccess          = 1006
+	CodeUpdateInfoSuccess             = 1007
+	CodeCreateUserSuccess             = 1008
+	CodeCreateDepartmentSuccess       = 1009
+	CodeUpdateUserSuccess             = 1010
+	CodeUpdateUserPasswordSuccess     = 1011
+	CodeDeleteUserSuccess             = 1012
+	CodeDeleteUsersSuccess            = 1013
+	CodeConfirmFilesSuccess           = 1014
+	CodeCreateUploadSuccess           = 1015
+	CodeCompleteUploadSuccess         = 1016
+	CodeAbortUploadSuccess            = 1017
+	CodeMarkNotificationReadSuccess   = 1018
+	CodeMarkNotificationsReadSuccess  = 1019
+	CodeDeleteEmailSuppressionSuccess = 1020
+	CodeRequestMagicLinkSuccess       = 1021
+	CodeUpdateRoleSettingSuccess      = 1022
+	CodeStartImpersonationSuccess     = 1023
+	CodeStopImpersonationSuccess      = 1024
+	CodeCreatePropertySuccess         = 1025
+	CodeUpdatePropertySuccess         = 1026
+	CodeCreateShiftSuccess            = 1027
+	CodeUpdateShiftSuccess            = 1028
+	CodeDeleteShiftSuccess            = 1029
+	CodeAssignShiftSuccess            = 1030
+	CodeUnassignShiftSuccess          = 1031
+	CodeNotifyOnDutySuccess           = 1032
+	CodeBadRequest                    = 4000
+	CodeLoginFailed                   = 4001
+	CodeInvalidToken                  = 4002
+	CodeUnAuth                        = 4003
+	CodeNoRefreshToken                = 4004
+	CodeUserNotFound                  = 4005
+	CodeInvalidPassword               = 4006
+	CodeEmailDoesNotExist             = 4007
+	CodeTooManyAttempts               = 4008
+	CodeInvalidOTP                    = 4009
+	CodeEmailAlreadyExists            = 4010
+	CodePhoneAlreadyExists            = 4011
+	CodeDepartmentNotFound            = 4012
+	CodeUsernameAlreadyExists         = 4013
+	CodeForbidden                     = 4014
+	CodeNameAlreadyExists             = 4015
+	CodeInvalidID                     = 4016
+	CodeNeedAdmin                     = 4017
+	CodeProtectedRecord               = 4018
+	CodeHasUserNotFound               = 4019
+	CodeWeakPassword                  = 4020
+	CodePasswordReused                = 4021
+	CodePasswordExpired               = 4022
+	CodeFileNotFound                  = 4023
+	CodeFileNotUploaded               = 4024
+	CodeContentTypeNotAllowed         = 4025
+	CodeFileTooLarge                  = 4026
+	CodeUploadQuotaExceeded           = 4027
+	CodeMultipartUploadNotFound       = 4028
+	CodeInvalidUploadParts            = 4029
+	CodeInvalidAvatar                 = 4030
+	CodeNotificationNotFound          = 4031
+	CodeEmailSuppressed               = 4032
+	CodeEmailSuppressionNotFound      = 4033
+	CodePhoneDoesNotExist             = 4034
+	CodeTooManyOTPRequests            = 4035
+	CodeInvalidRole                   = 4036
+	CodeOIDCDisabled                  = 4037
+	CodeOIDCLoginFailed               = 4038
+	CodeOIDCAccountNotFound           = 4039
+	CodeOIDCProvisioningFailed        = 4040
+	CodeImpersonationRestricted       = 4041
+	CodeCannotImpersonateUser         = 4042
+	CodeNotImpersonating              = 4043
+	CodePropertyNotFound              = 4044
+	CodePropertyCodeAlreadyExists     = 4045
+	CodePropertyMismatch              = 4046
+	CodeShiftNotFound                 = 4047
+	CodeInvalidShiftTime              = 4048
+	CodeShiftConflict                 = 4049
+	CodeUserNotInDepartment           = 4050
+	CodeAlreadyAssignedToShift        = 4051
+	CodeShiftAssignmentNotFound       = 4052
+	CodePropertyRequired              = 4053
+	CodeMultipartUploadRequired       = 4054
+	CodeInternalError                 = 5000
 
 	ExchangeEmail       = "email.send"
 	QueueNameAuthEmail  = "email.send.auth"
 	RoutingKeyAuthEmail = "email.send.auth"
 
-	RoleAdminDisplayName = "Quản trị viên"
-	RoleStaffDisplayName = "Nhân viên"
+	QueueNameNotificationEmail  = "email.send.notification"
+	RoutingKeyNotificationEmail = "email.send.notification"
+
+	QueueNameTemplateEmail             = "email.send.template"
+	RoutingKeyWelcomeEmail             = "email.send.welcome"
+	RoutingKeyPasswordChangedEmail     = "email.send.password-changed"
+	RoutingKeyAccountDeactivatedEmail  = "email.send.account-deactivated"
+	RoutingKeyBookingConfirmationEmail = "email.send.booking-confirmation"
+	RoutingKeyInvoiceEmail             = "email.send.invoice"
+	RoutingKeyNewLoginEmail            = "email.send.new-login"
+	RoutingKeyEmailChangedEmail        = "email.send.email-changed"
+	RoutingKeyMagicLinkEmail           = "email.send.magic-link"
+
+	EmailTemplateOTP                 = "otp"
+	EmailTemplateNotification        = "notification"
+	EmailTemplateWelcome             = "welcome"
+	EmailTemplatePasswordChanged     = "password-changed"
+	EmailTemplateAccountDeactivated  = "account-deactivated"
+	EmailTemplateBookingConfirmation = "booking-confirmation"
+	EmailTemplateInvoice             = "invoice"
+	EmailTemplateNewLogin            = "new-login"
+	EmailTemplateEmailChanged        = "email-changed"
+	EmailTemplateMagicLink           = "magic-link"
+
+	ExchangeSMS      = "sms.send"
+	QueueNameOTPSMS  = "sms.send.otp"
+	RoutingKeyOTPSMS = "sms.send.otp"
+
+	ExchangeFile            = "file.process"
+	QueueNameImageVariants  = "file.process.image"
+	RoutingKeyImageVariants = "file.process.image"
+
+	EventSessionRevoked = "session.revoked"
+	EventSessionExpired = "session.expired"
+	EventUserUpdated    = "user.updated"
+
+	EventNotificationCreated = "notification.created"
+
+	NotificationTypeAnnouncement = "announcement"
+
+	NotificationAudienceOnDuty     = "on_duty"
+	NotificationAudienceDepartment = "department"
+
+	StorageDriverS3    = "s3"
+	StorageDriverLocal = "local"
+
+	// Only objects under this prefix may be served without a presigned URL.
+	StoragePublicPrefix = "public/avatars/"
+
+	EmailDriverSMTP = "smtp"
+	EmailDriverHTTP = "http"
+	EmailDriverFile = "file"
+
+	SMTPTLSNone     = "none"
+	SMTPTLSStartTLS = "starttls"
+	SMTPTLSImplicit = "tls"
+
+	SMSDriverLog  = "log"
+	SMSDriverHTTP = "http"
+	SMSDriverZalo = "zalo"
+
+	OTPChannelEmail     = "email"
+	OTPChannelPhone     = "phone"
+	OTPChannelMagicLink = "magic_link"
 )
@@ -4,62 +4,135 @@ import (
 	"net/http"
 
 	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
 )
 
 var (
-	ErrLoginFailed = NewAPIError(http.StatusBadRequest, constants.CodeLoginFailed, "Incorrect username or password")
+	ErrLoginFailed = NewAPIError(http.StatusBadRequest, constants.CodeLoginFailed, "error.login_failed")
 
-	ErrInvalidToken = NewAPIError(http.StatusBadRequest, constants.CodeInvalidToken, "Invalid or expired token")
+	ErrInvalidToken = NewAPIError(http.StatusBadRequest, constants.CodeInvalidToken, "error.invalid_token")
 
-	ErrBadRequest = NewAPIError(http.StatusBadRequest, constants.CodeBadRequest, "Invalid data")
+	ErrBadRequest = NewAPIError(http.StatusBadRequest, constants.CodeBadRequest, "error.bad_request")
 
-	ErrUnAuth = NewAPIError(http.StatusUnauthorized, constants.CodeUnAuth, "Unauthorized")
+	ErrUnAuth = NewAPIError(http.StatusUnauthorized, constants.CodeUnAuth, "error.unauthorized")
 
-	ErrForbidden = NewAPIError(http.StatusForbidden, constants.CodeForbidden, "Forbidden")
+	ErrForbidden = NewAPIError(http.StatusForbidden, constants.CodeForbidden, "error.forbidden")
 
-	ErrInvalidPassword = NewAPIError(http.StatusBadRequest, constants.CodeInvalidPassword, "Incorrect password")
+	ErrInvalidPassword = NewAPIError(http.StatusBadRequest, constants.CodeInvalidPassword, "error.invalid_password")
 
-	ErrInvalidUser = NewAPIError(http.StatusForbidden, constants.CodeNoRefreshToken, "Please login again")
+	ErrInvalidUser = NewAPIError(http.StatusForbidden, constants.CodeNoRefreshToken, "error.invalid_user")
 
-	ErrUserNotFound = NewAPIError(http.StatusNotFound, constants.CodeUserNotFound, "User not found")
+	ErrUserNotFound = NewAPIError(http.StatusNotFound, constants.CodeUserNotFound, "error.user_not_found")
 
-	ErrEmailDoesNotExist = NewAPIError(http.StatusBadRequest, constants.CodeEmailDoesNotExist, "Email does not exist")
+	ErrEmailDoesNotExist = NewAPIError(http.StatusBadRequest, constants.CodeEmailDoesNotExist, "error.email_does_not_exist")
 
-	ErrTooManyAttempts = NewAPIError(http.StatusTooManyRequests, constants.CodeTooManyAttempts, "Too many attempts")
+	ErrTooManyAttempts = NewAPIError(http.StatusTooManyRequests, constants.CodeTooManyAttempts, "error.too_many_attempts")
 
-	ErrInvalidOTP = NewAPIError(http.StatusBadRequest, constants.CodeInvalidOTP, "Invalid or expired OTP")
+	ErrInvalidOTP = NewAPIError(http.StatusBadRequest, constants.CodeInvalidOTP, "error.invalid_otp")
 
-	ErrEmailAlreadyExists = NewAPIError(http.StatusConflict, constants.CodeEmailAlreadyExists, "Email already exists")
+	ErrEmailAlreadyExists = NewAPIError(http.StatusConflict, constants.CodeEmailAlreadyExists, "error.email_already_exists")
 
-	ErrNameAlreadyExists = NewAPIError(http.StatusConflict, constants.CodeNameAlreadyExists, "Name already exists")
+	ErrNameAlreadyExists = NewAPIError(http.StatusConflict, constants.CodeNameAlreadyExists, "error.name_already_exists")
 
-	ErrPhoneAlreadyExists = NewAPIError(http.StatusConflict, constants.CodePhoneAlreadyExists, "Phone already exists")
+	ErrPhoneAlreadyExists = NewAPIError(http.StatusConflict, constants.CodePhoneAlreadyExists, "error.phone_already_exists")
 
-	ErrDepartmentNotFound = NewAPIError(http.StatusNotFound, constants.CodeDepartmentNotFound, "Department not found")
+	ErrDepartmentNotFound = NewAPIError(http.StatusNotFound, constants.CodeDepartmentNotFound, "error.department_not_found")
 
-	ErrInvalidID = NewAPIError(http.StatusBadRequest, constants.CodeInvalidID, "Invalid id")
+	ErrInvalidID = NewAPIError(http.StatusBadRequest, constants.CodeInvalidID, "error.invalid_id")
 
-	ErrProtectedRecord = NewAPIError(http.StatusConflict, constants.CodeProtectedRecord, "Protected record")
+	ErrProtectedRecord = NewAPIError(http.StatusConflict, constants.CodeProtectedRecord, "error.protected_record")
 
-	ErrHasUserNotFound = NewAPIError(http.StatusConflict, constants.CodeHasUserNotFound, "Has user not found")
+	ErrHasUserNotFound = NewAPIError(http.StatusConflict, constants.CodeHasUserNotFound, "error.has_user_not_found")
 
-	ErrNeedAdmin = NewAPIError(http.StatusBadRequest, constants.CodeNeedAdmin, "Need 1 active administrator")
+	ErrNeedAdmin = NewAPIError(http.StatusBadRequest, constants.CodeNeedAdmin, "error.need_admin")
 
-	ErrUsernameAlreadyExists = NewAPIError(http.StatusConflict, constants.CodeUsernameAlreadyExists, "Username already exists")
+	ErrUsernameAlreadyExists = NewAPIError(http.StatusConflict, constants.CodeUsernameAlreadyExists, "error.username_already_exists")
+
+	ErrWeakPassword = NewAPIError(http.StatusBadRequest, constants.CodeWeakPassword, "error.weak_password")
+
+	ErrPasswordReused = NewAPIError(http.StatusBadRequest, constants.CodePasswordReused, "error.password_reused")
+
+	ErrPasswordExpired = NewAPIError(http.StatusForbidden, constants.CodePasswordExpired, "error.password_expired")
+
+	ErrFileNotFound = NewAPIError(http.StatusNotFound, constants.CodeFileNotFound, "error.file_not_found")
+
+	ErrFileNotUploaded = NewAPIError(http.StatusBadRequest, constants.CodeFileNotUploaded, "error.file_not_uploaded")
+
+	ErrContentTypeNotAllowed = NewAPIError(http.StatusBadRequest, constants.CodeContentTypeNotAllowed, "error.content_type_not_allowed")
+
+	ErrFileTooLarge = NewAPIError(http.StatusRequestEntityTooLarge, constants.CodeFileTooLarge, "error.file_too_large")
+
+	ErrUploadQuotaExceeded = NewAPIError(http.StatusTooManyRequests, constants.CodeUploadQuotaExceeded, "error.upload_quota_exceeded")
+
+	ErrMultipartUploadNotFound = NewAPIError(http.StatusNotFound, constants.CodeMultipartUploadNotFound, "error.multipart_upload_not_found")
+
+	ErrInvalidUploadParts = NewAPIError(http.StatusBadRequest, constants.CodeInvalidUploadParts, "error.invalid_upload_parts")
+
+	ErrInvalidAvatar = NewAPIError(http.StatusBadRequest, constants.CodeInvalidAvatar, "error.invalid_avatar")
+
+	ErrNotificationNotFound = NewAPIError(http.StatusNotFound, constants.CodeNotificationNotFound, "error.notification_not_found")
+
+	ErrEmailSuppressed = NewAPIError(http.StatusUnprocessableEntity, constants.CodeEmailSuppressed, "error.email_suppressed")
+
+	ErrEmailSuppressionNotFound = NewAPIError(http.StatusNotFound, constants.CodeEmailSuppressionNotFound, "error.email_suppression_not_found")
+
+	ErrPhoneDoesNotExist = NewAPIError(http.StatusBadRequest, constants.CodePhoneDoesNotExist, "error.phone_does_not_exist")
+
+	ErrTooManyOTPRequests = NewAPIError(http.StatusTooManyRequests, constants.CodeTooManyOTPRequests, "error.too_many_otp_requests")
+
+	ErrInvalidRole = NewAPIError(http.StatusBadRequest, constants.CodeInvalidRole, "error.invalid_role")
+
+	ErrOIDCDisabled = NewAPIError(http.StatusNotFound, constants.CodeOIDCDisabled, "error.oidc_disabled")
+
+	ErrOIDCLoginFailed = NewAPIError(http.StatusUnauthorized, constants.CodeOIDCLoginFailed, "error.oidc_login_failed")
+
+	ErrOIDCAccountNotFound = NewAPIError(http.StatusForbidden, constants.CodeOIDCAccountNotFound, "error.oidc_account_not_found")
+
+	ErrOIDCProvisioningFailed = NewAPIError(http.StatusUnprocessableEntity, constants.CodeOIDCProvisioningFailed, "error.oidc_provisioning_failed")
+
+	ErrImpersonationRestricted = NewAPIError(http.StatusForbidden, constants.CodeImpersonationRestricted, "error.impersonation_restricted")
+
+	ErrCannotImpersonateUser = NewAPIError(http.StatusForbidden, constants.CodeCannotImpersonateUser, "error.cannot_impersonate_user")
+
+	ErrNotImpersonating = NewAPIError(http.StatusBadRequest, constants.CodeNotImpersonating, "error.not_impersonating")
+
+	ErrPropertyNotFound = NewAPIError(http.StatusNotFound, constants.CodePropertyNotFound, "error.property_not_found")
+
+	ErrPropertyCodeAlreadyExists = NewAPIError(http.StatusConflict, constants.CodePropertyCodeAlreadyExists, "error.property_code_already_exists")
+
+	ErrPropertyMismatch = NewAPIError(http.StatusForbidden, constants.CodePropertyMismatch, "error.property_mismatch")
+
+	ErrShiftNotFound = NewAPIError(http.StatusNotFound, constants.CodeShiftNotFound, "error.shift_not_found")
+
+	ErrInvalidShiftTime = NewAPIError(http.StatusBadRequest, constants.CodeInvalidShiftTime, "error.invalid_shift_time")
+
+	ErrShiftConflict = NewAPIError(http.StatusConflict, constants.CodeShiftConflict, "error.shift_conflict")
+
+	ErrUserNotInDepartment = NewAPIError(http.StatusBadRequest, constants.CodeUserNotInDepartment, "error.user_not_in_department")
+
+	ErrAlreadyAssignedToShift = NewAPIError(http.StatusConflict, constants.CodeAlreadyAssignedToShift, "error.already_assigned_to_shift")
+
+	ErrShiftAssignmentNotFound = NewAPIError(http.StatusNotFound, constants.CodeShiftAssignmentNotFound, "error.shift_assignment_not_found")
+
+	ErrPropertyRequired = NewAPIError(http.StatusBadRequest, constants.CodePropertyRequired, "error.property_required")
+
+	ErrMultipartUploadRequired = NewAPIError(http.StatusBadRequest, constants.CodeMultipartUploadRequired, "error.multipart_upload_required")
 )
 
 type APIError struct {
 	Status  int
 	Code    int
+	Key     string
 	Message string
 	Data    any
 }
 
-func NewAPIError(status, code int, message string) *APIError {
+func NewAPIError(status, code int, key string) *APIError {
 	return &APIError{
 		status,
 		code,
-		message,
+		key,
+		i18n.T(i18n.DefaultLocale, key),
 		nil,
 	}
 }
@@ -68,7 +141,12 @@ func (e *APIError) Error() string {
 	return e.Message
 }
 
+func (e *APIError) Localize(locale string) string {
+	return i18n.T(locale, e.Key)
+}
+
 func (e *APIError) WithData(data any) *APIError {
-	e.Data = data
-	return e
+	cp := *e
+	cp.Data = data
+	return &cp
 }
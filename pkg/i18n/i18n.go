@@ -0,0 +1,140 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	LocaleVI = "vi"
+	LocaleEN = "en"
+
+	DefaultLocale = LocaleEN
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var catalogs = loadCatalogs()
+
+type ctxKey struct{}
+
+func loadCatalogs() map[string]map[string]string {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	result := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Errorf("parse locale %s: %w", entry.Name(), err))
+		}
+
+		result[strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))] = messages
+	}
+
+	return result
+}
+
+func T(locale, key string, args ...any) string {
+	msg, ok := catalogs[Normalize(locale)][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+func Has(locale, key string) bool {
+	_, ok := catalogs[Normalize(locale)][key]
+	return ok
+}
+
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if idx := strings.IndexAny(locale, "-_"); idx != -1 {
+		locale = locale[:idx]
+	}
+
+	if IsSupported(locale) {
+		return locale
+	}
+	return DefaultLocale
+}
+
+func ParseAcceptLanguage(header string) (string, bool) {
+	type candidate struct {
+		locale string
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		locale := strings.ToLower(tag)
+		if idx := strings.IndexAny(locale, "-_"); idx != -1 {
+			locale = locale[:idx]
+		}
+		if q > 0 && IsSupported(locale) {
+			candidates = append(candidates, candidate{locale, q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	return candidates[0].locale, true
+}
+
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, Normalize(locale))
+}
+
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(ctxKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,173 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	tagOrientation = 0x0112
+	tagGPSInfo     = 0x8825
+)
+
+var typeSizes = map[uint16]uint64{
+	1:  1,
+	2:  1,
+	3:  2,
+	4:  4,
+	5:  8,
+	6:  1,
+	7:  1,
+	8:  2,
+	9:  4,
+	10: 8,
+	11: 4,
+	12: 8,
+}
+
+// Offsets are kept in uint64 so that offset and size arithmetic on values
+// read from the file cannot wrap around.
+type tiff struct {
+	data  []byte
+	order binary.ByteOrder
+	ifd0  uint64
+}
+
+// StripGPS returns a copy of a JPEG with the GPS IFD of its EXIF block blanked,
+// keeping the rest of the metadata (orientation, camera info) intact.
+func StripGPS(data []byte) ([]byte, bool) {
+	out := bytes.Clone(data)
+
+	t, ok := findTIFF(out)
+	if !ok {
+		return data, false
+	}
+
+	gpsEntry, ok := t.findEntry(t.ifd0, tagGPSInfo)
+	if !ok {
+		return data, false
+	}
+
+	gpsOffset := uint64(t.order.Uint32(t.data[gpsEntry+8:]))
+	if !t.inBounds(gpsOffset, 2) {
+		return data, false
+	}
+
+	count := uint64(t.order.Uint16(t.data[gpsOffset:]))
+	if !t.inBounds(gpsOffset+2, count*12) {
+		return data, false
+	}
+
+	for i := range count {
+		entry := gpsOffset + 2 + i*12
+		typ := t.order.Uint16(t.data[entry+2:])
+		size := typeSizes[typ] * uint64(t.order.Uint32(t.data[entry+4:]))
+		if size > 4 {
+			valueOffset := uint64(t.order.Uint32(t.data[entry+8:]))
+			if t.inBounds(valueOffset, size) {
+				clear(t.data[valueOffset : valueOffset+size])
+			}
+		}
+		clear(t.data[entry : entry+12])
+	}
+	t.order.PutUint16(t.data[gpsOffset:], 0)
+
+	return out, true
+}
+
+// Orientation returns the EXIF orientation (1-8) of a JPEG, or 1 when absent.
+func Orientation(data []byte) int {
+	t, ok := findTIFF(data)
+	if !ok {
+		return 1
+	}
+
+	entry, ok := t.findEntry(t.ifd0, tagOrientation)
+	if !ok {
+		return 1
+	}
+
+	orientation := int(t.order.Uint16(t.data[entry+8:]))
+	if orientation < 1 || orientation > 8 {
+		return 1
+	}
+	return orientation
+}
+
+func findTIFF(data []byte) (*tiff, bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, false
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil, false
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return nil, false
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return nil, false
+		}
+
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return parseTIFF(segment[6:])
+		}
+
+		pos += 2 + length
+	}
+
+	return nil, false
+}
+
+func parseTIFF(data []byte) (*tiff, bool) {
+	if len(data) < 8 {
+		return nil, false
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, false
+	}
+
+	if order.Uint16(data[2:]) != 42 {
+		return nil, false
+	}
+
+	t := &tiff{data, order, uint64(order.Uint32(data[4:]))}
+	if !t.inBounds(t.ifd0, 2) {
+		return nil, false
+	}
+
+	return t, true
+}
+
+func (t *tiff) findEntry(ifd uint64, tag uint16) (uint64, bool) {
+	count := uint64(t.order.Uint16(t.data[ifd:]))
+	if !t.inBounds(ifd+2, count*12) {
+		return 0, false
+	}
+
+	for i := range count {
+		entry := ifd + 2 + i*12
+		if t.order.Uint16(t.data[entry:]) == tag {
+			return entry, true
+		}
+	}
+
+	return 0, false
+}
+
+func (t *tiff) inBounds(offset, size uint64) bool {
+	return offset <= uint64(len(t.data)) && size <= uint64(len(t.data))-offset
+}
@@ -0,0 +1,287 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+type ifdEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value uint32
+}
+
+type exifFixture struct {
+	order binary.ByteOrder
+	ifd0  []ifdEntry
+	gps   []ifdEntry
+	// gpsOffset overrides the pointer stored in the GPSInfo entry.
+	gpsOffset uint32
+	// gpsData is appended after the GPS IFD; entries reference it through
+	// the gpsDataOffset placeholder.
+	gpsData []byte
+}
+
+const gpsDataOffset = 0xFFFFFFFF
+
+// tiff lays out the header, IFD0, the GPS IFD and the GPS values in that
+// order and returns the TIFF bytes with the offset of the GPS values.
+func (f exifFixture) tiff() ([]byte, uint32) {
+	var buf bytes.Buffer
+	order := f.order
+
+	if order == binary.LittleEndian {
+		buf.WriteString("II")
+	} else {
+		buf.WriteString("MM")
+	}
+	binary.Write(&buf, order, uint16(42))
+	binary.Write(&buf, order, uint32(8))
+
+	ifd0Size := uint32(2 + len(f.ifd0)*12 + 4)
+	gpsOffset := 8 + ifd0Size
+	dataOffset := gpsOffset + uint32(2+len(f.gps)*12+4)
+	if f.gps == nil {
+		dataOffset = gpsOffset
+	}
+
+	writeIFD := func(entries []ifdEntry) {
+		binary.Write(&buf, order, uint16(len(entries)))
+		for _, e := range entries {
+			value := e.value
+			switch {
+			case e.tag == tagGPSInfo && f.gpsOffset != 0:
+				value = f.gpsOffset
+			case e.tag == tagGPSInfo:
+				value = gpsOffset
+			case value == gpsDataOffset:
+				value = dataOffset
+			}
+
+			binary.Write(&buf, order, e.tag)
+			binary.Write(&buf, order, e.typ)
+			binary.Write(&buf, order, e.count)
+			if e.typ == 3 && e.count == 1 {
+				// A SHORT value sits left-aligned in the 4-byte field.
+				binary.Write(&buf, order, uint16(value))
+				binary.Write(&buf, order, uint16(0))
+			} else {
+				binary.Write(&buf, order, value)
+			}
+		}
+		binary.Write(&buf, order, uint32(0))
+	}
+
+	writeIFD(f.ifd0)
+	if f.gps != nil {
+		writeIFD(f.gps)
+	}
+	buf.Write(f.gpsData)
+
+	return buf.Bytes(), dataOffset
+}
+
+func (f exifFixture) jpeg() ([]byte, int) {
+	tiff, dataOffset := f.tiff()
+	return wrapJPEG(tiff), exifTIFFStart + int(dataOffset)
+}
+
+// exifTIFFStart is where the TIFF header begins inside wrapJPEG's output:
+// SOI, APP1 marker and length, then the "Exif\0\0" identifier.
+const exifTIFFStart = 2 + 4 + 6
+
+func wrapJPEG(tiff []byte) []byte {
+	var buf bytes.Buffer
+	buf.Write([]byte{0xFF, 0xD8, 0xFF, 0xE1})
+	binary.Write(&buf, binary.BigEndian, uint16(2+6+len(tiff)))
+	buf.WriteString("Exif\x00\x00")
+	buf.Write(tiff)
+	buf.Write([]byte{0xFF, 0xDA, 0x00, 0x02, 0xFF, 0xD9})
+	return buf.Bytes()
+}
+
+var gpsCoordinates = bytes.Repeat([]byte{0xAB}, 24)
+
+func gpsFixture(order binary.ByteOrder) exifFixture {
+	return exifFixture{
+		order: order,
+		ifd0: []ifdEntry{
+			{tag: tagOrientation, typ: 3, count: 1, value: 6},
+			{tag: tagGPSInfo, typ: 4, count: 1},
+		},
+		gps: []ifdEntry{
+			{tag: 0x0001, typ: 2, count: 2, value: 0x4E000000}, // GPSLatitudeRef "N", inline
+			{tag: 0x0002, typ: 5, count: 3, value: gpsDataOffset},
+		},
+		gpsData: gpsCoordinates,
+	}
+}
+
+func TestStripGPS(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		order binary.ByteOrder
+	}{
+		{"little endian", binary.LittleEndian},
+		{"big endian", binary.BigEndian},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, valuesAt := gpsFixture(tc.order).jpeg()
+			original := bytes.Clone(data)
+
+			stripped, ok := StripGPS(data)
+			if !ok {
+				t.Fatal("StripGPS reported no GPS data")
+			}
+			if !bytes.Equal(data, original) {
+				t.Fatal("StripGPS modified its input")
+			}
+			if len(stripped) != len(data) {
+				t.Fatalf("len = %d, want %d", len(stripped), len(data))
+			}
+
+			if got := stripped[valuesAt : valuesAt+len(gpsCoordinates)]; !bytes.Equal(got, make([]byte, len(gpsCoordinates))) {
+				t.Fatalf("GPS coordinates left in place: %x", got)
+			}
+
+			tr, ok := findTIFF(stripped)
+			if !ok {
+				t.Fatal("stripped EXIF no longer parses")
+			}
+			gpsEntry, _ := tr.findEntry(tr.ifd0, tagGPSInfo)
+			gpsIFD := tr.order.Uint32(tr.data[gpsEntry+8:])
+			if n := tr.order.Uint16(tr.data[gpsIFD:]); n != 0 {
+				t.Fatalf("GPS IFD still has %d entries", n)
+			}
+
+			if got := Orientation(stripped); got != 6 {
+				t.Fatalf("Orientation after strip = %d, want 6", got)
+			}
+		})
+	}
+}
+
+func TestStripGPSLeavesUnsafeInputAlone(t *testing.T) {
+	truncatedGPS := gpsFixture(binary.LittleEndian)
+	truncatedGPS.gps = append(truncatedGPS.gps, make([]ifdEntry, 40)...)
+	truncatedGPS.gpsData = nil
+
+	for _, tc := range []struct {
+		name string
+		data func() []byte
+	}{
+		{"not a jpeg", func() []byte { return []byte("GIF89a....") }},
+		{"jpeg without exif", func() []byte {
+			return []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x04, 'J', 'F', 0xFF, 0xDA, 0x00, 0x02}
+		}},
+		{"unknown byte order", func() []byte {
+			tiff, _ := gpsFixture(binary.LittleEndian).tiff()
+			copy(tiff, "XX")
+			return wrapJPEG(tiff)
+		}},
+		{"no gps ifd", func() []byte {
+			data, _ := exifFixture{
+				order: binary.BigEndian,
+				ifd0:  []ifdEntry{{tag: tagOrientation, typ: 3, count: 1, value: 3}},
+			}.jpeg()
+			return data
+		}},
+		{"truncated ifd0", func() []byte {
+			tiff, _ := gpsFixture(binary.LittleEndian).tiff()
+			binary.LittleEndian.PutUint16(tiff[8:], 500)
+			return wrapJPEG(tiff)
+		}},
+		{"truncated gps ifd", func() []byte {
+			tiff, _ := truncatedGPS.tiff()
+			return wrapJPEG(tiff[:len(tiff)-200])
+		}},
+		{"gps offset out of range", func() []byte {
+			f := gpsFixture(binary.LittleEndian)
+			f.gpsOffset = 0xFFFFFFF0
+			data, _ := f.jpeg()
+			return data
+		}},
+		{"ifd0 offset out of range", func() []byte {
+			tiff, _ := gpsFixture(binary.BigEndian).tiff()
+			binary.BigEndian.PutUint32(tiff[4:], 0xFFFFFFFF)
+			return wrapJPEG(tiff)
+		}},
+		{"segment longer than file", func() []byte {
+			data, _ := gpsFixture(binary.LittleEndian).jpeg()
+			return data[:20]
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.data()
+			original := bytes.Clone(data)
+
+			out, ok := StripGPS(data)
+			if ok {
+				t.Fatal("StripGPS reported success")
+			}
+			if !bytes.Equal(out, original) {
+				t.Fatal("StripGPS changed data it could not parse")
+			}
+		})
+	}
+}
+
+func TestStripGPSHandlesBadValueOffsets(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		entry ifdEntry
+	}{
+		// 8 * 0x20000001 wraps to 8 in 32-bit arithmetic.
+		{"huge count", ifdEntry{tag: 0x0002, typ: 5, count: 0x20000001, value: gpsDataOffset}},
+		{"max count", ifdEntry{tag: 0x0002, typ: 5, count: 0xFFFFFFFF, value: gpsDataOffset}},
+		{"value offset out of range", ifdEntry{tag: 0x0002, typ: 5, count: 3, value: 0xFFFFFFF0}},
+		{"unknown type", ifdEntry{tag: 0x0002, typ: 99, count: 3, value: gpsDataOffset}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := gpsFixture(binary.LittleEndian)
+			f.gps = []ifdEntry{tc.entry}
+			data, valuesAt := f.jpeg()
+
+			stripped, ok := StripGPS(data)
+			if !ok {
+				t.Fatal("StripGPS reported no GPS data")
+			}
+			if got := stripped[valuesAt : valuesAt+len(gpsCoordinates)]; !bytes.Equal(got, gpsCoordinates) {
+				t.Fatalf("bytes outside a valid value range were cleared: %x", got)
+			}
+		})
+	}
+}
+
+func TestOrientation(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data func() []byte
+		want int
+	}{
+		{"little endian", func() []byte { data, _ := gpsFixture(binary.LittleEndian).jpeg(); return data }, 6},
+		{"big endian", func() []byte { data, _ := gpsFixture(binary.BigEndian).jpeg(); return data }, 6},
+		{"missing tag", func() []byte {
+			data, _ := exifFixture{order: binary.LittleEndian, ifd0: []ifdEntry{{tag: 0x010F, typ: 2, count: 4}}}.jpeg()
+			return data
+		}, 1},
+		{"out of range value", func() []byte {
+			data, _ := exifFixture{order: binary.BigEndian, ifd0: []ifdEntry{{tag: tagOrientation, typ: 3, count: 1, value: 9}}}.jpeg()
+			return data
+		}, 1},
+		{"truncated ifd0", func() []byte {
+			tiff, _ := gpsFixture(binary.BigEndian).tiff()
+			binary.BigEndian.PutUint16(tiff[8:], 0xFFFF)
+			return wrapJPEG(tiff)
+		}, 1},
+		{"not a jpeg", func() []byte { return []byte{0x89, 'P', 'N', 'G'} }, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Orientation(tc.data()); got != tc.want {
+				t.Fatalf("Orientation = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+
+	maxPixels = 50_000_000
+)
+
+var ErrImageTooLarge = errors.New("image dimensions exceed limit")
+
+var contentTypes = map[string]string{
+	FormatJPEG: "image/jpeg",
+	FormatPNG:  "image/png",
+	FormatWebP: "image/webp",
+}
+
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+func Decode(data []byte) (image.Image, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, "", ErrImageTooLarge
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return img, format, nil
+}
+
+// Fit scales img down so that neither side exceeds maxSize; smaller images are
+// returned unchanged.
+func Fit(img image.Image, maxSize int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxSize && h <= maxSize {
+		return img
+	}
+
+	if w >= h {
+		h = max(1, h*maxSize/w)
+		w = maxSize
+	} else {
+		w = max(1, w*maxSize/h)
+		h = maxSize
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+
+	return dst
+}
+
+// Orient applies an EXIF orientation so the pixels are stored upright.
+func Orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := range h {
+		for x := range w {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+
+	return dst
+}
+
+func Encode(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var err error
+	switch format {
+	case FormatPNG:
+		err = png.Encode(&buf, img)
+	case FormatWebP:
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,20 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+func WithContext(ctx context.Context, log *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, log)
+}
+
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if log, ok := ctx.Value(ctxKey{}).(*zap.Logger); ok && log != nil {
+		return log
+	}
+	return fallback
+}
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
 	"github.com/InstaySystem/is_v2-be/internal/domain/model"
 )
@@ -26,6 +28,7 @@ func ToBasicUserResponse(usr *model.User) *dto.BasicUserResponse {
 		Username:  usr.Username,
 		FirstName: usr.FirstName,
 		LastName:  usr.LastName,
+		AvatarURL: avatarURL(usr),
 	}
 }
 
@@ -35,16 +38,19 @@ func ToUserResponse(usr *model.User) *dto.UserResponse {
 	}
 
 	return &dto.UserResponse{
-		ID:         usr.ID,
-		Email:      usr.Email,
-		Phone:      usr.Phone,
-		Username:   usr.Username,
-		FirstName:  usr.FirstName,
-		LastName:   usr.LastName,
-		Role:       usr.Role,
-		IsActive:   usr.IsActive,
-		CreatedAt:  usr.CreatedAt,
-		Department: ToBasicDepartmentResponse(usr.Department),
+		ID:                 usr.ID,
+		Email:              usr.Email,
+		Phone:              usr.Phone,
+		Username:           usr.Username,
+		FirstName:          usr.FirstName,
+		LastName:           usr.LastName,
+		Role:               usr.Role,
+		IsActive:           usr.IsActive,
+		Locale:             usr.Locale,
+		AvatarURL:          avatarURL(usr),
+		EmailNotifications: usr.EmailNotifications,
+		CreatedAt:          usr.CreatedAt,
+		Department:         ToBasicDepartmentResponse(usr.Department),
 	}
 }
 
@@ -62,6 +68,7 @@ func ToUserDetailsResponse(usr *model.User) *dto.UserDetailsResponse {
 		LastName:   usr.LastName,
 		Role:       usr.Role,
 		IsActive:   usr.IsActive,
+		AvatarURL:  avatarURL(usr),
 		CreatedAt:  usr.CreatedAt,
 		UpdatedAt:  usr.UpdatedAt,
 		Department: ToBasicDepartmentResponse(usr.Department),
@@ -81,6 +88,7 @@ func ToSimpleUserResponse(usr *model.User) *dto.SimpleUserResponse {
 		LastName:   usr.LastName,
 		Role:       usr.Role,
 		IsActive:   usr.IsActive,
+		AvatarURL:  avatarURL(usr),
 		CreatedAt:  usr.CreatedAt,
 		Department: ToBasicDepartmentResponse(usr.Department),
 	}
@@ -98,3 +106,339 @@ func ToSimpleUsersResponse(usrs []*model.User) []*dto.SimpleUserResponse {
 
 	return usersRes
 }
+
+func ToFileResponse(file *model.File) *dto.FileResponse {
+	if file == nil {
+		return nil
+	}
+
+	return &dto.FileResponse{
+		ID:           file.ID,
+		Key:          file.Key,
+		OriginalName: file.OriginalName,
+		ContentType:  file.ContentType,
+		Purpose:      file.Purpose,
+		Size:         file.Size,
+		Checksum:     file.Checksum,
+		Status:       file.Status,
+		ConfirmedAt:  file.ConfirmedAt,
+		CreatedAt:    file.CreatedAt,
+	}
+}
+
+func ToFilesResponse(files []*model.File) []*dto.FileResponse {
+	if len(files) == 0 {
+		return make([]*dto.FileResponse, 0)
+	}
+
+	filesRes := make([]*dto.FileResponse, 0, len(files))
+	for _, file := range files {
+		filesRes = append(filesRes, ToFileResponse(file))
+	}
+
+	return filesRes
+}
+
+func ToNotificationResponse(noti *model.Notification) *dto.NotificationResponse {
+	if noti == nil {
+		return nil
+	}
+
+	return &dto.NotificationResponse{
+		ID:        noti.ID,
+		Type:      noti.Type,
+		Title:     noti.Title,
+		Body:      noti.Body,
+		Link:      noti.Link,
+		ReadAt:    noti.ReadAt,
+		CreatedAt: noti.CreatedAt,
+	}
+}
+
+func ToNotificationsResponse(notis []*model.Notification) []*dto.NotificationResponse {
+	if len(notis) == 0 {
+		return make([]*dto.NotificationResponse, 0)
+	}
+
+	notisRes := make([]*dto.NotificationResponse, 0, len(notis))
+	for _, noti := range notis {
+		notisRes = append(notisRes, ToNotificationResponse(noti))
+	}
+
+	return notisRes
+}
+
+func ToEmailLogResponse(emailLog *model.EmailLog) *dto.EmailLogResponse {
+	if emailLog == nil {
+		return nil
+	}
+
+	return &dto.EmailLogResponse{
+		ID:        emailLog.ID,
+		MessageID: emailLog.MessageID,
+		Recipient: emailLog.Recipient,
+		Template:  emailLog.Template,
+		Status:    emailLog.Status,
+		Attempts:  emailLog.Attempts,
+		Response:  emailLog.Response,
+		SentAt:    emailLog.SentAt,
+		CreatedAt: emailLog.CreatedAt,
+		UpdatedAt: emailLog.UpdatedAt,
+	}
+}
+
+func ToEmailLogsResponse(emailLogs []*model.EmailLog) []*dto.EmailLogResponse {
+	if len(emailLogs) == 0 {
+		return make([]*dto.EmailLogResponse, 0)
+	}
+
+	emailLogsRes := make([]*dto.EmailLogResponse, 0, len(emailLogs))
+	for _, emailLog := range emailLogs {
+		emailLogsRes = append(emailLogsRes, ToEmailLogResponse(emailLog))
+	}
+
+	return emailLogsRes
+}
+
+func ToEmailSuppressionResponse(suppression *model.EmailSuppression) *dto.EmailSuppressionResponse {
+	if suppression == nil {
+		return nil
+	}
+
+	return &dto.EmailSuppressionResponse{
+		ID:        suppression.ID,
+		Email:     suppression.Email,
+		Reason:    suppression.Reason,
+		CreatedAt: suppression.CreatedAt,
+	}
+}
+
+func ToEmailSuppressionsResponse(suppressions []*model.EmailSuppression) []*dto.EmailSuppressionResponse {
+	if len(suppressions) == 0 {
+		return make([]*dto.EmailSuppressionResponse, 0)
+	}
+
+	suppressionsRes := make([]*dto.EmailSuppressionResponse, 0, len(suppressions))
+	for _, suppression := range suppressions {
+		suppressionsRes = append(suppressionsRes, ToEmailSuppressionResponse(suppression))
+	}
+
+	return suppressionsRes
+}
+
+func ToRoleSettingResponse(setting *model.RoleSetting) *dto.RoleSettingResponse {
+	if setting == nil {
+		return nil
+	}
+
+	return &dto.RoleSettingResponse{
+		Role:             string(setting.Role),
+		MagicLinkEnabled: setting.MagicLinkEnabled,
+		UpdatedAt:        setting.UpdatedAt,
+	}
+}
+
+func ToRoleSettingsResponse(settings []*model.RoleSetting) []*dto.RoleSettingResponse {
+	if len(settings) == 0 {
+		return make([]*dto.RoleSettingResponse, 0)
+	}
+
+	settingsRes := make([]*dto.RoleSettingResponse, 0, len(settings))
+	for _, setting := range settings {
+		settingsRes = append(settingsRes, ToRoleSettingResponse(setting))
+	}
+
+	return settingsRes
+}
+
+func avatarURL(usr *model.User) *string {
+	if usr.AvatarURL == "" {
+		return nil
+	}
+	return &usr.AvatarURL
+}
+
+func ToImpersonationSessionResponse(session *model.ImpersonationSession) *dto.ImpersonationSessionResponse {
+	if session == nil {
+		return nil
+	}
+
+	return &dto.ImpersonationSessionResponse{
+		ID:        session.ID,
+		Admin:     ToBasicUserResponse(session.Admin),
+		User:      ToBasicUserResponse(session.User),
+		Reason:    session.Reason,
+		IPAddress: session.IPAddress,
+		UserAgent: session.UserAgent,
+		ExpiresAt: session.ExpiresAt,
+		EndedAt:   session.EndedAt,
+		CreatedAt: session.CreatedAt,
+	}
+}
+
+func ToImpersonationSessionsResponse(sessions []*model.ImpersonationSession) []*dto.ImpersonationSessionResponse {
+	if len(sessions) == 0 {
+		return make([]*dto.ImpersonationSessionResponse, 0)
+	}
+
+	sessionsRes := make([]*dto.ImpersonationSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		sessionsRes = append(sessionsRes, ToImpersonationSessionResponse(session))
+	}
+
+	return sessionsRes
+}
+
+func ToPropertyResponse(property *model.Property) *dto.PropertyResponse {
+	if property == nil {
+		return nil
+	}
+
+	return &dto.PropertyResponse{
+		ID:        property.ID,
+		Code:      property.Code,
+		Name:      property.Name,
+		Address:   property.Address,
+		IsActive:  property.IsActive,
+		CreatedAt: property.CreatedAt,
+		UpdatedAt: property.UpdatedAt,
+	}
+}
+
+func ToPropertiesResponse(properties []*model.Property) []*dto.PropertyResponse {
+	if len(properties) == 0 {
+		return make([]*dto.PropertyResponse, 0)
+	}
+
+	propertiesRes := make([]*dto.PropertyResponse, 0, len(properties))
+	for _, property := range properties {
+		propertiesRes = append(propertiesRes, ToPropertyResponse(property))
+	}
+
+	return propertiesRes
+}
+
+func ToBasicShiftResponse(shift *model.Shift) *dto.BasicShiftResponse {
+	if shift == nil {
+		return nil
+	}
+
+	return &dto.BasicShiftResponse{
+		ID:       shift.ID,
+		Name:     shift.Name,
+		StartsAt: shift.StartsAt,
+		EndsAt:   shift.EndsAt,
+	}
+}
+
+func ToShiftResponse(shift *model.Shift) *dto.ShiftResponse {
+	if shift == nil {
+		return nil
+	}
+
+	return &dto.ShiftResponse{
+		ID:          shift.ID,
+		Name:        shift.Name,
+		StartsAt:    shift.StartsAt,
+		EndsAt:      shift.EndsAt,
+		Note:        shift.Note,
+		Department:  ToBasicDepartmentResponse(shift.Department),
+		Assignments: ToShiftAssignmentsResponse(shift.Assignments),
+		CreatedAt:   shift.CreatedAt,
+		UpdatedAt:   shift.UpdatedAt,
+	}
+}
+
+func ToShiftsResponse(shifts []*model.Shift) []*dto.ShiftResponse {
+	if len(shifts) == 0 {
+		return make([]*dto.ShiftResponse, 0)
+	}
+
+	shiftsRes := make([]*dto.ShiftResponse, 0, len(shifts))
+	for _, shift := range shifts {
+		shiftsRes = append(shiftsRes, ToShiftResponse(shift))
+	}
+
+	return shiftsRes
+}
+
+func ToShiftAssignmentResponse(assignment *model.ShiftAssignment) *dto.ShiftAssignmentResponse {
+	if assignment == nil {
+		return nil
+	}
+
+	return &dto.ShiftAssignmentResponse{
+		ID:        assignment.ID,
+		Role:      assignment.Role,
+		User:      ToBasicUserResponse(assignment.User),
+		CreatedAt: assignment.CreatedAt,
+	}
+}
+
+func ToShiftAssignmentsResponse(assignments []*model.ShiftAssignment) []*dto.ShiftAssignmentResponse {
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	assignmentsRes := make([]*dto.ShiftAssignmentResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		assignmentsRes = append(assignmentsRes, ToShiftAssignmentResponse(assignment))
+	}
+
+	return assignmentsRes
+}
+
+func ToShiftConflictsResponse(assignments []*model.ShiftAssignment) []*dto.ShiftConflictResponse {
+	conflictsRes := make([]*dto.ShiftConflictResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		conflictsRes = append(conflictsRes, &dto.ShiftConflictResponse{
+			UserID: assignment.UserID,
+			Shift:  ToBasicShiftResponse(assignment.Shift),
+		})
+	}
+
+	return conflictsRes
+}
+
+func ToOnDutyResponse(assignments []*model.ShiftAssignment) []*dto.OnDutyResponse {
+	onDutyRes := make([]*dto.OnDutyResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		onDutyRes = append(onDutyRes, &dto.OnDutyResponse{
+			User:  ToBasicUserResponse(assignment.User),
+			Role:  assignment.Role,
+			Shift: ToBasicShiftResponse(assignment.Shift),
+		})
+	}
+
+	return onDutyRes
+}
+
+// ToShiftRosterResponse lays shifts, ordered by start, out over the seven
+// days from weekStart. A shift belongs to the day it starts on; one carried
+// over from the previous week is listed on the first day.
+func ToShiftRosterResponse(weekStart time.Time, shifts []*model.Shift) *dto.ShiftRosterResponse {
+	days := make([]*dto.RosterDayResponse, 7)
+	for i := range days {
+		days[i] = &dto.RosterDayResponse{
+			Date:   weekStart.AddDate(0, 0, i).Format("2006-01-02"),
+			Shifts: make([]*dto.ShiftResponse, 0),
+		}
+	}
+
+	for _, shift := range shifts {
+		day := 0
+		for i := len(days) - 1; i > 0; i-- {
+			if !shift.StartsAt.Before(weekStart.AddDate(0, 0, i)) {
+				day = i
+				break
+			}
+		}
+		days[day].Shifts = append(days[day].Shifts, ToShiftResponse(shift))
+	}
+
+	return &dto.ShiftRosterResponse{
+		WeekStart: weekStart,
+		WeekEnd:   weekStart.AddDate(0, 0, 7),
+		Days:      days,
+	}
+}
@@ -0,0 +1,135 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	RuleMinLength = "min_length"
+	RuleUpper     = "upper"
+	RuleLower     = "lower"
+	RuleDigit     = "digit"
+	RuleSymbol    = "symbol"
+	RuleCommon    = "common"
+	RuleUsername  = "username"
+	RuleReused    = "reused"
+
+	defaultMinLength = 8
+)
+
+//go:embed common.txt
+var commonList string
+
+var commonPasswords = loadCommonPasswords()
+
+type Settings struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	CheckCommon   bool
+	HistorySize   int
+	MaxAge        time.Duration
+}
+
+type Policy struct {
+	cfg Settings
+}
+
+func NewPolicy(cfg Settings) *Policy {
+	if cfg.MinLength <= 0 {
+		cfg.MinLength = defaultMinLength
+	}
+	return &Policy{cfg}
+}
+
+func (p *Policy) MinLength() int {
+	return p.cfg.MinLength
+}
+
+func (p *Policy) HistorySize() int {
+	return p.cfg.HistorySize
+}
+
+func (p *Policy) IsExpired(changedAt time.Time) bool {
+	if p.cfg.MaxAge <= 0 {
+		return false
+	}
+	return time.Since(changedAt) > p.cfg.MaxAge
+}
+
+func (p *Policy) CheckComplexity(password string) []string {
+	var violations []string
+
+	if len([]rune(password)) < p.cfg.MinLength {
+		violations = append(violations, RuleMinLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, ch := range password {
+		switch {
+		case unicode.IsUpper(ch):
+			hasUpper = true
+		case unicode.IsLower(ch):
+			hasLower = true
+		case unicode.IsDigit(ch):
+			hasDigit = true
+		case unicode.IsPunct(ch) || unicode.IsSymbol(ch):
+			hasSymbol = true
+		}
+	}
+
+	if p.cfg.RequireUpper && !hasUpper {
+		violations = append(violations, RuleUpper)
+	}
+	if p.cfg.RequireLower && !hasLower {
+		violations = append(violations, RuleLower)
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		violations = append(violations, RuleDigit)
+	}
+	if p.cfg.RequireSymbol && !hasSymbol {
+		violations = append(violations, RuleSymbol)
+	}
+
+	return violations
+}
+
+func (p *Policy) Check(password, username string) []string {
+	violations := p.CheckComplexity(password)
+
+	if p.cfg.CheckCommon && IsCommon(password) {
+		violations = append(violations, RuleCommon)
+	}
+
+	if username != "" && strings.EqualFold(password, username) {
+		violations = append(violations, RuleUsername)
+	}
+
+	return violations
+}
+
+func IsCommon(password string) bool {
+	_, ok := commonPasswords[strings.ToLower(password)]
+	return ok
+}
+
+func loadCommonPasswords() map[string]struct{} {
+	result := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(strings.NewReader(commonList))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		result[strings.ToLower(line)] = struct{}{}
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+package tenant
+
+import "context"
+
+// Tenant is the property a request operates on. A zero PropertyID means the
+// request is not bound to a property: a chain admin who has not selected one,
+// or an account from before properties existed.
+type Tenant struct {
+	PropertyID int64
+	ChainAdmin bool
+}
+
+type ctxKey struct{}
+
+type unscopedKey struct{}
+
+func WithContext(ctx context.Context, t Tenant) context.Context {
+	return context.WithValue(ctx, ctxKey{}, t)
+}
+
+func FromContext(ctx context.Context) (Tenant, bool) {
+	t, ok := ctx.Value(ctxKey{}).(Tenant)
+	return t, ok
+}
+
+// PropertyID returns the property ctx is scoped to, or 0 when queries made
+// with ctx are not filtered.
+func PropertyID(ctx context.Context) int64 {
+	if IsUnscoped(ctx) {
+		return 0
+	}
+	t, _ := FromContext(ctx)
+	return t.PropertyID
+}
+
+// IsChainAdmin reports whether the request was made by a chain admin.
+func IsChainAdmin(ctx context.Context) bool {
+	t, _ := FromContext(ctx)
+	return t.ChainAdmin
+}
+
+// Unscoped disables property filtering for queries made with the returned
+// context, e.g. when a chain admin who has selected a property reads their
+// own account.
+func Unscoped(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unscopedKey{}, true)
+}
+
+func IsUnscoped(ctx context.Context) bool {
+	unscoped, _ := ctx.Value(unscopedKey{}).(bool)
+	return unscoped
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/InstaySystem/is_v2-be/internal/application/dto"
 	"github.com/InstaySystem/is_v2-be/pkg/constants"
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
 	"github.com/gin-gonic/gin"
 	"github.com/gosimple/slug"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -27,11 +28,11 @@ func APIResponse(c *gin.Context, status, code int, message string, data any) {
 }
 
 func ISEResponse(c *gin.Context) {
-	APIResponse(c, http.StatusInternalServerError, constants.CodeInternalError, "Internal server error", nil)
+	APIResponse(c, http.StatusInternalServerError, constants.CodeInternalError, i18n.T(i18n.FromContext(c.Request.Context()), "error.internal_server"), nil)
 }
 
 func BadRequestResponse(c *gin.Context) {
-	APIResponse(c, http.StatusBadRequest, constants.CodeBadRequest, "Invalid data", nil)
+	APIResponse(c, http.StatusBadRequest, constants.CodeBadRequest, i18n.T(i18n.FromContext(c.Request.Context()), "error.bad_request"), nil)
 }
 
 func OKResponse(c *gin.Context, data any) {
@@ -4,26 +4,148 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 
+	"github.com/InstaySystem/is_v2-be/pkg/i18n"
+	"github.com/InstaySystem/is_v2-be/pkg/password"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
-func HandleRequestError(err error) (string, string, string) {
-	if errs, ok := err.(validator.ValidationErrors); ok {
-		e := errs[0]
-		return strings.ToLower(e.Field()), e.Tag(), e.Param()
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Param   string `json:"param"`
+	Message string `json:"message"`
+}
+
+var vnPhoneRegex = regexp.MustCompile(`^0(3|5|7|8|9)[0-9]{8}$`)
+
+var subdomainRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,48}[a-z0-9])?$`)
+
+func Register(policy *password.Policy) error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("unsupported validator engine")
+	}
+
+	v.RegisterTagNameFunc(fieldName)
+
+	if err := v.RegisterValidation("vnphone", validateVNPhone); err != nil {
+		return err
+	}
+
+	if err := v.RegisterValidation("subdomain", validateSubdomain); err != nil {
+		return err
+	}
+
+	if err := v.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		return len(policy.CheckComplexity(fl.Field().String())) == 0
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func HandleRequestError(locale string, err error) []*FieldError {
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		result := make([]*FieldError, 0, len(errs))
+		for _, e := range errs {
+			result = append(result, &FieldError{
+				Field:   fieldPath(e),
+				Tag:     e.Tag(),
+				Param:   e.Param(),
+				Message: message(locale, messageKey(e), formatParam(e)),
+			})
+		}
+		return result
 	}
 
 	var unmarshalTypeError *json.UnmarshalTypeError
 	if errors.As(err, &unmarshalTypeError) {
-		return unmarshalTypeError.Field, "type_error", unmarshalTypeError.Type.String()
+		return []*FieldError{NewFieldError(locale, unmarshalTypeError.Field, "type_error", unmarshalTypeError.Type.String())}
 	}
 
 	var syntaxError *json.SyntaxError
 	if errors.As(err, &syntaxError) {
-		return "json", "syntax_error", fmt.Sprintf("%d", syntaxError.Offset)
+		return []*FieldError{NewFieldError(locale, "json", "syntax_error", fmt.Sprintf("%d", syntaxError.Offset))}
+	}
+
+	return []*FieldError{NewFieldError(locale, "unknown", "invalid", "")}
+}
+
+func NewFieldError(locale, field, tag, param string) *FieldError {
+	return &FieldError{
+		Field:   field,
+		Tag:     tag,
+		Param:   param,
+		Message: message(locale, "validation."+tag, param),
 	}
+}
+
+func message(locale, key, param string) string {
+	if !i18n.Has(locale, key) {
+		return i18n.T(locale, "validation.default")
+	}
+
+	if param != "" {
+		return i18n.T(locale, key, param)
+	}
+	return i18n.T(locale, key)
+}
+
+func messageKey(e validator.FieldError) string {
+	key := "validation." + e.Tag()
+
+	switch e.Tag() {
+	case "min", "max", "len":
+		switch e.Kind() {
+		case reflect.String:
+			return key + "_string"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return key + "_items"
+		}
+	}
+
+	return key
+}
+
+func formatParam(e validator.FieldError) string {
+	if e.Tag() == "oneof" {
+		return strings.Join(strings.Fields(e.Param()), ", ")
+	}
+	return e.Param()
+}
+
+func fieldName(fld reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(fld.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return fld.Name
+}
+
+func fieldPath(e validator.FieldError) string {
+	ns := e.Namespace()
+	if _, path, ok := strings.Cut(ns, "."); ok {
+		return path
+	}
+	return e.Field()
+}
+
+func validateVNPhone(fl validator.FieldLevel) bool {
+	return vnPhoneRegex.MatchString(fl.Field().String())
+}
 
-	return "unknown", "invalid", ""
-}
\ No newline at end of file
+func validateSubdomain(fl validator.FieldLevel) bool {
+	return subdomainRegex.MatchString(fl.Field().String())
+}